@@ -35,7 +35,12 @@ func init() {
 	app.Usage = "The Scroll chain monitor"
 	app.Version = utils.Version
 	app.Flags = append(app.Flags, utils.CommonFlags...)
-	app.Commands = []*cli.Command{}
+	app.Commands = []*cli.Command{
+		snapshotBaselineCommand,
+		verifyTrieCommand,
+		rebaseMessengerBalanceCommand,
+		silenceCommand,
+	}
 	app.Before = func(ctx *cli.Context) error {
 		return utils.LogSetup(ctx)
 	}
@@ -83,15 +88,33 @@ func action(ctx *cli.Context) error {
 
 	observability.Server(ctx, db)
 
-	slackAlert := controller.NewSlackAlertController(subCtx, cfg.AlertConfig)
+	slackAlert := controller.NewSlackAlertController(subCtx, cfg.AlertConfig, cfg.AlertRoutingConfig, db)
 	slackAlert.Start()
 
+	alertSinkCtl := controller.NewAlertSinkController(subCtx, cfg)
+	alertSinkCtl.Start()
+
 	contractCtl := controller.NewContractController(cfg, db, l1Client, l2Client)
 	contractCtl.Watch(subCtx)
 
 	crossChainCtl := controller.NewCrossChainController(cfg, db, ethclient.NewClient(l1Client), ethclient.NewClient(l2Client))
 	crossChainCtl.Watch(subCtx)
 
+	proxyDriftCtl := controller.NewProxyDriftController(cfg, db, ethclient.NewClient(l1Client), ethclient.NewClient(l2Client))
+	proxyDriftCtl.Watch(subCtx)
+
+	reserveCtl := controller.NewReserveController(cfg, db, ethclient.NewClient(l1Client), ethclient.NewClient(l2Client))
+	reserveCtl.Watch(subCtx)
+
+	messengerBalanceCtl := controller.NewMessengerBalanceController(cfg, db, ethclient.NewClient(l1Client), ethclient.NewClient(l2Client))
+	messengerBalanceCtl.Watch(subCtx)
+
+	invariantCtl := controller.NewInvariantController(cfg, ethclient.NewClient(l1Client), ethclient.NewClient(l2Client))
+	invariantCtl.Watch(subCtx)
+
+	reportCtl := controller.NewReportController(cfg, db)
+	reportCtl.Watch(subCtx)
+
 	apiSrv := apiServer(ctx, cfg, db)
 
 	log.Info("Start chain-monitor successfully.")
@@ -99,6 +122,12 @@ func action(ctx *cli.Context) error {
 	defer func() {
 		contractCtl.Stop()
 		crossChainCtl.Stop()
+		proxyDriftCtl.Stop()
+		reserveCtl.Stop()
+		messengerBalanceCtl.Stop()
+		invariantCtl.Stop()
+		reportCtl.Stop()
+		alertSinkCtl.Stop()
 		slackAlert.Stop()
 		if err = database.CloseDB(db); err != nil {
 			log.Error("failed to close database", "err", err)
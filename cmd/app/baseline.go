@@ -0,0 +1,61 @@
+package app
+
+import (
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/governance"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+	"github.com/scroll-tech/chain-monitor/internal/utils/database"
+)
+
+var snapshotBaselineCommand = &cli.Command{
+	Name:   "snapshot-baseline",
+	Usage:  "Pin the current eip1967 proxy state of the configured bridge contracts as the drift check baseline",
+	Action: snapshotBaseline,
+}
+
+func snapshotBaseline(ctx *cli.Context) error {
+	cfgFile := ctx.String(utils.ConfigFileFlag.Name)
+	cfg, err := config.NewConfig(cfgFile)
+	if err != nil {
+		log.Crit("failed to load config file", "config file", cfgFile, "error", err)
+	}
+
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		log.Crit("failed to connect to db", "err", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close database", "err", err)
+		}
+	}()
+
+	l1Client, err := ethclient.Dial(cfg.L1Config.L1URL)
+	if err != nil {
+		log.Crit("failed to connect to l1 geth", "l1 geth url", cfg.L1Config.L1URL, "err", err)
+	}
+
+	l2Client, err := ethclient.Dial(cfg.L2Config.L2URL)
+	if err != nil {
+		log.Crit("failed to connect to l2 geth", "l2 geth url", cfg.L2Config.L2URL, "err", err)
+	}
+
+	proxyDriftLogic := governance.NewLogicProxyDrift(cfg, db, l1Client, l2Client)
+	for _, layer := range []types.LayerType{types.Layer1, types.Layer2} {
+		baselines, snapshotErr := proxyDriftLogic.SnapshotBaseline(ctx.Context, layer)
+		if snapshotErr != nil {
+			return snapshotErr
+		}
+		for _, baseline := range baselines {
+			log.Info("pinned contract baseline", "layer", layer, "contract", baseline.Name, "address", baseline.ContractAddress,
+				"implementation", baseline.Implementation, "admin", baseline.Admin, "code hash", baseline.CodeHash,
+				"implementation code hash", baseline.ImplementationCodeHash, "block number", baseline.BlockNumber)
+		}
+	}
+	return nil
+}
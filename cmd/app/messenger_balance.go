@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	crosschain "github.com/scroll-tech/chain-monitor/internal/logic/cross_chain"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+	"github.com/scroll-tech/chain-monitor/internal/utils/database"
+)
+
+var (
+	rebaseLayerFlag = cli.StringFlag{
+		Name:     "layer",
+		Usage:    "The layer of the messenger to rebase, l1 or l2",
+		Required: true,
+	}
+	rebaseBalanceFlag = cli.StringFlag{
+		Name:  "balance",
+		Usage: "The trusted messenger eth balance in wei at the latest tracked block, the balance queried from the configured node if unset",
+	}
+)
+
+var rebaseMessengerBalanceCommand = &cli.Command{
+	Name:   "rebase-messenger-balance",
+	Usage:  "Rebase the tracked messenger eth balance of a layer from a trusted snapshot",
+	Flags:  []cli.Flag{&rebaseLayerFlag, &rebaseBalanceFlag},
+	Action: rebaseMessengerBalance,
+}
+
+func rebaseMessengerBalance(ctx *cli.Context) error {
+	var layer types.LayerType
+	switch ctx.String(rebaseLayerFlag.Name) {
+	case "l1":
+		layer = types.Layer1
+	case "l2":
+		layer = types.Layer2
+	default:
+		return fmt.Errorf("invalid layer: %s, expected l1 or l2", ctx.String(rebaseLayerFlag.Name))
+	}
+
+	var balance *big.Int
+	if ctx.IsSet(rebaseBalanceFlag.Name) {
+		var ok bool
+		balance, ok = new(big.Int).SetString(ctx.String(rebaseBalanceFlag.Name), 10)
+		if !ok || balance.Sign() < 0 {
+			return fmt.Errorf("invalid balance: %s", ctx.String(rebaseBalanceFlag.Name))
+		}
+	}
+
+	cfgFile := ctx.String(utils.ConfigFileFlag.Name)
+	cfg, err := config.NewConfig(cfgFile)
+	if err != nil {
+		log.Crit("failed to load config file", "config file", cfgFile, "error", err)
+	}
+
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		log.Crit("failed to connect to db", "err", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close database", "err", err)
+		}
+	}()
+
+	l1Client, err := ethclient.Dial(cfg.L1Config.L1URL)
+	if err != nil {
+		log.Crit("failed to connect to l1 geth", "l1 geth url", cfg.L1Config.L1URL, "err", err)
+	}
+
+	l2Client, err := ethclient.Dial(cfg.L2Config.L2URL)
+	if err != nil {
+		log.Crit("failed to connect to l2 geth", "l2 geth url", cfg.L2Config.L2URL, "err", err)
+	}
+
+	messengerBalanceLogic := crosschain.NewLogicMessengerBalance(db, l1Client, l2Client, cfg.L1Config.L1Contracts.ScrollMessenger, cfg.L2Config.L2Contracts.ScrollMessenger)
+	blockNumber, previousBalance, rebasedBalance, rebaseErr := messengerBalanceLogic.RebaseETHBalance(ctx.Context, layer, balance)
+	if rebaseErr != nil {
+		return rebaseErr
+	}
+	log.Info("rebased messenger eth balance", "layer", layer, "block number", blockNumber, "previous balance", previousBalance.String(), "balance", rebasedBalance.String())
+	return nil
+}
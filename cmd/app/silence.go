@@ -0,0 +1,173 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/silence"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+	"github.com/scroll-tech/chain-monitor/internal/utils/database"
+)
+
+var (
+	silenceKindFlag = cli.StringFlag{
+		Name:  "kind",
+		Usage: "The alert kind to silence, e.g. proxy_drift",
+	}
+	silenceLayerFlag = cli.StringFlag{
+		Name:  "layer",
+		Usage: "The layer of the alerts to silence, l1 or l2",
+	}
+	silenceTokenFlag = cli.StringFlag{
+		Name:  "token",
+		Usage: "The token address or symbol of the alerts to silence",
+	}
+	silenceAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "The contract or account address of the alerts to silence",
+	}
+	silenceStartFlag = cli.StringFlag{
+		Name:  "start",
+		Usage: "The start time of the silence in RFC3339, e.g. 2026-10-19T08:00:00Z, now if unset",
+	}
+	silenceEndFlag = cli.StringFlag{
+		Name:  "end",
+		Usage: "The end time of the silence in RFC3339, either end or duration is required",
+	}
+	silenceDurationFlag = cli.DurationFlag{
+		Name:  "duration",
+		Usage: "The duration of the silence from its start, e.g. 2h",
+	}
+	silenceCreatedByFlag = cli.StringFlag{
+		Name:     "created-by",
+		Usage:    "The creator of the silence",
+		Required: true,
+	}
+	silenceReasonFlag = cli.StringFlag{
+		Name:     "reason",
+		Usage:    "The reason of the silence, e.g. the contract upgrade or node maintenance",
+		Required: true,
+	}
+	silenceIDFlag = cli.Int64Flag{
+		Name:     "id",
+		Usage:    "The id of the silence",
+		Required: true,
+	}
+)
+
+var silenceCommand = &cli.Command{
+	Name:  "silence",
+	Usage: "Create, list and expire the alert silences",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "create",
+			Usage: "Silence the alerts matching the kind, layer, token and address between the start and end time",
+			Flags: []cli.Flag{&silenceKindFlag, &silenceLayerFlag, &silenceTokenFlag, &silenceAddressFlag, &silenceStartFlag,
+				&silenceEndFlag, &silenceDurationFlag, &silenceCreatedByFlag, &silenceReasonFlag},
+			Action: createSilence,
+		},
+		{
+			Name:   "list",
+			Usage:  "List the active and pending alert silences",
+			Action: listSilences,
+		},
+		{
+			Name:   "expire",
+			Usage:  "Expire the alert silence now",
+			Flags:  []cli.Flag{&silenceIDFlag},
+			Action: expireSilence,
+		},
+	},
+}
+
+func createSilence(ctx *cli.Context) error {
+	startsAt := utils.NowUTC()
+	if ctx.IsSet(silenceStartFlag.Name) {
+		var err error
+		if startsAt, err = time.Parse(time.RFC3339, ctx.String(silenceStartFlag.Name)); err != nil {
+			return fmt.Errorf("invalid start: %w", err)
+		}
+	}
+
+	var endsAt time.Time
+	switch {
+	case ctx.IsSet(silenceEndFlag.Name) == ctx.IsSet(silenceDurationFlag.Name):
+		return fmt.Errorf("either end or duration is required")
+	case ctx.IsSet(silenceEndFlag.Name):
+		var err error
+		if endsAt, err = time.Parse(time.RFC3339, ctx.String(silenceEndFlag.Name)); err != nil {
+			return fmt.Errorf("invalid end: %w", err)
+		}
+	default:
+		endsAt = startsAt.Add(ctx.Duration(silenceDurationFlag.Name))
+	}
+
+	return withSilenceLogic(ctx, func(silenceLogic *silence.LogicSilence) error {
+		id, err := silenceLogic.CreateSilence(ctx.Context, orm.AlertSilence{
+			Kind:      ctx.String(silenceKindFlag.Name),
+			Layer:     ctx.String(silenceLayerFlag.Name),
+			Token:     ctx.String(silenceTokenFlag.Name),
+			Address:   ctx.String(silenceAddressFlag.Name),
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+			CreatedBy: ctx.String(silenceCreatedByFlag.Name),
+			Reason:    ctx.String(silenceReasonFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created silence %d\n", id)
+		return nil
+	})
+}
+
+func listSilences(ctx *cli.Context) error {
+	return withSilenceLogic(ctx, func(silenceLogic *silence.LogicSilence) error {
+		silences, err := silenceLogic.ListSilences(ctx.Context)
+		if err != nil {
+			return err
+		}
+		now := utils.NowUTC()
+		for _, s := range silences {
+			state := "active"
+			if s.StartsAt.After(now) {
+				state = "pending"
+			}
+			fmt.Printf("%d\t%s\tkind=%s layer=%s token=%s address=%s\t%s - %s\tby %s: %s\n", s.ID, state, s.Kind, s.Layer,
+				s.Token, s.Address, s.StartsAt.Format(time.RFC3339), s.EndsAt.Format(time.RFC3339), s.CreatedBy, s.Reason)
+		}
+		return nil
+	})
+}
+
+func expireSilence(ctx *cli.Context) error {
+	return withSilenceLogic(ctx, func(silenceLogic *silence.LogicSilence) error {
+		return silenceLogic.ExpireSilence(ctx.Context, ctx.Int64(silenceIDFlag.Name))
+	})
+}
+
+// withSilenceLogic runs the silence command with the silence logic of the configured db.
+func withSilenceLogic(ctx *cli.Context, run func(silenceLogic *silence.LogicSilence) error) error {
+	cfgFile := ctx.String(utils.ConfigFileFlag.Name)
+	cfg, err := config.NewConfig(cfgFile)
+	if err != nil {
+		log.Crit("failed to load config file", "config file", cfgFile, "error", err)
+	}
+
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		log.Crit("failed to connect to db", "err", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close database", "err", err)
+		}
+	}()
+
+	return run(silence.NewLogicSilence(db))
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/assembler"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+	"github.com/scroll-tech/chain-monitor/internal/utils/database"
+)
+
+var verifyTrieCommand = &cli.Command{
+	Name:   "verify-trie",
+	Usage:  "Replay the stored l2 sent messages and confirm every withdraw trie checkpoint",
+	Action: verifyTrie,
+}
+
+func verifyTrie(ctx *cli.Context) error {
+	cfgFile := ctx.String(utils.ConfigFileFlag.Name)
+	cfg, err := config.NewConfig(cfgFile)
+	if err != nil {
+		log.Crit("failed to load config file", "config file", cfgFile, "error", err)
+	}
+
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		log.Crit("failed to connect to db", "err", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close database", "err", err)
+		}
+	}()
+
+	verified, mismatched, verifyErr := assembler.NewMessageMatchAssembler(db).VerifyWithdrawTrieCheckpoints(ctx.Context)
+	if verifyErr != nil {
+		return verifyErr
+	}
+	log.Info("withdraw trie checkpoints verified", "verified", verified, "mismatched", mismatched)
+	if mismatched > 0 {
+		return fmt.Errorf("%d withdraw trie checkpoints mismatch", mismatched)
+	}
+	return nil
+}
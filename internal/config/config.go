@@ -7,12 +7,16 @@ import (
 
 	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/rpc"
+	"github.com/shopspring/decimal"
 
 	"github.com/scroll-tech/chain-monitor/internal/utils/database"
 )
 
 // Gateway address list.
 type Gateway struct {
+	// eth
+	ETHGateway common.Address `json:"eth_gateway"`
+
 	// erc20
 	WETHGateway          common.Address `json:"weth_gateway"`
 	StandardERC20Gateway common.Address `json:"standard_erc20_gateway"`
@@ -29,10 +33,48 @@ type Gateway struct {
 	ERC1155Gateway common.Address `json:"erc1155_gateway"`
 }
 
+// ContractAddress is a named contract address of the bridge.
+type ContractAddress struct {
+	Name    string
+	Address common.Address
+}
+
+// Addresses returns the configured gateway addresses, unconfigured gateways are skipped.
+func (g *Gateway) Addresses() []ContractAddress {
+	gateways := []ContractAddress{
+		{"eth_gateway", g.ETHGateway},
+		{"weth_gateway", g.WETHGateway},
+		{"standard_erc20_gateway", g.StandardERC20Gateway},
+		{"custom_erc20_gateway", g.CustomERC20Gateway},
+		{"dai_gateway", g.DAIGateway},
+		{"usdc_gateway", g.USDCGateway},
+		{"lido_gateway", g.LIDOGateway},
+		{"puffer_gateway", g.PufferGateway},
+		{"erc721_gateway", g.ERC721Gateway},
+		{"erc1155_gateway", g.ERC1155Gateway},
+	}
+	return nonZeroAddresses(gateways)
+}
+
 // L1Contracts l1chain config.
 type L1Contracts struct {
 	Gateway         `json:"l1_gateways"`
 	ScrollMessenger common.Address `json:"scroll_messenger"`
+	MessageQueue    common.Address `json:"message_queue"`
+}
+
+// Addresses returns all the configured l1 bridge contract addresses.
+func (c *L1Contracts) Addresses() []ContractAddress {
+	return append(c.Gateway.Addresses(), nonZeroAddresses([]ContractAddress{
+		{"scroll_messenger", c.ScrollMessenger},
+		{"message_queue", c.MessageQueue},
+	})...)
+}
+
+// ExplorerConfig the block explorer url templates of a layer, {} is replaced with the tx hash or the address.
+type ExplorerConfig struct {
+	TxURL      string `json:"tx_url"`
+	AddressURL string `json:"address_url"`
 }
 
 // L1Config l1 chain config.
@@ -42,6 +84,11 @@ type L1Config struct {
 	L1Contracts           *L1Contracts `json:"l1_contracts"`
 	StartNumber           uint64       `json:"start_number"`
 	StartMessengerBalance uint64       `json:"start_messenger_balance"`
+	// TraceMessengerOutflow traces the blocks with messenger events or a messenger balance change to attribute the eth
+	// leaving the messenger, it requires the debug api of the node.
+	TraceMessengerOutflow bool `json:"trace_messenger_outflow"`
+	// Explorer links the tx hashes and addresses of the l1 alerts.
+	Explorer *ExplorerConfig `json:"explorer"`
 }
 
 // L2Contracts l1chain config.
@@ -51,26 +98,256 @@ type L2Contracts struct {
 	MessageQueue    common.Address `json:"message_queue"`
 }
 
+// Addresses returns all the configured l2 bridge contract addresses.
+func (c *L2Contracts) Addresses() []ContractAddress {
+	return append(c.Gateway.Addresses(), nonZeroAddresses([]ContractAddress{
+		{"scroll_messenger", c.ScrollMessenger},
+		{"message_queue", c.MessageQueue},
+	})...)
+}
+
 // L2Config l1 chain config.
 type L2Config struct {
 	L2URL       string `json:"l2_url"`
 	Confirm     rpc.BlockNumber
 	L2Contracts *L2Contracts `json:"l2_contracts"`
+	// WithdrawRootCheckInterval also checks the withdraw root of every this many blocks and the last block of the
+	// processed range, in addition to the blocks with SentMessage events. 0 only checks the blocks with SentMessage
+	// events and 1 checks every block.
+	WithdrawRootCheckInterval uint64 `json:"withdraw_root_check_interval"`
+	// TraceMessengerOutflow traces the blocks with messenger events or a messenger balance change to attribute the eth
+	// leaving the messenger, it requires the debug api of the node.
+	TraceMessengerOutflow bool `json:"trace_messenger_outflow"`
+	// Explorer links the tx hashes and addresses of the l2 alerts.
+	Explorer *ExplorerConfig `json:"explorer"`
 }
 
 // SlackWebhookConfig slack webhook config.
 type SlackWebhookConfig struct {
-	WebhookURL       string `json:"webhook_url,omitempty"`
+	WebhookURL string `json:"webhook_url,omitempty"`
+	// BotToken posts the alerts through the slack web api to the channel instead of the webhook, the follow-up alerts
+	// of the same message hash are posted as thread replies.
+	BotToken string `json:"bot_token,omitempty"`
+	Channel  string `json:"channel,omitempty"`
+	// APIURL the slack web api url, https://slack.com/api if not set.
+	APIURL           string `json:"api_url,omitempty"`
 	WorkerCount      int    `json:"worker_count"`
 	WorkerBufferSize int    `json:"worker_buffer_size"`
+	// MinSeverity only the alerts of at least this severity (info, warning or critical) are posted, all if not set.
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// AlertRoute the sinks the alerts of a severity tier are sent to.
+type AlertRoute struct {
+	// Severity the severity tier of the route, info, warning or critical.
+	Severity string `json:"severity"`
+	// Sinks the names of the sinks: slack, discord, telegram and matrix.
+	Sinks []string `json:"sinks"`
+	// Channel the slack channel of the tier posted to with the bot token, the slack_webhook_config channel if not set.
+	Channel string `json:"channel,omitempty"`
+	// WebhookURL the slack webhook of the tier without the bot token, the slack_webhook_config webhook if not set.
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// EscalationTier re-notifies the critical alerts not acknowledged within AfterMinutes of the first notification.
+type EscalationTier struct {
+	AfterMinutes uint64   `json:"after_minutes"`
+	Sinks        []string `json:"sinks"`
+	Channel      string   `json:"channel,omitempty"`
+	WebhookURL   string   `json:"webhook_url,omitempty"`
+	// Mention the slack mention of the escalated alert, e.g. <!channel> or <!subteam^S0123456789>.
+	Mention string `json:"mention,omitempty"`
+}
+
+// AlertRoutingConfig the severity of the alert kinds, the routing of the severity tiers and the escalation of the
+// unacknowledged critical alerts.
+type AlertRoutingConfig struct {
+	// Severities overrides the severities of the alert kinds, e.g. {"gateway_duplicated": "warning"}.
+	Severities map[string]string `json:"severities,omitempty"`
+	// Routes the routes of the severity tiers, the tiers without a route are sent to every sink.
+	Routes []AlertRoute `json:"routes,omitempty"`
+	// Escalation the escalation tiers in order of their after minutes.
+	Escalation []EscalationTier `json:"escalation,omitempty"`
+}
+
+// DiscordConfig discord webhook alert sink config.
+type DiscordConfig struct {
+	WebhookURL  string `json:"webhook_url"`
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// TelegramConfig telegram bot api alert sink config.
+type TelegramConfig struct {
+	BotToken string `json:"bot_token"`
+	ChatID   string `json:"chat_id"`
+	// APIURL the telegram bot api url, https://api.telegram.org if not set.
+	APIURL      string `json:"api_url,omitempty"`
+	MinSeverity string `json:"min_severity,omitempty"`
+}
+
+// MatrixConfig matrix room alert sink config, the access token is of the account posting to the room.
+type MatrixConfig struct {
+	HomeserverURL string `json:"homeserver_url"`
+	AccessToken   string `json:"access_token"`
+	RoomID        string `json:"room_id"`
+	MinSeverity   string `json:"min_severity,omitempty"`
+}
+
+// SMTPConfig the smtp server the digests are sent through, the plain auth is used if the username is set.
+type SMTPConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// DigestConfig the daily and weekly bridge health email digest config.
+type DigestConfig struct {
+	SMTP   *SMTPConfig `json:"smtp"`
+	Daily  bool        `json:"daily"`
+	Weekly bool        `json:"weekly"`
+	// SendHour the utc hour the digests are sent at, the weekly digest is sent on mondays.
+	SendHour int `json:"send_hour"`
+	// StuckMessageAfter the seconds after which an l1 message not relayed on l2 is reported as stuck.
+	StuckMessageAfter uint64 `json:"stuck_message_after"`
+}
+
+// MessengerAlertConfig messenger failed relay and drop alert config.
+type MessengerAlertConfig struct {
+	// FailedRelayAlertTimes alerts once a message failed to relay at least this many times.
+	FailedRelayAlertTimes int `json:"failed_relay_alert_times"`
+	// DropAlertETHAmount alerts when a dropped message carries at least this much eth (in wei).
+	DropAlertETHAmount decimal.Decimal `json:"drop_alert_eth_amount"`
+}
+
+// ReserveToken a bridged erc20 token always reconciled, the other tokens are discovered from the gateway mappings.
+type ReserveToken struct {
+	Name string `json:"name"`
+	// Gateway the name of the l1 gateway escrowing the token, e.g. standard_erc20_gateway, resolved through the
+	// gateway mappings if unconfigured.
+	Gateway string         `json:"gateway"`
+	L1Token common.Address `json:"l1_token"`
+	// L2Token is resolved through the l1 gateway if unconfigured.
+	L2Token common.Address `json:"l2_token"`
+	// Tolerance the imbalance allowed between the escrow and the supply, in the smallest unit of the token.
+	Tolerance decimal.Decimal `json:"tolerance"`
+}
+
+// ReserveConfig escrow and supply reconciliation config.
+type ReserveConfig struct {
+	// DefaultTolerance the tolerance of the discovered tokens, in the smallest unit of the token.
+	DefaultTolerance decimal.Decimal `json:"default_tolerance"`
+	Tokens           []ReserveToken  `json:"tokens"`
+}
+
+// TransferAlertToken the large transfer and escrow outflow thresholds of a bridged token, in token units.
+type TransferAlertToken struct {
+	Name string `json:"name"`
+	// L1Token the l1 token address, the zero address is ETH.
+	L1Token common.Address `json:"l1_token"`
+	// Decimals is read from the l1 token if unconfigured.
+	Decimals *int32 `json:"decimals"`
+	// LargeTransfer alerts on a single deposit or withdrawal above the amount, disabled if zero.
+	LargeTransfer decimal.Decimal `json:"large_transfer"`
+	// OutflowLimit alerts when the net outflow from the l1 escrow within the window exceeds the amount, disabled if zero.
+	OutflowLimit decimal.Decimal `json:"outflow_limit"`
+	// OutflowWindowBlocks the l1 blocks of the rolling outflow window.
+	OutflowWindowBlocks uint64 `json:"outflow_window_blocks"`
+}
+
+// TransferAlertConfig large transfer and escrow outflow velocity alert config.
+type TransferAlertConfig struct {
+	Tokens []TransferAlertToken `json:"tokens"`
+}
+
+// WatchedEventCondition a condition on a decoded event field, the op is one of eq, ne, gt, gte, lt and lte. The
+// numeric fields are compared as integers, the other fields only support eq and ne, compared case-insensitively.
+type WatchedEventCondition struct {
+	Field string `json:"field"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
+
+// WatchedEventAlert alerts on the captured events matching all the conditions, every captured event is alerted
+// if there is no condition.
+type WatchedEventAlert struct {
+	Severity   string                  `json:"severity"`
+	Conditions []WatchedEventCondition `json:"conditions"`
+}
+
+// WatchedEvent an event of the watched contract to capture, only the events matching all the filters are captured.
+type WatchedEvent struct {
+	Name    string                  `json:"name"`
+	Filters []WatchedEventCondition `json:"filters"`
+	Alert   *WatchedEventAlert      `json:"alert"`
+}
+
+// WatchedContract a contract whose events are captured without dedicated bindings.
+type WatchedContract struct {
+	Name string `json:"name"`
+	// Layer the layer of the contract, l1 or l2.
+	Layer   string         `json:"layer"`
+	Address common.Address `json:"address"`
+	// ABIFile the path of the abi json file of the contract.
+	ABIFile string         `json:"abi_file"`
+	Events  []WatchedEvent `json:"events"`
+}
+
+// Invariant a contract state asserted with eth_call on every evaluation.
+type Invariant struct {
+	Name string `json:"name"`
+	// Layer the layer of the contract, l1 or l2.
+	Layer   string         `json:"layer"`
+	Address common.Address `json:"address"`
+	// Method the view method signature with its outputs, e.g. counterpart()(address) or balanceOf(address)(uint256 balance).
+	Method string   `json:"method"`
+	Args   []string `json:"args"`
+	// Expression the condition the call result must hold, the first output is bound to result, the outputs to out0,
+	// out1... and their names, and the first output of the previous evaluation to previous.
+	Expression string `json:"expression"`
+	// Interval the seconds between evaluations on the latest block, the invariant is evaluated on the latest processed
+	// block every time the contract controller moves forward if zero.
+	Interval uint64 `json:"interval"`
+}
+
+// RuleConfig rule engine config.
+type RuleConfig struct {
+	// Files the json files of the rules, a file is reloaded once its modification time changes.
+	Files []string `json:"files"`
+}
+
+// APIConfig the api config, the api routes changing the alerting such as the acknowledgements and the silences require
+// an admin token.
+type APIConfig struct {
+	// AdminTokens the admin tokens by the name of their holder, the name is logged with the acknowledgements and
+	// recorded as the creator of the silences.
+	// The admin routes are refused if no token is configured.
+	AdminTokens map[string]string `json:"admin_tokens"`
 }
 
 // Config chain-monitor main config.
 type Config struct {
-	L1Config    *L1Config           `json:"l1_config"`
-	L2Config    *L2Config           `json:"l2_config"`
-	AlertConfig *SlackWebhookConfig `json:"slack_webhook_config"`
-	DBConfig    *database.Config    `json:"db_config"`
+	L1Config             *L1Config             `json:"l1_config"`
+	L2Config             *L2Config             `json:"l2_config"`
+	AlertConfig          *SlackWebhookConfig   `json:"slack_webhook_config"`
+	DiscordConfig        *DiscordConfig        `json:"discord_config"`
+	TelegramConfig       *TelegramConfig       `json:"telegram_config"`
+	MatrixConfig         *MatrixConfig         `json:"matrix_config"`
+	DigestConfig         *DigestConfig         `json:"digest_config"`
+	AlertRoutingConfig   *AlertRoutingConfig   `json:"alert_routing_config"`
+	MessengerAlertConfig *MessengerAlertConfig `json:"messenger_alert_config"`
+	ReserveConfig        *ReserveConfig        `json:"reserve_config"`
+	WatchedContracts     []WatchedContract     `json:"watched_contracts"`
+	Invariants           []Invariant           `json:"invariants"`
+	RuleConfig           *RuleConfig           `json:"rule_config"`
+	TransferAlertConfig  *TransferAlertConfig  `json:"transfer_alert_config"`
+	TokenListFile        string                `json:"token_list_file"`
+	AddressLabelFile     string                `json:"address_label_file"`
+	MessageExplorerURL   string                `json:"message_explorer_url"`
+	APIConfig            *APIConfig            `json:"api_config"`
+	DBConfig             *database.Config      `json:"db_config"`
 }
 
 // NewConfig return an unmarshalled config instance.
@@ -86,3 +363,13 @@ func NewConfig(file string) (*Config, error) {
 	}
 	return &cfg, nil
 }
+
+func nonZeroAddresses(contracts []ContractAddress) []ContractAddress {
+	var addresses []ContractAddress
+	for _, contract := range contracts {
+		if contract.Address != (common.Address{}) {
+			addresses = append(addresses, contract)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// AlertController the critical alert acknowledgement handler
+type AlertController struct{}
+
+// NewAlertController create alert controller instance
+func NewAlertController() *AlertController {
+	return &AlertController{}
+}
+
+// PendingAlerts list the critical alerts pending acknowledgement
+func (a *AlertController) PendingAlerts(ctx *gin.Context) {
+	types.RenderSuccess(ctx, slack.PendingAlerts())
+}
+
+// Acknowledge acknowledge the critical alert by the holder of the admin token, it's no longer escalated
+func (a *AlertController) Acknowledge(ctx *gin.Context) {
+	var ackParam types.AlertAckParam
+	if err := ctx.ShouldBindUri(&ackParam); err != nil {
+		log.Error("acknowledge alert failed", "error", err)
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	if !slack.Acknowledge(ackParam.AlertID) {
+		types.RenderFailure(ctx, types.ErrAlertNotPendingNo, fmt.Errorf("alert %s is not pending acknowledgement", ackParam.AlertID))
+		return
+	}
+	log.Info("critical alert acknowledged", "alert id", ackParam.AlertID, "by", ctx.GetString(adminCallerKey))
+	types.RenderSuccess(ctx, nil)
+}
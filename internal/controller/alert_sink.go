@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/sink"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+)
+
+// AlertSinkController the controller of the discord, telegram and matrix alert sinks
+type AlertSinkController struct {
+	sinks []*sink.Sink
+}
+
+// NewAlertSinkController create AlertSinkController with the configured sinks, and register them to the notifier
+func NewAlertSinkController(ctx context.Context, cfg *config.Config) *AlertSinkController {
+	c := &AlertSinkController{}
+
+	if cfg.DiscordConfig != nil {
+		discordSink, err := sink.NewDiscordSink(ctx, cfg.DiscordConfig)
+		if err != nil {
+			log.Crit("NewDiscordSink failed", "error", err)
+		}
+		c.sinks = append(c.sinks, discordSink)
+	}
+	if cfg.TelegramConfig != nil {
+		telegramSink, err := sink.NewTelegramSink(ctx, cfg.TelegramConfig)
+		if err != nil {
+			log.Crit("NewTelegramSink failed", "error", err)
+		}
+		c.sinks = append(c.sinks, telegramSink)
+	}
+	if cfg.MatrixConfig != nil {
+		matrixSink, err := sink.NewMatrixSink(ctx, cfg.MatrixConfig)
+		if err != nil {
+			log.Crit("NewMatrixSink failed", "error", err)
+		}
+		c.sinks = append(c.sinks, matrixSink)
+	}
+
+	for _, s := range c.sinks {
+		slack.RegisterSink(s)
+	}
+	return c
+}
+
+// Start the alert sinks
+func (c *AlertSinkController) Start() {
+	for _, s := range c.sinks {
+		s.Start()
+		log.Info("alert sink start successful", "sink", s.Name())
+	}
+}
+
+// Stop the alert sinks
+func (c *AlertSinkController) Stop() {
+	for _, s := range c.sinks {
+		s.Stop()
+	}
+}
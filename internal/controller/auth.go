@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// adminCallerKey the context key of the name of the admin token holder calling the api.
+const adminCallerKey = "admin_caller"
+
+type adminToken struct {
+	name  string
+	token []byte
+}
+
+// AuthController the admin token authentication of the api routes changing the alerting
+type AuthController struct {
+	tokens []adminToken
+}
+
+// NewAuthController create the admin token authentication instance, the tokens without a holder name are ignored
+func NewAuthController(conf *config.Config) *AuthController {
+	a := &AuthController{}
+	if conf.APIConfig == nil {
+		return a
+	}
+	for name, token := range conf.APIConfig.AdminTokens {
+		if name == "" || token == "" {
+			log.Warn("ignore the admin token without a holder name or a token", "name", name)
+			continue
+		}
+		a.tokens = append(a.tokens, adminToken{name: name, token: []byte(token)})
+	}
+	return a
+}
+
+// Admin requires the admin token in the bearer authorization header, and sets the name of its holder as the caller
+func (a *AuthController) Admin(ctx *gin.Context) {
+	authorization := ctx.GetHeader("Authorization")
+	if token := strings.TrimPrefix(authorization, "Bearer "); token != authorization && token != "" {
+		for _, t := range a.tokens {
+			if subtle.ConstantTimeCompare([]byte(token), t.token) == 1 {
+				ctx.Set(adminCallerKey, t.name)
+				ctx.Next()
+				return
+			}
+		}
+	}
+
+	log.Warn("unauthorized admin api request", "method", ctx.Request.Method, "path", ctx.Request.URL.Path, "client ip", ctx.ClientIP())
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, types.Response{
+		ErrCode: types.ErrUnauthorizedNo,
+		ErrMsg:  fmt.Sprintf("admin token required for %s %s", ctx.Request.Method, ctx.FullPath()),
+	})
+}
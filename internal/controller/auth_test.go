@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+)
+
+func TestAdminAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newRouter := func(conf *config.Config) *gin.Engine {
+		router := gin.New()
+		router.POST("/v1/alerts/1/ack", NewAuthController(conf).Admin, func(ctx *gin.Context) {
+			ctx.String(http.StatusOK, ctx.GetString(adminCallerKey))
+		})
+		return router
+	}
+	conf := &config.Config{APIConfig: &config.APIConfig{AdminTokens: map[string]string{"alice": "alice-token", "bob": ""}}}
+
+	tests := []struct {
+		name          string
+		conf          *config.Config
+		authorization string
+		status        int
+		caller        string
+	}{
+		{name: "valid", conf: conf, authorization: "Bearer alice-token", status: http.StatusOK, caller: "alice"},
+		{name: "missing", conf: conf, status: http.StatusUnauthorized},
+		{name: "invalid", conf: conf, authorization: "Bearer bob-token", status: http.StatusUnauthorized},
+		{name: "emptyToken", conf: conf, authorization: "Bearer ", status: http.StatusUnauthorized},
+		{name: "notBearer", conf: conf, authorization: "alice-token", status: http.StatusUnauthorized},
+		{name: "noTokenConfigured", conf: &config.Config{}, authorization: "Bearer alice-token", status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/alerts/1/ack", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			newRouter(tt.conf).ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, tt.caller, w.Body.String())
+			} else {
+				assert.Contains(t, w.Body.String(), `"errcode":40101`)
+			}
+		})
+	}
+}
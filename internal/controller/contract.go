@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
+	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/ethclient"
 	"github.com/scroll-tech/go-ethereum/log"
 	"github.com/scroll-tech/go-ethereum/rpc"
@@ -19,7 +20,14 @@ import (
 	"github.com/scroll-tech/chain-monitor/internal/logic/assembler"
 	"github.com/scroll-tech/chain-monitor/internal/logic/contracts"
 	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/explorer"
+	"github.com/scroll-tech/chain-monitor/internal/logic/governance"
 	messagematch "github.com/scroll-tech/chain-monitor/internal/logic/message_match"
+	"github.com/scroll-tech/chain-monitor/internal/logic/rule"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/logic/token"
+	"github.com/scroll-tech/chain-monitor/internal/logic/transfer"
+	"github.com/scroll-tech/chain-monitor/internal/logic/watcher"
 	"github.com/scroll-tech/chain-monitor/internal/orm"
 	"github.com/scroll-tech/chain-monitor/internal/types"
 	"github.com/scroll-tech/chain-monitor/internal/utils"
@@ -36,6 +44,10 @@ type ContractController struct {
 	contractsLogic        *contracts.Contracts
 	messageMatchAssembler *assembler.MessageMatchAssembler
 	messageMatchLogic     *messagematch.LogicMessageMatch
+	governanceLogic       *governance.LogicGovernance
+	watcherLogic          *watcher.LogicWatcher
+	ruleLogic             *rule.LogicRule
+	transferAlertLogic    *transfer.LogicTransferAlert
 
 	stopL1ContractChan  chan struct{}
 	stopL2ContractChan  chan struct{}
@@ -49,27 +61,33 @@ type ContractController struct {
 	contractControllerGatewayCheckFailureTotal               *prometheus.CounterVec
 	contractControllerUpdateOrInsertMessageMatchFailureTotal *prometheus.CounterVec
 	contractControllerCheckWithdrawRootFailureTotal          *prometheus.CounterVec
+	contractControllerFilterGovernanceEventFailureTotal      *prometheus.CounterVec
+	contractControllerFilterWatchedEventFailureTotal         *prometheus.CounterVec
 
-	db                       *gorm.DB
-	messengerMessageMatchOrm *orm.MessengerMessageMatch
-	gatewayMessageMatchOrm   *orm.GatewayMessageMatch
+	db                        *gorm.DB
+	messengerMessageMatchOrm  *orm.MessengerMessageMatch
+	gatewayMessageMatchOrm    *orm.GatewayMessageMatch
+	withdrawTrieCheckpointOrm *orm.WithdrawTrieCheckpoint
 }
 
 // NewContractController creates a new ContractController object.
 func NewContractController(conf *config.Config, db *gorm.DB, l1Client, l2Client *rpc.Client) *ContractController {
 	c := &ContractController{
-		l1Client:                 l1Client,
-		l2Client:                 l2Client,
-		conf:                     conf,
-		eventGatherLogic:         events.NewEventGather(),
-		contractsLogic:           contracts.NewContracts(ethclient.NewClient(l1Client), ethclient.NewClient(l2Client)),
-		messageMatchAssembler:    assembler.NewMessageMatchAssembler(db),
-		messageMatchLogic:        messagematch.NewMessageMatchLogic(conf, db),
-		stopL1ContractChan:       make(chan struct{}),
-		stopL2ContractChan:       make(chan struct{}),
-		db:                       db,
-		messengerMessageMatchOrm: orm.NewMessengerMessageMatch(db),
-		gatewayMessageMatchOrm:   orm.NewGatewayMessageMatch(db),
+		l1Client:                  l1Client,
+		l2Client:                  l2Client,
+		conf:                      conf,
+		eventGatherLogic:          events.NewEventGather(),
+		contractsLogic:            contracts.NewContracts(ethclient.NewClient(l1Client), ethclient.NewClient(l2Client)),
+		messageMatchAssembler:     assembler.NewMessageMatchAssembler(db),
+		messageMatchLogic:         messagematch.NewMessageMatchLogic(conf, db),
+		governanceLogic:           governance.NewLogicGovernance(db),
+		watcherLogic:              watcher.NewLogicWatcher(db),
+		stopL1ContractChan:        make(chan struct{}),
+		stopL2ContractChan:        make(chan struct{}),
+		db:                        db,
+		messengerMessageMatchOrm:  orm.NewMessengerMessageMatch(db),
+		gatewayMessageMatchOrm:    orm.NewGatewayMessageMatch(db),
+		withdrawTrieCheckpointOrm: orm.NewWithdrawTrieCheckpoint(db),
 	}
 
 	if err := c.contractsLogic.Register(c.conf); err != nil {
@@ -77,6 +95,30 @@ func NewContractController(conf *config.Config, db *gorm.DB, l1Client, l2Client
 		return nil
 	}
 
+	ruleLogic, err := rule.NewLogicRule(c.conf)
+	if err != nil {
+		log.Crit("rule load failure", "error", err)
+		return nil
+	}
+	c.ruleLogic = ruleLogic
+
+	transferAlertLogic, err := transfer.NewLogicTransferAlert(c.conf, db, ethclient.NewClient(l1Client))
+	if err != nil {
+		log.Crit("transfer alert config failure", "error", err)
+		return nil
+	}
+	c.transferAlertLogic = transferAlertLogic
+
+	// the token metadata and the address labels are shared by the alerts of all the controllers.
+	if _, err = token.NewCache(c.conf, ethclient.NewClient(l1Client), ethclient.NewClient(l2Client)); err != nil {
+		log.Crit("token list load failure", "error", err)
+		return nil
+	}
+	if _, err = explorer.NewRegistry(c.conf); err != nil {
+		log.Crit("address label load failure", "error", err)
+		return nil
+	}
+
 	// eth balance is checked by other means.
 	c.l1EventCategoryList = append(c.l1EventCategoryList, types.ERC20EventCategory)
 	c.l1EventCategoryList = append(c.l1EventCategoryList, types.ERC721EventCategory)
@@ -115,6 +157,15 @@ func NewContractController(conf *config.Config, db *gorm.DB, l1Client, l2Client
 		Name: "contract_controller_check_l2_withdraw_root_failure_total",
 		Help: "The total number of controller check l2 withdraw root failure total.",
 	}, []string{"layer"})
+	c.contractControllerFilterGovernanceEventFailureTotal = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "contract_controller_filter_governance_event_failure_total",
+		Help: "The total number of controller filter governance event failure total.",
+	}, []string{"layer"})
+
+	c.contractControllerFilterWatchedEventFailureTotal = promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+		Name: "contract_controller_filter_watched_event_failure_total",
+		Help: "The total number of controller filter watched contract event failure total.",
+	}, []string{"layer"})
 
 	return c
 }
@@ -143,10 +194,12 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 	log.Info("Block process height in db", "layer", layer, "block number", blockNumberInDB)
 	start := blockNumberInDB + 1
 
-	if layer == types.Layer2 {
-		l2CurrentMaxBlockNumber.Store(blockNumberInDB)
+	if err := c.seedMessageNonce(ctx, layer, blockNumberInDB); err != nil {
+		log.Warn("seed message nonce failed, the first block range only checks the nonces within it", "layer", layer, "err", err)
 	}
 
+	storeCurrentMaxBlockNumber(layer, blockNumberInDB)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -188,6 +241,9 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 		var mux sync.Mutex
 		var gatewayMessageMatches []orm.GatewayMessageMatch
 		var messengerMessageMatches []orm.MessengerMessageMatch
+		var messengerEvents []events.EventUnmarshaler
+		var alerts rangeAlerts
+		var gatewayEvents []events.EventUnmarshaler
 		for i := 0; i < concurrency; i++ {
 			if loopStart > confirmationNumber {
 				log.Info("Watcher loop start block number > ConfirmationNumber",
@@ -214,15 +270,18 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 			eg.Go(func() error {
 				var retGatewayMessageMatches []orm.GatewayMessageMatch
 				var retMessengerMessageMatches []orm.MessengerMessageMatch
+				var retMessengerEvents []events.EventUnmarshaler
+				var retAlerts rangeAlerts
+				var retGatewayEvents []events.EventUnmarshaler
 				var watchErr error
 				switch layer {
 				case types.Layer1:
-					retGatewayMessageMatches, retMessengerMessageMatches, watchErr = c.l1Watch(ctx, currentStart, currentEnd)
+					retGatewayMessageMatches, retMessengerMessageMatches, retMessengerEvents, retGatewayEvents, watchErr = c.l1Watch(ctx, currentStart, currentEnd, &retAlerts)
 					if watchErr != nil {
 						return watchErr
 					}
 				case types.Layer2:
-					retGatewayMessageMatches, retMessengerMessageMatches, watchErr = c.l2Watch(ctx, currentStart, currentEnd)
+					retGatewayMessageMatches, retMessengerMessageMatches, retMessengerEvents, retGatewayEvents, watchErr = c.l2Watch(ctx, currentStart, currentEnd, &retAlerts)
 					if watchErr != nil {
 						return watchErr
 					}
@@ -230,6 +289,9 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 				mux.Lock()
 				gatewayMessageMatches = append(gatewayMessageMatches, retGatewayMessageMatches...)
 				messengerMessageMatches = append(messengerMessageMatches, retMessengerMessageMatches...)
+				messengerEvents = append(messengerEvents, retMessengerEvents...)
+				alerts.append(retAlerts)
+				gatewayEvents = append(gatewayEvents, retGatewayEvents...)
 				mux.Unlock()
 				return nil
 			})
@@ -241,10 +303,39 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 		}
 
 		if loopEnd >= start {
+			governanceEvents, governanceErr := c.contractsLogic.GetGovernanceEvents(ctx, start, loopEnd, layer)
+			if governanceErr != nil {
+				c.contractControllerFilterGovernanceEventFailureTotal.WithLabelValues(layer.String()).Inc()
+				log.Error("get governance events failed", "layer", layer, "start", start, "end", loopEnd, "error", governanceErr)
+				continue
+			}
+
+			watchedEvents, watchedErr := c.contractsLogic.GetWatchedEvents(ctx, start, loopEnd, layer)
+			if watchedErr != nil {
+				c.contractControllerFilterWatchedEventFailureTotal.WithLabelValues(layer.String()).Inc()
+				log.Error("get watched events failed", "layer", layer, "start", start, "end", loopEnd, "error", watchedErr)
+				continue
+			}
+
+			var queueEvents []events.MessageQueueEvent
+			var queueErr error
+			switch layer {
+			case types.Layer1:
+				queueEvents, queueErr = c.contractsLogic.GetL1MessageQueueEvents(ctx, start, loopEnd)
+			case types.Layer2:
+				queueEvents, queueErr = c.contractsLogic.GetL2MessageQueueEvents(ctx, start, loopEnd)
+			}
+			if queueErr != nil {
+				c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(layer.String(), types.MessengerEventCategory.String()).Inc()
+				log.Error("get message queue events failed", "layer", layer, "start", start, "end", loopEnd, "error", queueErr)
+				continue
+			}
+
 			var lastMessage *orm.MessengerMessageMatch
+			var checkpoints []orm.WithdrawTrieCheckpoint
 			if layer == types.Layer2 {
 				var checkErr error
-				lastMessage, checkErr = c.messageMatchAssembler.L2WithdrawRootsValidator(ctx, start, loopEnd, c.l2Client, c.conf.L2Config.L2Contracts.MessageQueue)
+				lastMessage, checkpoints, checkErr = c.messageMatchAssembler.L2WithdrawRootsValidator(ctx, start, loopEnd, c.l2Client, c.conf.L2Config.L2Contracts.MessageQueue, c.conf.L2Config.WithdrawRootCheckInterval)
 				if checkErr != nil {
 					c.contractControllerCheckWithdrawRootFailureTotal.WithLabelValues(types.Layer2.String()).Inc()
 					log.Error("check withdraw roots failed", "layer", types.Layer2, "start", start, "end", loopEnd, "error", checkErr)
@@ -253,11 +344,17 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 			}
 
 			// Update last valid message's withdraw trie proof and block status after check.
+			var messengerFailures []slack.MessengerFailureInfo
+			var recordedGovernanceEvents []events.GovernanceEvent
+			var alertedWatchedEvents []events.WatchedEvent
 			updateErr := c.db.Transaction(func(tx *gorm.DB) error {
 				if layer == types.Layer2 {
 					if updateMsgProofErr := c.messengerMessageMatchOrm.UpdateMsgProofAndStatus(ctx, lastMessage, tx); updateMsgProofErr != nil {
 						return fmt.Errorf("insert or update msg proof and status failed, err: %w, message: %+v", updateMsgProofErr, lastMessage)
 					}
+					if _, insertCheckpointErr := c.withdrawTrieCheckpointOrm.InsertOrUpdateWithdrawTrieCheckpoints(ctx, checkpoints, tx); insertCheckpointErr != nil {
+						return fmt.Errorf("insert or update withdraw trie checkpoints failed, err: %w", insertCheckpointErr)
+					}
 				}
 
 				if insertEventErr := c.messageMatchLogic.InsertOrUpdateMessageMatches(ctx, layer, gatewayMessageMatches, messengerMessageMatches); insertEventErr != nil {
@@ -265,6 +362,28 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 					log.Error("insert message events failed", "layer", layer.String(), "error", insertEventErr)
 					return insertEventErr
 				}
+
+				var insertFailureErr error
+				messengerFailures, insertFailureErr = c.messageMatchLogic.InsertOrUpdateMessengerFailures(ctx, messengerEvents, tx)
+				if insertFailureErr != nil {
+					c.contractControllerUpdateOrInsertMessageMatchFailureTotal.WithLabelValues(layer.String()).Inc()
+					log.Error("insert messenger failure events failed", "layer", layer.String(), "error", insertFailureErr)
+					return insertFailureErr
+				}
+
+				var insertGovernanceErr error
+				recordedGovernanceEvents, insertGovernanceErr = c.governanceLogic.InsertGovernanceEvents(ctx, governanceEvents, tx)
+				if insertGovernanceErr != nil {
+					log.Error("insert governance events failed", "layer", layer.String(), "error", insertGovernanceErr)
+					return insertGovernanceErr
+				}
+
+				var insertWatchedErr error
+				alertedWatchedEvents, insertWatchedErr = c.watcherLogic.InsertWatchedEvents(ctx, watchedEvents, tx)
+				if insertWatchedErr != nil {
+					log.Error("insert watched events failed", "layer", layer.String(), "error", insertWatchedErr)
+					return insertWatchedErr
+				}
 				return nil
 			})
 			if updateErr != nil {
@@ -272,9 +391,31 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 				continue
 			}
 
-			if layer == types.Layer2 {
-				l2CurrentMaxBlockNumber.Store(loopEnd)
+			// the failures are alerted once the range is stored, so a rolled back range isn't alerted.
+			c.messageMatchLogic.NotifyMessengerFailures(messengerFailures, messengerEvents)
+			c.governanceLogic.NotifyGovernanceEvents(recordedGovernanceEvents)
+			c.watcherLogic.NotifyWatchedEvents(alertedWatchedEvents)
+			alerts.notify()
+
+			// the nonces are checked once the range is stored, so a retried range isn't checked twice.
+			nonceFailures := c.messageMatchAssembler.MessageNonceValidator(layer, start, loopEnd, messengerEvents, queueEvents)
+			if len(nonceFailures) > 0 {
+				c.contractControllerGatewayCheckFailureTotal.WithLabelValues(layer.String()).Inc()
+			}
+			for _, info := range nonceFailures {
+				slack.Notify(slack.MrkDwnMessageNonceMessage(info))
 			}
+
+			for _, info := range c.ruleLogic.Evaluate(rule.NormalizeEvents(gatewayEvents, messengerEvents)) {
+				slack.Notify(slack.MrkDwnRuleMessage(info))
+			}
+
+			c.transferAlertLogic.CheckLargeTransfers(ctx, layer, gatewayMessageMatches, messengerMessageMatches)
+			if layer == types.Layer1 {
+				c.transferAlertLogic.CheckOutflow(ctx, loopEnd)
+			}
+
+			storeCurrentMaxBlockNumber(layer, loopEnd)
 		}
 
 		// Update start after all handlings are successful.
@@ -282,7 +423,7 @@ func (c *ContractController) watcherStart(ctx context.Context, client *ethclient
 	}
 }
 
-func (c *ContractController) l1Watch(ctx context.Context, start uint64, end uint64) ([]orm.GatewayMessageMatch, []orm.MessengerMessageMatch, error) {
+func (c *ContractController) l1Watch(ctx context.Context, start uint64, end uint64, alerts *rangeAlerts) ([]orm.GatewayMessageMatch, []orm.MessengerMessageMatch, []events.EventUnmarshaler, []events.EventUnmarshaler, error) {
 	log.Info("watching block number", "layer", types.Layer1, "start", start, "end", end)
 	opts := bind.FilterOpts{
 		Start:   start,
@@ -294,33 +435,63 @@ func (c *ContractController) l1Watch(ctx context.Context, start uint64, end uint
 	if err != nil {
 		c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(types.Layer1.String(), types.MessengerEventCategory.String()).Inc()
 		log.Error("get messenger iterator failed", "layer", types.Layer1, "eventCategory", types.MessengerEventCategory, "error", err)
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	messengerEvents := c.eventGatherLogic.Dispatch(ctx, types.Layer1, types.MessengerEventCategory, messengerIterList)
+	if err = c.contractsLogic.DecodeRelayedMessages(ctx, types.Layer1, messengerEvents); err != nil {
+		log.Error("decode relayed messages failed", "layer", types.Layer1, "error", err)
+		return nil, nil, nil, nil, err
+	}
+	mismatchedRelays := c.messageMatchAssembler.RelayedMessageValidator(messengerEvents)
+	if len(mismatchedRelays) > 0 {
+		c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer1.String()).Inc()
+		alerts.relays = append(alerts.relays, mismatchedRelays...)
+	}
 	messengerMessageMatches, err := c.messageMatchAssembler.MessageMatchAssembler(messengerEvents)
 	if err != nil {
 		log.Error("generate messenger message match failed", "layer", types.Layer2, "eventCategory", types.MessengerEventCategory, "error", err)
-		return nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	// replayed and dropped messages are recorded with the messenger events, they don't take part in gateway assemble.
+	replayAndDropEvents, err := c.contractsLogic.GetL1MessengerReplayAndDrop(ctx, start, end)
+	if err != nil {
+		c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(types.Layer1.String(), types.MessengerEventCategory.String()).Inc()
+		log.Error("get messenger replay and drop events failed", "layer", types.Layer1, "error", err)
+		return nil, nil, nil, nil, err
+	}
+
+	if c.conf.L1Config.TraceMessengerOutflow {
+		unattributed, traceErr := c.messageMatchAssembler.MessengerOutflowValidator(ctx, types.Layer1, c.l1Client, c.conf.L1Config.L1Contracts.ScrollMessenger, start, end, append(messengerEvents, replayAndDropEvents...))
+		if traceErr != nil {
+			log.Error("trace messenger outflow failed", "layer", types.Layer1, "error", traceErr)
+			return nil, nil, nil, nil, traceErr
+		}
+		if len(unattributed) > 0 {
+			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer1.String()).Inc()
+			alerts.outflows = append(alerts.outflows, unattributed...)
+		}
 	}
 
 	if len(messengerMessageMatches) == 0 {
-		return nil, nil, nil
+		return nil, nil, append(messengerEvents, replayAndDropEvents...), nil, nil
 	}
 
 	var l1GatewayMessageMatches []orm.GatewayMessageMatch
+	var l1GatewayEvents []events.EventUnmarshaler
 	for _, eventCategory := range c.l1EventCategoryList {
 		wrapIterList, err := c.contractsLogic.Iterator(ctx, &opts, types.Layer1, eventCategory)
 		if err != nil {
 			c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(types.Layer1.String(), eventCategory.String()).Inc()
 			log.Error("get contract iterator failed", "layer", types.Layer1, "eventCategory", eventCategory, "error", err)
-			return nil, nil, err
+			return nil, nil, nil, nil, err
 		}
 
 		transferEvents, err := c.contractsLogic.GetGatewayTransfer(ctx, start, end, types.Layer1, eventCategory)
 		if err != nil {
 			c.contractControllerFilterTransferIteratorFailureTotal.WithLabelValues(types.Layer1.String(), "transfer").Inc()
 			log.Error("get gateway related transfer events failed", "layer", types.Layer1, "eventCategory", eventCategory, "error", err)
-			return nil, nil, err
+			return nil, nil, nil, nil, err
 		}
 
 		// parse the gateway and messenger event data
@@ -329,6 +500,15 @@ func (c *ContractController) l1Watch(ctx context.Context, start uint64, end uint
 			log.Debug("event gather deal event return empty data", "layer", types.Layer1, "eventCategory", eventCategory)
 			continue
 		}
+		l1GatewayEvents = append(l1GatewayEvents, gatewayEvents...)
+		c.resolveTokens(ctx, gatewayEvents)
+
+		// the gateway deposits must bridge what the gateway events claim.
+		mismatchedPayloads := c.messageMatchAssembler.MessagePayloadValidator(gatewayEvents, messengerEvents)
+		if len(mismatchedPayloads) > 0 {
+			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer1.String()).Inc()
+			alerts.payloads = append(alerts.payloads, mismatchedPayloads...)
+		}
 
 		// match transfer event
 		retL1MessageMatches, checkErr := c.messageMatchAssembler.GatewayMessageAssembler(eventCategory, gatewayEvents, messengerEvents, transferEvents)
@@ -336,14 +516,14 @@ func (c *ContractController) l1Watch(ctx context.Context, start uint64, end uint
 		if checkErr != nil {
 			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer1.String()).Inc()
 			log.Error("event matcher deal failed", "layer", types.Layer1, "eventCategory", eventCategory, "error", checkErr)
-			return nil, nil, err
+			return nil, nil, nil, nil, err
 		}
 	}
 
-	return l1GatewayMessageMatches, messengerMessageMatches, nil
+	return l1GatewayMessageMatches, messengerMessageMatches, append(messengerEvents, replayAndDropEvents...), l1GatewayEvents, nil
 }
 
-func (c *ContractController) l2Watch(ctx context.Context, start uint64, end uint64) ([]orm.GatewayMessageMatch, []orm.MessengerMessageMatch, error) {
+func (c *ContractController) l2Watch(ctx context.Context, start uint64, end uint64, alerts *rangeAlerts) ([]orm.GatewayMessageMatch, []orm.MessengerMessageMatch, []events.EventUnmarshaler, []events.EventUnmarshaler, error) {
 	log.Info("watching block number", "layer", types.Layer2, "start", start, "end", end)
 	opts := bind.FilterOpts{
 		Start:   start,
@@ -355,43 +535,86 @@ func (c *ContractController) l2Watch(ctx context.Context, start uint64, end uint
 	if err != nil {
 		c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(types.Layer2.String(), types.MessengerEventCategory.String()).Inc()
 		log.Error("get messenger iterator failed", "layer", types.Layer2, "eventCategory", types.MessengerEventCategory, "error", err)
-		return nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 	messengerEvents := c.eventGatherLogic.Dispatch(ctx, types.Layer2, types.MessengerEventCategory, messengerIterList)
+	if err = c.contractsLogic.DecodeRelayedMessages(ctx, types.Layer2, messengerEvents); err != nil {
+		log.Error("decode relayed messages failed", "layer", types.Layer2, "error", err)
+		return nil, nil, nil, nil, err
+	}
+	mismatchedRelays := c.messageMatchAssembler.RelayedMessageValidator(messengerEvents)
+	if len(mismatchedRelays) > 0 {
+		c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer2.String()).Inc()
+		alerts.relays = append(alerts.relays, mismatchedRelays...)
+	}
+	if c.conf.L2Config.TraceMessengerOutflow {
+		unattributed, traceErr := c.messageMatchAssembler.MessengerOutflowValidator(ctx, types.Layer2, c.l2Client, c.conf.L2Config.L2Contracts.ScrollMessenger, start, end, messengerEvents)
+		if traceErr != nil {
+			log.Error("trace messenger outflow failed", "layer", types.Layer2, "error", traceErr)
+			return nil, nil, nil, nil, traceErr
+		}
+		if len(unattributed) > 0 {
+			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer2.String()).Inc()
+			alerts.outflows = append(alerts.outflows, unattributed...)
+		}
+	}
 	messengerMessageMatches, err := c.messageMatchAssembler.MessageMatchAssembler(messengerEvents)
 	if err != nil {
 		log.Error("generate messenger message match failed", "layer", types.Layer2, "eventCategory", types.MessengerEventCategory, "error", err)
-		return nil, nil, err
-	}
-
-	if len(messengerMessageMatches) == 0 {
-		return nil, nil, nil
+		return nil, nil, nil, nil, err
 	}
 
 	var l2GatewayMessageMatches []orm.GatewayMessageMatch
+	var l2GatewayEvents []events.EventUnmarshaler
 	for _, eventCategory := range c.l2EventCategoryList {
-		var wrapIterList []types.WrapIterator
-		wrapIterList, err = c.contractsLogic.Iterator(ctx, &opts, types.Layer2, eventCategory)
-		if err != nil {
-			c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(types.Layer2.String(), eventCategory.String()).Inc()
-			log.Error("get contract iterator failed", "layer", types.Layer2, "eventCategory", eventCategory, "error", err)
-			return nil, nil, err
-		}
-
 		var transferEvents []events.EventUnmarshaler
 		transferEvents, err = c.contractsLogic.GetGatewayTransfer(ctx, start, end, types.Layer2, eventCategory)
 		if err != nil {
 			c.contractControllerFilterTransferIteratorFailureTotal.WithLabelValues(types.Layer2.String(), "transfer").Inc()
 			log.Error("get gateway related transfer events failed", "layer", types.Layer2, "eventCategory", eventCategory, "error", err)
-			return nil, nil, err
+			return nil, nil, nil, nil, err
+		}
+
+		// every gateway event comes with a messenger event, there is no gateway event without messenger event.
+		var gatewayEvents []events.EventUnmarshaler
+		if len(messengerMessageMatches) != 0 {
+			var wrapIterList []types.WrapIterator
+			wrapIterList, err = c.contractsLogic.Iterator(ctx, &opts, types.Layer2, eventCategory)
+			if err != nil {
+				c.contractControllerFilterGatewayIteratorFailureTotal.WithLabelValues(types.Layer2.String(), eventCategory.String()).Inc()
+				log.Error("get contract iterator failed", "layer", types.Layer2, "eventCategory", eventCategory, "error", err)
+				return nil, nil, nil, nil, err
+			}
+
+			// parse the event data
+			gatewayEvents = c.eventGatherLogic.Dispatch(ctx, types.Layer2, eventCategory, wrapIterList)
+		}
+
+		// the mints and burns of bridged tokens outside the gateway transactions.
+		bridgedTokens, bridgedErr := c.contractsLogic.GetL2BridgedTokens(ctx, assembler.TokenAddresses(transferEvents), assembler.TokenAddresses(gatewayEvents))
+		if bridgedErr != nil {
+			log.Error("get l2 bridged tokens failed", "layer", types.Layer2, "eventCategory", eventCategory, "error", bridgedErr)
+			return nil, nil, nil, nil, bridgedErr
+		}
+		mismatchedMintBurns := c.messageMatchAssembler.L2MintBurnValidator(gatewayEvents, transferEvents, bridgedTokens)
+		if len(mismatchedMintBurns) > 0 {
+			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer2.String()).Inc()
+			alerts.mintBurns = append(alerts.mintBurns, mismatchedMintBurns...)
 		}
 
-		// parse the event data
-		gatewayEvents := c.eventGatherLogic.Dispatch(ctx, types.Layer2, eventCategory, wrapIterList)
 		if gatewayEvents == nil {
 			log.Debug("dispatch gateway events returns empty data", "layer", types.Layer2, "eventCategory", eventCategory)
 			continue
 		}
+		l2GatewayEvents = append(l2GatewayEvents, gatewayEvents...)
+		c.resolveTokens(ctx, gatewayEvents)
+
+		// the gateway withdrawals must bridge what the gateway events claim.
+		mismatchedPayloads := c.messageMatchAssembler.MessagePayloadValidator(gatewayEvents, messengerEvents)
+		if len(mismatchedPayloads) > 0 {
+			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer2.String()).Inc()
+			alerts.payloads = append(alerts.payloads, mismatchedPayloads...)
+		}
 
 		// match transfer event
 		retL2MessageMatches, checkErr := c.messageMatchAssembler.GatewayMessageAssembler(eventCategory, gatewayEvents, messengerEvents, transferEvents)
@@ -399,8 +622,72 @@ func (c *ContractController) l2Watch(ctx context.Context, start uint64, end uint
 		if checkErr != nil {
 			c.contractControllerGatewayCheckFailureTotal.WithLabelValues(types.Layer2.String()).Inc()
 			log.Error("event matcher deal failed", "layer", types.Layer2, "eventCategory", eventCategory, "error", checkErr)
-			return nil, nil, err
+			return nil, nil, nil, nil, err
+		}
+	}
+	return l2GatewayMessageMatches, messengerMessageMatches, messengerEvents, l2GatewayEvents, nil
+}
+
+// seedMessageNonce restores the next message nonce of the layer at the block number stored in db. The l2 one comes
+// from the stored l2 sent messages, the l1 one is read from the l1 message queue, since the enforced transactions
+// consuming the l1 queue indexes aren't stored.
+func (c *ContractController) seedMessageNonce(ctx context.Context, layer types.LayerType, blockNumber uint64) error {
+	if layer == types.Layer2 {
+		return c.messageMatchAssembler.SeedL2MessageNonce(ctx)
+	}
+
+	nextQueueIndex, ok, err := c.contractsLogic.GetL1NextQueueIndex(ctx, blockNumber)
+	if err != nil || !ok {
+		return err
+	}
+	c.messageMatchAssembler.SeedMessageNonce(layer, nextQueueIndex, blockNumber, common.Hash{})
+	return nil
+}
+
+// resolveTokens caches the metadata of the bridged erc20 tokens on both layers, so the alerts of the range show
+// their amounts with symbols.
+func (c *ContractController) resolveTokens(ctx context.Context, gatewayEvents []events.EventUnmarshaler) {
+	for _, event := range gatewayEvents {
+		erc20Event, ok := event.(*events.ERC20GatewayEventUnmarshaler)
+		if !ok {
+			continue
+		}
+		if _, err := token.Resolve(ctx, types.Layer1, erc20Event.L1Token); err != nil {
+			log.Debug("resolve token metadata failed", "layer", types.Layer1, "token", erc20Event.L1Token.Hex(), "error", err)
 		}
+		if _, err := token.Resolve(ctx, types.Layer2, erc20Event.L2Token); err != nil {
+			log.Debug("resolve token metadata failed", "layer", types.Layer2, "token", erc20Event.L2Token.Hex(), "error", err)
+		}
+	}
+}
+
+// rangeAlerts the alerts raised by the validators while watching a range, they are notified once the range is stored,
+// so a range rolled back and watched again isn't alerted twice.
+type rangeAlerts struct {
+	mintBurns []slack.UnauthorizedMintBurnInfo
+	payloads  []slack.MessagePayloadInfo
+	relays    []slack.RelayedMessageHashInfo
+	outflows  []slack.MessengerOutflowInfo
+}
+
+func (a *rangeAlerts) append(other rangeAlerts) {
+	a.mintBurns = append(a.mintBurns, other.mintBurns...)
+	a.payloads = append(a.payloads, other.payloads...)
+	a.relays = append(a.relays, other.relays...)
+	a.outflows = append(a.outflows, other.outflows...)
+}
+
+func (a *rangeAlerts) notify() {
+	for _, info := range a.mintBurns {
+		slack.Notify(slack.MrkDwnUnauthorizedMintBurnMessage(info))
+	}
+	for _, info := range a.payloads {
+		slack.Notify(slack.MrkDwnMessagePayloadMessage(info))
+	}
+	for _, info := range a.relays {
+		slack.Notify(slack.MrkDwnRelayedMessageHashMessage(info))
+	}
+	for _, info := range a.outflows {
+		slack.Notify(slack.MrkDwnMessengerOutflowMessage(info))
 	}
-	return l2GatewayMessageMatches, messengerMessageMatches, nil
 }
@@ -6,14 +6,39 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/types"
 )
 
-var l2CurrentMaxBlockNumber atomic.Uint64
+var (
+	l1CurrentMaxBlockNumber atomic.Uint64
+	l2CurrentMaxBlockNumber atomic.Uint64
+)
 
-// FinalizeBatchCtl the Finalize batch handler
-var FinalizeBatchCtl *FinalizeBatchCheckController
+var (
+	// FinalizeBatchCtl the Finalize batch handler
+	FinalizeBatchCtl *FinalizeBatchCheckController
+	// AlertCtl the critical alert acknowledgement handler
+	AlertCtl *AlertController
+	// AuthCtl the admin token authentication of the api
+	AuthCtl *AuthController
+	// SilenceCtl the alert silence handler
+	SilenceCtl *SilenceController
+)
 
 // InitAPI init the api controller
 func InitAPI(conf *config.Config, db *gorm.DB) {
 	FinalizeBatchCtl = NewFinalizeBatchCheckController(conf, db)
+	AlertCtl = NewAlertController()
+	AuthCtl = NewAuthController(conf)
+	SilenceCtl = NewSilenceController(db)
+}
+
+// storeCurrentMaxBlockNumber stores the block number the contract controller of the layer has processed up to.
+func storeCurrentMaxBlockNumber(layer types.LayerType, blockNumber uint64) {
+	switch layer {
+	case types.Layer1:
+		l1CurrentMaxBlockNumber.Store(blockNumber)
+	case types.Layer2:
+		l2CurrentMaxBlockNumber.Store(blockNumber)
+	}
 }
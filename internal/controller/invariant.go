@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/invariant"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// invariantCheckInterval polls often enough to follow every move of the contract controller, the invariants with
+// their own interval are only evaluated once it elapses.
+const invariantCheckInterval = 5 * time.Second
+
+// InvariantController evaluates the configured contract state invariants.
+type InvariantController struct {
+	invariantLogic *invariant.LogicInvariant
+
+	stopInvariantChan chan struct{}
+
+	invariantControllerRunningTotal prometheus.Counter
+}
+
+// NewInvariantController is a constructor function that creates a new InvariantController object.
+func NewInvariantController(cfg *config.Config, l1Client, l2Client *ethclient.Client) *InvariantController {
+	invariantLogic, err := invariant.NewLogicInvariant(cfg, l1Client, l2Client)
+	if err != nil {
+		log.Crit("invariant register failure", "error", err)
+	}
+
+	return &InvariantController{
+		invariantLogic:    invariantLogic,
+		stopInvariantChan: make(chan struct{}),
+		invariantControllerRunningTotal: promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+			Name: "invariant_check_controller_running_total",
+			Help: "The total number of invariant check controllers running.",
+		}),
+	}
+}
+
+// Watch starts the invariant evaluation.
+func (c *InvariantController) Watch(ctx context.Context) {
+	go c.watcherStart(ctx)
+}
+
+// Stop the invariant controller
+func (c *InvariantController) Stop() {
+	c.stopInvariantChan <- struct{}{}
+}
+
+func (c *InvariantController) watcherStart(ctx context.Context) {
+	log.Info("invariant controller start successful")
+
+	tick := time.NewTicker(invariantCheckInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			tick.Stop()
+			if ctx.Err() != nil {
+				log.Error("InvariantController watch canceled with error", "error", ctx.Err())
+			}
+			return
+		case <-c.stopInvariantChan:
+			tick.Stop()
+			log.Info("InvariantController the run loop exit")
+			return
+		case <-tick.C:
+			c.invariantControllerRunningTotal.Inc()
+			c.invariantLogic.Check(ctx, types.Layer1, l1CurrentMaxBlockNumber.Load())
+			c.invariantLogic.Check(ctx, types.Layer2, l2CurrentMaxBlockNumber.Load())
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	crosschain "github.com/scroll-tech/chain-monitor/internal/logic/cross_chain"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// messengerBalanceCheckInterval the reconciliation only needs one balance query of each layer per round.
+const messengerBalanceCheckInterval = time.Minute
+
+// MessengerBalanceController periodically reconciles the tracked messenger eth balances with the balances on chain.
+type MessengerBalanceController struct {
+	messengerBalanceLogic *crosschain.LogicMessengerBalance
+
+	stopMessengerBalanceChan chan struct{}
+
+	messengerBalanceControllerRunningTotal prometheus.Counter
+}
+
+// NewMessengerBalanceController is a constructor function that creates a new MessengerBalanceController object.
+func NewMessengerBalanceController(cfg *config.Config, db *gorm.DB, l1Client, l2Client *ethclient.Client) *MessengerBalanceController {
+	l1MessengerAddr := cfg.L1Config.L1Contracts.ScrollMessenger
+	l2MessengerAddr := cfg.L2Config.L2Contracts.ScrollMessenger
+	return &MessengerBalanceController{
+		messengerBalanceLogic:    crosschain.NewLogicMessengerBalance(db, l1Client, l2Client, l1MessengerAddr, l2MessengerAddr),
+		stopMessengerBalanceChan: make(chan struct{}),
+		messengerBalanceControllerRunningTotal: promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+			Name: "messenger_balance_check_controller_running_total",
+			Help: "The total number of messenger balance check controllers running.",
+		}),
+	}
+}
+
+// Watch starts the messenger balance reconciliation.
+func (c *MessengerBalanceController) Watch(ctx context.Context) {
+	go c.watcherStart(ctx)
+}
+
+// Stop the messenger balance controller
+func (c *MessengerBalanceController) Stop() {
+	c.stopMessengerBalanceChan <- struct{}{}
+}
+
+func (c *MessengerBalanceController) watcherStart(ctx context.Context) {
+	log.Info("messenger balance controller start successful")
+
+	tick := time.NewTicker(messengerBalanceCheckInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			tick.Stop()
+			if ctx.Err() != nil {
+				log.Error("MessengerBalanceController watch canceled with error", "error", ctx.Err())
+			}
+			return
+		case <-c.stopMessengerBalanceChan:
+			tick.Stop()
+			log.Info("MessengerBalanceController the run loop exit")
+			return
+		case <-tick.C:
+			c.messengerBalanceControllerRunningTotal.Inc()
+			c.messengerBalanceLogic.ReconcileETHBalance(ctx, types.Layer1, l1CurrentMaxBlockNumber.Load())
+			c.messengerBalanceLogic.ReconcileETHBalance(ctx, types.Layer2, l2CurrentMaxBlockNumber.Load())
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/governance"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// proxyDriftCheckInterval the proxy state changes rarely, there is no need to check it every block.
+const proxyDriftCheckInterval = time.Minute
+
+// ProxyDriftController periodically checks the bridge proxies against the pinned baseline.
+type ProxyDriftController struct {
+	proxyDriftLogic *governance.LogicProxyDrift
+
+	stopL1ProxyDriftChan chan struct{}
+	stopL2ProxyDriftChan chan struct{}
+
+	proxyDriftControllerRunningTotal *prometheus.CounterVec
+}
+
+// NewProxyDriftController is a constructor function that creates a new ProxyDriftController object.
+func NewProxyDriftController(cfg *config.Config, db *gorm.DB, l1Client, l2Client *ethclient.Client) *ProxyDriftController {
+	return &ProxyDriftController{
+		proxyDriftLogic:      governance.NewLogicProxyDrift(cfg, db, l1Client, l2Client),
+		stopL1ProxyDriftChan: make(chan struct{}),
+		stopL2ProxyDriftChan: make(chan struct{}),
+		proxyDriftControllerRunningTotal: promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+			Name: "proxy_drift_check_controller_running_total",
+			Help: "The total number of proxy drift check controllers running.",
+		}, []string{"layer"}),
+	}
+}
+
+// Watch starts the proxy drift checker of both layers.
+func (c *ProxyDriftController) Watch(ctx context.Context) {
+	go c.watcherStart(ctx, types.Layer1, c.stopL1ProxyDriftChan)
+	go c.watcherStart(ctx, types.Layer2, c.stopL2ProxyDriftChan)
+}
+
+// Stop all the proxy drift controller
+func (c *ProxyDriftController) Stop() {
+	c.stopL1ProxyDriftChan <- struct{}{}
+	c.stopL2ProxyDriftChan <- struct{}{}
+}
+
+func (c *ProxyDriftController) watcherStart(ctx context.Context, layer types.LayerType, stopChan chan struct{}) {
+	log.Info("proxy drift controller start successful", "layer", layer.String())
+
+	tick := time.NewTicker(proxyDriftCheckInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			tick.Stop()
+			if ctx.Err() != nil {
+				log.Error("ProxyDriftController watch canceled with error", "layer", layer.String(), "error", ctx.Err())
+			}
+			return
+		case <-stopChan:
+			tick.Stop()
+			log.Info("ProxyDriftController the run loop exit", "layer", layer.String())
+			return
+		case <-tick.C:
+			c.proxyDriftControllerRunningTotal.WithLabelValues(layer.String()).Inc()
+			c.proxyDriftLogic.CheckProxyDrift(ctx, layer)
+		}
+	}
+}
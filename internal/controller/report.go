@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/report"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+// reportCheckInterval the watcher uptime is sampled at this interval, and the digests are sent in the first check of
+// the send hour.
+const reportCheckInterval = time.Minute
+
+// ReportController samples the watcher uptime and mails the daily and weekly bridge health digests.
+type ReportController struct {
+	cfg         *config.DigestConfig
+	reportLogic *report.LogicReport
+	uptime      *report.UptimeTracker
+
+	// lastDaily and lastWeekly the end times of the digests sent last.
+	lastDaily  time.Time
+	lastWeekly time.Time
+
+	stopReportChan chan struct{}
+
+	reportControllerRunningTotal prometheus.Counter
+	reportSentTotal              *prometheus.CounterVec
+	reportFailureTotal           *prometheus.CounterVec
+}
+
+// NewReportController is a constructor function that creates a new ReportController object, the controller does
+// nothing if the digests aren't configured.
+func NewReportController(cfg *config.Config, db *gorm.DB) *ReportController {
+	c := &ReportController{
+		cfg:            cfg.DigestConfig,
+		stopReportChan: make(chan struct{}),
+	}
+	if cfg.DigestConfig == nil {
+		return c
+	}
+
+	c.uptime = report.NewUptimeTracker(prometheus.DefaultGatherer, db, reportCheckInterval)
+	reportLogic, err := report.NewLogicReport(cfg.DigestConfig, db, c.uptime)
+	if err != nil {
+		log.Crit("NewLogicReport failed", "error", err)
+	}
+	c.reportLogic = reportLogic
+	c.reportControllerRunningTotal = promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+		Name: "report_controller_running_total",
+		Help: "The total number of report controllers running.",
+	})
+	c.reportSentTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "report_digest_sent_total",
+		Help: "The total number of bridge health digests sent.",
+	}, []string{"period"})
+	c.reportFailureTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "report_digest_failure_total",
+		Help: "The total number of bridge health digests failed to send.",
+	}, []string{"period"})
+	return c
+}
+
+// Watch starts sampling the watcher uptime and sending the digests.
+func (c *ReportController) Watch(ctx context.Context) {
+	if c.reportLogic == nil {
+		return
+	}
+	go c.watcherStart(ctx)
+}
+
+// Stop the report controller
+func (c *ReportController) Stop() {
+	if c.reportLogic == nil {
+		return
+	}
+	c.stopReportChan <- struct{}{}
+}
+
+func (c *ReportController) watcherStart(ctx context.Context) {
+	log.Info("report controller start successful")
+
+	tick := time.NewTicker(reportCheckInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			tick.Stop()
+			if ctx.Err() != nil {
+				log.Error("ReportController watch canceled with error", "error", ctx.Err())
+			}
+			return
+		case <-c.stopReportChan:
+			tick.Stop()
+			log.Info("ReportController the run loop exit")
+			return
+		case <-tick.C:
+			c.reportControllerRunningTotal.Inc()
+			now := utils.NowUTC()
+			c.uptime.Sample(ctx, now)
+			c.sendDigests(ctx, now)
+		}
+	}
+}
+
+func (c *ReportController) sendDigests(ctx context.Context, now time.Time) {
+	if now.Hour() != c.cfg.SendHour {
+		return
+	}
+	endTime := now.Truncate(time.Hour)
+
+	if c.cfg.Daily && !c.lastDaily.Equal(endTime) {
+		c.lastDaily = endTime
+		c.sendDigest(ctx, report.Daily, endTime)
+	}
+	if c.cfg.Weekly && now.Weekday() == time.Monday && !c.lastWeekly.Equal(endTime) {
+		c.lastWeekly = endTime
+		c.sendDigest(ctx, report.Weekly, endTime)
+	}
+}
+
+func (c *ReportController) sendDigest(ctx context.Context, period report.Period, endTime time.Time) {
+	if err := c.reportLogic.SendDigest(ctx, period, endTime); err != nil {
+		c.reportFailureTotal.WithLabelValues(string(period)).Inc()
+		log.Error("send bridge health digest failed", "period", period, "end time", endTime, "error", err)
+		return
+	}
+	c.reportSentTotal.WithLabelValues(string(period)).Inc()
+	log.Info("bridge health digest sent", "period", period, "end time", endTime)
+}
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/reserve"
+)
+
+// reserveCheckInterval the finalized heights move slowly, there is no need to reconcile every block.
+const reserveCheckInterval = 5 * time.Minute
+
+// ReserveController periodically reconciles the l1 escrow of the bridged tokens with their l2 supply.
+type ReserveController struct {
+	reserveLogic *reserve.LogicReserve
+
+	stopReserveChan chan struct{}
+
+	reserveControllerRunningTotal prometheus.Counter
+}
+
+// NewReserveController is a constructor function that creates a new ReserveController object.
+func NewReserveController(cfg *config.Config, db *gorm.DB, l1Client, l2Client *ethclient.Client) *ReserveController {
+	return &ReserveController{
+		reserveLogic:    reserve.NewLogicReserve(cfg, db, l1Client, l2Client),
+		stopReserveChan: make(chan struct{}),
+		reserveControllerRunningTotal: promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+			Name: "reserve_check_controller_running_total",
+			Help: "The total number of reserve check controllers running.",
+		}),
+	}
+}
+
+// Watch starts the reserve reconciliation.
+func (c *ReserveController) Watch(ctx context.Context) {
+	go c.watcherStart(ctx)
+}
+
+// Stop the reserve controller
+func (c *ReserveController) Stop() {
+	c.stopReserveChan <- struct{}{}
+}
+
+func (c *ReserveController) watcherStart(ctx context.Context) {
+	log.Info("reserve controller start successful")
+
+	tick := time.NewTicker(reserveCheckInterval)
+	for {
+		select {
+		case <-ctx.Done():
+			tick.Stop()
+			if ctx.Err() != nil {
+				log.Error("ReserveController watch canceled with error", "error", ctx.Err())
+			}
+			return
+		case <-c.stopReserveChan:
+			tick.Stop()
+			log.Info("ReserveController the run loop exit")
+			return
+		case <-tick.C:
+			c.reserveControllerRunningTotal.Inc()
+			c.reserveLogic.CheckReserves(ctx)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/silence"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// silencedAlertsLimit the maximum number of the latest silenced alerts listed of a silence.
+const silencedAlertsLimit = 100
+
+// SilenceController the alert silence handler
+type SilenceController struct {
+	silenceLogic *silence.LogicSilence
+}
+
+// NewSilenceController create alert silence controller instance
+func NewSilenceController(db *gorm.DB) *SilenceController {
+	return &SilenceController{
+		silenceLogic: silence.NewLogicSilence(db),
+	}
+}
+
+// Create create an alert silence, it returns the id of the silence
+func (s *SilenceController) Create(ctx *gin.Context) {
+	var createParam types.SilenceCreateParam
+	if err := ctx.ShouldBindJSON(&createParam); err != nil {
+		log.Error("create alert silence failed", "error", err)
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	id, err := s.silenceLogic.CreateSilence(ctx, orm.AlertSilence{
+		Kind:      createParam.Kind,
+		Layer:     createParam.Layer,
+		Token:     createParam.Token,
+		Address:   createParam.Address,
+		StartsAt:  createParam.StartsAt,
+		EndsAt:    createParam.EndsAt,
+		CreatedBy: ctx.GetString(adminCallerKey),
+		Reason:    createParam.Reason,
+	})
+	if errors.Is(err, silence.ErrInvalidSilence) {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+	if err != nil {
+		types.RenderFatal(ctx, err)
+		return
+	}
+	types.RenderSuccess(ctx, id)
+}
+
+// List list the active and the pending alert silences with their creators and reasons
+func (s *SilenceController) List(ctx *gin.Context) {
+	silences, err := s.silenceLogic.ListSilences(ctx)
+	if err != nil {
+		types.RenderFatal(ctx, err)
+		return
+	}
+	types.RenderSuccess(ctx, silences)
+}
+
+// Expire expire the alert silence now
+func (s *SilenceController) Expire(ctx *gin.Context) {
+	var idParam types.SilenceIDParam
+	if err := ctx.ShouldBindUri(&idParam); err != nil {
+		log.Error("expire alert silence failed", "error", err)
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	err := s.silenceLogic.ExpireSilence(ctx, idParam.ID)
+	if errors.Is(err, silence.ErrSilenceNotFound) {
+		types.RenderFailure(ctx, types.ErrSilenceNotFoundNo, err)
+		return
+	}
+	if err != nil {
+		types.RenderFatal(ctx, err)
+		return
+	}
+	log.Info("alert silence expired", "id", idParam.ID, "by", ctx.GetString(adminCallerKey))
+	types.RenderSuccess(ctx, nil)
+}
+
+// SilencedAlerts list the latest alerts silenced by the alert silence
+func (s *SilenceController) SilencedAlerts(ctx *gin.Context) {
+	var idParam types.SilenceIDParam
+	if err := ctx.ShouldBindUri(&idParam); err != nil {
+		log.Error("list silenced alerts failed", "error", err)
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	alerts, err := s.silenceLogic.SilencedAlerts(ctx, idParam.ID, silencedAlertsLimit)
+	if err != nil {
+		types.RenderFatal(ctx, err)
+		return
+	}
+	types.RenderSuccess(ctx, alerts)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 
 	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
 
 	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/silence"
 	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
 )
 
@@ -14,10 +16,18 @@ type SlackAlertController struct {
 	slackLogic *slack.AlertSlack
 }
 
-// NewSlackAlertController create SlackAlertController
-func NewSlackAlertController(ctx context.Context, conf *config.SlackWebhookConfig) *SlackAlertController {
+// NewSlackAlertController create SlackAlertController, the alerts are routed and escalated by the optional routing config,
+// and the alerts matching an active silence in the db are recorded instead of notified
+func NewSlackAlertController(ctx context.Context, conf *config.SlackWebhookConfig, routing *config.AlertRoutingConfig, db *gorm.DB) *SlackAlertController {
+	if conf.MinSeverity != "" && !slack.ValidSeverity(conf.MinSeverity) {
+		log.Crit("invalid slack min severity", "min severity", conf.MinSeverity)
+	}
+	if err := slack.ValidateRouting(routing); err != nil {
+		log.Crit("invalid alert routing config", "error", err)
+	}
+	slack.RegisterSilencer(silence.NewLogicSilence(db))
 	return &SlackAlertController{
-		slackLogic: slack.NewAlertSlack(ctx, conf),
+		slackLogic: slack.NewAlertSlack(ctx, conf, routing),
 	}
 }
 
@@ -21,16 +21,20 @@ type messageEventKey struct {
 // MessageMatchAssembler is a structure that helps in verifying the data integrity
 // in the blockchain by checking the message matches and the events.
 type MessageMatchAssembler struct {
-	messengerMessageMatchOrm *orm.MessengerMessageMatch
+	messengerMessageMatchOrm  *orm.MessengerMessageMatch
+	withdrawTrieCheckpointOrm *orm.WithdrawTrieCheckpoint
 
 	transferMatcher *TransferEventMatcher
+	nonceChecker    *MessageNonceChecker
 }
 
 // NewMessageMatchAssembler returns a new message match instance.
 func NewMessageMatchAssembler(db *gorm.DB) *MessageMatchAssembler {
 	return &MessageMatchAssembler{
-		messengerMessageMatchOrm: orm.NewMessengerMessageMatch(db),
-		transferMatcher:          NewTransferEventMatcher(),
+		messengerMessageMatchOrm:  orm.NewMessengerMessageMatch(db),
+		withdrawTrieCheckpointOrm: orm.NewWithdrawTrieCheckpoint(db),
+		transferMatcher:           NewTransferEventMatcher(),
+		nonceChecker:              NewMessageNonceChecker(),
 	}
 }
 
@@ -47,9 +51,11 @@ func (c *MessageMatchAssembler) GatewayMessageAssembler(eventCategory types.Even
 	return nil, nil
 }
 
-// L2WithdrawRootsValidator the L2 withdraw roots validator.
-func (c *MessageMatchAssembler) L2WithdrawRootsValidator(ctx context.Context, startBlockNumber, endBlockNumber uint64, client *rpc.Client, messageQueueAddr common.Address) (*orm.MessengerMessageMatch, error) {
-	return c.checkL2WithdrawRoots(ctx, startBlockNumber, endBlockNumber, client, messageQueueAddr)
+// L2WithdrawRootsValidator the L2 withdraw roots validator, the blocks of every checkInterval blocks are also checked
+// to keep the withdraw root unchanged when no message was appended. It returns the last checked message and the
+// withdraw trie checkpoints of the blocks with messages.
+func (c *MessageMatchAssembler) L2WithdrawRootsValidator(ctx context.Context, startBlockNumber, endBlockNumber uint64, client *rpc.Client, messageQueueAddr common.Address, checkInterval uint64) (*orm.MessengerMessageMatch, []orm.WithdrawTrieCheckpoint, error) {
+	return c.checkL2WithdrawRoots(ctx, startBlockNumber, endBlockNumber, client, messageQueueAddr, checkInterval)
 }
 
 // MessageMatchAssembler assembles the messenger events.
@@ -49,6 +49,10 @@ func (c *MessageMatchAssembler) erc1155EventMessageMatchAssembler(gatewayEventsD
 				L1EventType:   int(erc1155EventUnmarshaler.Type),
 				L1BlockNumber: erc1155EventUnmarshaler.Number,
 				L1TxHash:      erc1155EventUnmarshaler.TxHash.Hex(),
+				L1From:        erc1155EventUnmarshaler.From.Hex(),
+				L1To:          erc1155EventUnmarshaler.To.Hex(),
+				L1L1Token:     erc1155EventUnmarshaler.L1Token.Hex(),
+				L1L2Token:     erc1155EventUnmarshaler.L2Token.Hex(),
 				L1TokenIds:    strings.Join(tokenIdsStrList, ","),
 				L1Amounts:     strings.Join(amountStrList, ","),
 			}
@@ -73,6 +77,10 @@ func (c *MessageMatchAssembler) erc1155EventMessageMatchAssembler(gatewayEventsD
 				L1EventType:   int(erc1155EventUnmarshaler.Type),
 				L1BlockNumber: erc1155EventUnmarshaler.Number,
 				L1TxHash:      erc1155EventUnmarshaler.TxHash.Hex(),
+				L1From:        erc1155EventUnmarshaler.From.Hex(),
+				L1To:          erc1155EventUnmarshaler.To.Hex(),
+				L1L1Token:     erc1155EventUnmarshaler.L1Token.Hex(),
+				L1L2Token:     erc1155EventUnmarshaler.L2Token.Hex(),
 				L1TokenIds:    strings.Join(tokenIdsStrList, ","),
 				L1Amounts:     strings.Join(amountStrList, ","),
 			}
@@ -97,6 +105,10 @@ func (c *MessageMatchAssembler) erc1155EventMessageMatchAssembler(gatewayEventsD
 				L2EventType:   int(erc1155EventUnmarshaler.Type),
 				L2BlockNumber: erc1155EventUnmarshaler.Number,
 				L2TxHash:      erc1155EventUnmarshaler.TxHash.Hex(),
+				L2From:        erc1155EventUnmarshaler.From.Hex(),
+				L2To:          erc1155EventUnmarshaler.To.Hex(),
+				L2L1Token:     erc1155EventUnmarshaler.L1Token.Hex(),
+				L2L2Token:     erc1155EventUnmarshaler.L2Token.Hex(),
 				L2TokenIds:    strings.Join(tokenIdsStrList, ","),
 				L2Amounts:     strings.Join(amountStrList, ","),
 			}
@@ -121,6 +133,10 @@ func (c *MessageMatchAssembler) erc1155EventMessageMatchAssembler(gatewayEventsD
 				L2EventType:   int(erc1155EventUnmarshaler.Type),
 				L2BlockNumber: erc1155EventUnmarshaler.Number,
 				L2TxHash:      erc1155EventUnmarshaler.TxHash.Hex(),
+				L2From:        erc1155EventUnmarshaler.From.Hex(),
+				L2To:          erc1155EventUnmarshaler.To.Hex(),
+				L2L1Token:     erc1155EventUnmarshaler.L1Token.Hex(),
+				L2L2Token:     erc1155EventUnmarshaler.L2Token.Hex(),
 				L2TokenIds:    strings.Join(tokenIdsStrList, ","),
 				L2Amounts:     strings.Join(amountStrList, ","),
 			}
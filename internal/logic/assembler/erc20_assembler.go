@@ -41,6 +41,10 @@ func (c *MessageMatchAssembler) erc20EventMessageMatchAssembler(gatewayEventsDat
 				L1EventType:   int(erc20EventUnmarshaler.Type),
 				L1BlockNumber: erc20EventUnmarshaler.Number,
 				L1TxHash:      erc20EventUnmarshaler.TxHash.Hex(),
+				L1From:        erc20EventUnmarshaler.From.Hex(),
+				L1To:          erc20EventUnmarshaler.To.Hex(),
+				L1L1Token:     erc20EventUnmarshaler.L1Token.Hex(),
+				L1L2Token:     erc20EventUnmarshaler.L2Token.Hex(),
 				L1Amounts:     decimal.NewFromBigInt(erc20EventUnmarshaler.Amount, 0).String(),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -56,6 +60,10 @@ func (c *MessageMatchAssembler) erc20EventMessageMatchAssembler(gatewayEventsDat
 				L1EventType:   int(erc20EventUnmarshaler.Type),
 				L1BlockNumber: erc20EventUnmarshaler.Number,
 				L1TxHash:      erc20EventUnmarshaler.TxHash.Hex(),
+				L1From:        erc20EventUnmarshaler.From.Hex(),
+				L1To:          erc20EventUnmarshaler.To.Hex(),
+				L1L1Token:     erc20EventUnmarshaler.L1Token.Hex(),
+				L1L2Token:     erc20EventUnmarshaler.L2Token.Hex(),
 				L1Amounts:     decimal.NewFromBigInt(erc20EventUnmarshaler.Amount, 0).String(),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -71,6 +79,10 @@ func (c *MessageMatchAssembler) erc20EventMessageMatchAssembler(gatewayEventsDat
 				L2EventType:   int(erc20EventUnmarshaler.Type),
 				L2BlockNumber: erc20EventUnmarshaler.Number,
 				L2TxHash:      erc20EventUnmarshaler.TxHash.Hex(),
+				L2From:        erc20EventUnmarshaler.From.Hex(),
+				L2To:          erc20EventUnmarshaler.To.Hex(),
+				L2L1Token:     erc20EventUnmarshaler.L1Token.Hex(),
+				L2L2Token:     erc20EventUnmarshaler.L2Token.Hex(),
 				L2Amounts:     decimal.NewFromBigInt(erc20EventUnmarshaler.Amount, 0).String(),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -86,6 +98,10 @@ func (c *MessageMatchAssembler) erc20EventMessageMatchAssembler(gatewayEventsDat
 				L2EventType:   int(erc20EventUnmarshaler.Type),
 				L2BlockNumber: erc20EventUnmarshaler.Number,
 				L2TxHash:      erc20EventUnmarshaler.TxHash.Hex(),
+				L2From:        erc20EventUnmarshaler.From.Hex(),
+				L2To:          erc20EventUnmarshaler.To.Hex(),
+				L2L1Token:     erc20EventUnmarshaler.L1Token.Hex(),
+				L2L2Token:     erc20EventUnmarshaler.L2Token.Hex(),
 				L2Amounts:     decimal.NewFromBigInt(erc20EventUnmarshaler.Amount, 0).String(),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -45,6 +45,10 @@ func (c *MessageMatchAssembler) erc721EventMessageMatchAssembler(gatewayEventsDa
 				L1EventType:   int(erc721EventUnmarshaler.Type),
 				L1BlockNumber: erc721EventUnmarshaler.Number,
 				L1TxHash:      erc721EventUnmarshaler.TxHash.Hex(),
+				L1From:        erc721EventUnmarshaler.From.Hex(),
+				L1To:          erc721EventUnmarshaler.To.Hex(),
+				L1L1Token:     erc721EventUnmarshaler.L1Token.Hex(),
+				L1L2Token:     erc721EventUnmarshaler.L2Token.Hex(),
 				L1TokenIds:    strings.Join(tokenIdsStrList, ","),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -64,6 +68,10 @@ func (c *MessageMatchAssembler) erc721EventMessageMatchAssembler(gatewayEventsDa
 				L1EventType:   int(erc721EventUnmarshaler.Type),
 				L1BlockNumber: erc721EventUnmarshaler.Number,
 				L1TxHash:      erc721EventUnmarshaler.TxHash.Hex(),
+				L1From:        erc721EventUnmarshaler.From.Hex(),
+				L1To:          erc721EventUnmarshaler.To.Hex(),
+				L1L1Token:     erc721EventUnmarshaler.L1Token.Hex(),
+				L1L2Token:     erc721EventUnmarshaler.L2Token.Hex(),
 				L1TokenIds:    strings.Join(tokenIdsStrList, ","),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -83,6 +91,10 @@ func (c *MessageMatchAssembler) erc721EventMessageMatchAssembler(gatewayEventsDa
 				L2EventType:   int(erc721EventUnmarshaler.Type),
 				L2BlockNumber: erc721EventUnmarshaler.Number,
 				L2TxHash:      erc721EventUnmarshaler.TxHash.Hex(),
+				L2From:        erc721EventUnmarshaler.From.Hex(),
+				L2To:          erc721EventUnmarshaler.To.Hex(),
+				L2L1Token:     erc721EventUnmarshaler.L1Token.Hex(),
+				L2L2Token:     erc721EventUnmarshaler.L2Token.Hex(),
 				L2TokenIds:    strings.Join(tokenIdsStrList, ","),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
@@ -102,6 +114,10 @@ func (c *MessageMatchAssembler) erc721EventMessageMatchAssembler(gatewayEventsDa
 				L2EventType:   int(erc721EventUnmarshaler.Type),
 				L2BlockNumber: erc721EventUnmarshaler.Number,
 				L2TxHash:      erc721EventUnmarshaler.TxHash.Hex(),
+				L2From:        erc721EventUnmarshaler.From.Hex(),
+				L2To:          erc721EventUnmarshaler.To.Hex(),
+				L2L1Token:     erc721EventUnmarshaler.L1Token.Hex(),
+				L2L2Token:     erc721EventUnmarshaler.L2Token.Hex(),
 				L2TokenIds:    strings.Join(tokenIdsStrList, ","),
 			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
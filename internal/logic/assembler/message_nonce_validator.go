@@ -0,0 +1,235 @@
+package assembler
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+const (
+	messageNonceMissing    = "missing"
+	messageNonceDuplicated = "duplicated"
+	messageNonceNotMatch   = "not match the message queue"
+)
+
+type messageNonceEntry struct {
+	nonce       uint64
+	blockNumber uint64
+	txHash      common.Hash
+}
+
+// MessageNonceChecker remembers the next message queue index of each layer, so the continuity of the message nonces
+// is also checked across the block ranges. Unless the layer is seeded with SeedMessageNonce, the first block range
+// after start only checks the continuity within it.
+type MessageNonceChecker struct {
+	mu      sync.Mutex
+	nexts   map[types.LayerType]messageNonceEntry
+	started map[types.LayerType]bool
+}
+
+// NewMessageNonceChecker creates a new instance of MessageNonceChecker.
+func NewMessageNonceChecker() *MessageNonceChecker {
+	return &MessageNonceChecker{
+		nexts:   make(map[types.LayerType]messageNonceEntry),
+		started: make(map[types.LayerType]bool),
+	}
+}
+
+// SeedMessageNonce sets the next message nonce of the layer at the blockNumber after a restart, so the first block
+// range is also checked against the messages before it.
+func (c *MessageMatchAssembler) SeedMessageNonce(layer types.LayerType, nextNonce, blockNumber uint64, txHash common.Hash) {
+	c.nonceChecker.mu.Lock()
+	defer c.nonceChecker.mu.Unlock()
+	c.nonceChecker.nexts[layer] = messageNonceEntry{nonce: nextNonce, blockNumber: blockNumber, txHash: txHash}
+	c.nonceChecker.started[layer] = true
+}
+
+// SeedL2MessageNonce seeds the next l2 message nonce from the latest stored l2 sent message.
+func (c *MessageMatchAssembler) SeedL2MessageNonce(ctx context.Context) error {
+	message, err := c.messengerMessageMatchOrm.GetLatestL2SentMessageMatch(ctx)
+	if err != nil {
+		return err
+	}
+	if message == nil {
+		return nil
+	}
+	c.SeedMessageNonce(types.Layer2, message.NextMessageNonce, message.L2BlockNumber, common.HexToHash(message.L2TxHash))
+	return nil
+}
+
+// MessageNonceValidator checks the SentMessage nonces of the layer between the startBlockNumber and endBlockNumber
+// against the message queue. The nonces must not be duplicated, every nonce must be the queue index of its message,
+// every messenger message in the queue must have a SentMessage, and the queue indexes must be contiguous, the l1
+// enforced transactions and replays fill the gaps of the l1 nonces. It returns the failed nonce checks.
+func (c *MessageMatchAssembler) MessageNonceValidator(layer types.LayerType, startBlockNumber, endBlockNumber uint64, messengerEvents []events.EventUnmarshaler, queueEvents []events.MessageQueueEvent) []slack.MessageNonceInfo {
+	sentType := types.L1SentMessage
+	if layer == types.Layer2 {
+		sentType = types.L2SentMessage
+	}
+
+	var sentMessages []*events.MessengerEventUnmarshaler
+	for _, eventData := range messengerEvents {
+		event, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok || event.Type != sentType || event.MessageNonce == nil {
+			continue
+		}
+		sentMessages = append(sentMessages, event)
+	}
+
+	var failed []slack.MessageNonceInfo
+	fail := func(info slack.MessageNonceInfo) {
+		failed = append(failed, info)
+		log.Error("messenger message nonce check failed",
+			"layer", info.Layer,
+			"kind", info.Kind,
+			"message nonce", info.MessageNonce,
+			"start block", info.StartBlockNumber,
+			"end block", info.EndBlockNumber,
+		)
+	}
+
+	// duplicated nonces
+	sentByNonce := make(map[uint64][]*events.MessengerEventUnmarshaler)
+	for _, event := range sentMessages {
+		nonce := event.MessageNonce.Uint64()
+		sentByNonce[nonce] = append(sentByNonce[nonce], event)
+	}
+	for nonce, duplicates := range sentByNonce {
+		if len(duplicates) < 2 {
+			continue
+		}
+		info := slack.MessageNonceInfo{Layer: layer, Kind: messageNonceDuplicated, MessageNonce: nonce, StartBlockNumber: duplicates[0].Number}
+		for _, event := range duplicates {
+			if event.Number < info.StartBlockNumber {
+				info.StartBlockNumber = event.Number
+			}
+			if event.Number > info.EndBlockNumber {
+				info.EndBlockNumber = event.Number
+			}
+			info.TxHashes = append(info.TxHashes, event.TxHash)
+		}
+		fail(info)
+	}
+
+	// the nonces against the message queue
+	if queueEvents != nil {
+		queueByIndex := make(map[uint64]events.MessageQueueEvent)
+		queueByHash := make(map[common.Hash]events.MessageQueueEvent)
+		for _, queueEvent := range queueEvents {
+			queueByIndex[queueEvent.QueueIndex] = queueEvent
+			if queueEvent.MessageHash != (common.Hash{}) {
+				queueByHash[queueEvent.MessageHash] = queueEvent
+			}
+		}
+
+		for _, event := range sentMessages {
+			nonce := event.MessageNonce.Uint64()
+			var queueEvent events.MessageQueueEvent
+			var exists bool
+			if layer == types.Layer1 {
+				queueEvent, exists = queueByIndex[nonce]
+				exists = exists && queueEvent.MessengerMessage
+			} else {
+				queueEvent, exists = queueByHash[event.MessageHash]
+				exists = exists && queueEvent.QueueIndex == nonce
+			}
+			if exists {
+				continue
+			}
+
+			info := slack.MessageNonceInfo{
+				Layer:            layer,
+				Kind:             messageNonceNotMatch,
+				MessageNonce:     nonce,
+				StartBlockNumber: event.Number,
+				EndBlockNumber:   event.Number,
+				TxHashes:         []common.Hash{event.TxHash},
+			}
+			if layer == types.Layer2 && queueEvent.TxHash != (common.Hash{}) {
+				queueIndex := queueEvent.QueueIndex
+				info.QueueIndex = &queueIndex
+			}
+			fail(info)
+		}
+
+		for _, queueEvent := range queueEvents {
+			if !queueEvent.MessengerMessage || len(sentByNonce[queueEvent.QueueIndex]) != 0 {
+				continue
+			}
+			queueIndex := queueEvent.QueueIndex
+			fail(slack.MessageNonceInfo{
+				Layer:            layer,
+				Kind:             messageNonceMissing,
+				MessageNonce:     queueIndex,
+				QueueIndex:       &queueIndex,
+				StartBlockNumber: queueEvent.Number,
+				EndBlockNumber:   queueEvent.Number,
+				TxHashes:         []common.Hash{queueEvent.TxHash},
+			})
+		}
+	}
+
+	// the continuity of the consumed queue indexes, the l1 messenger nonces have gaps filled by the queue entries, so
+	// they aren't checked without the l1 message queue.
+	if layer == types.Layer1 && queueEvents == nil {
+		return failed
+	}
+	var entries []messageNonceEntry
+	for _, event := range sentMessages {
+		entries = append(entries, messageNonceEntry{nonce: event.MessageNonce.Uint64(), blockNumber: event.Number, txHash: event.TxHash})
+	}
+	for _, queueEvent := range queueEvents {
+		entries = append(entries, messageNonceEntry{nonce: queueEvent.QueueIndex, blockNumber: queueEvent.Number, txHash: queueEvent.TxHash})
+	}
+	if len(entries) == 0 {
+		return failed
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].nonce != entries[j].nonce {
+			return entries[i].nonce < entries[j].nonce
+		}
+		return entries[i].blockNumber < entries[j].blockNumber
+	})
+
+	c.nonceChecker.mu.Lock()
+	previous, started := c.nonceChecker.nexts[layer], c.nonceChecker.started[layer]
+	c.nonceChecker.mu.Unlock()
+
+	if !started {
+		previous = messageNonceEntry{nonce: entries[0].nonce, blockNumber: startBlockNumber}
+	}
+	for _, entry := range entries {
+		if entry.nonce > previous.nonce {
+			info := slack.MessageNonceInfo{
+				Layer:            layer,
+				Kind:             messageNonceMissing,
+				MessageNonce:     previous.nonce,
+				LastMessageNonce: entry.nonce - 1,
+				StartBlockNumber: previous.blockNumber,
+				EndBlockNumber:   entry.blockNumber,
+				TxHashes:         []common.Hash{entry.txHash},
+			}
+			if previous.txHash != (common.Hash{}) {
+				info.TxHashes = append([]common.Hash{previous.txHash}, info.TxHashes...)
+			}
+			fail(info)
+		}
+		if entry.nonce >= previous.nonce {
+			previous = messageNonceEntry{nonce: entry.nonce + 1, blockNumber: entry.blockNumber, txHash: entry.txHash}
+		}
+	}
+
+	c.nonceChecker.mu.Lock()
+	c.nonceChecker.nexts[layer], c.nonceChecker.started[layer] = previous, true
+	c.nonceChecker.mu.Unlock()
+
+	log.Debug("message nonce check finished", "layer", layer, "start", startBlockNumber, "end", endBlockNumber, "next nonce", previous.nonce)
+	return failed
+}
@@ -0,0 +1,202 @@
+package assembler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// nonceFailure the kind and the nonce of a failed nonce check.
+type nonceFailure struct {
+	kind  string
+	nonce uint64
+}
+
+func nonceFailures(infos []slack.MessageNonceInfo) []nonceFailure {
+	var failures []nonceFailure
+	for _, info := range infos {
+		failures = append(failures, nonceFailure{kind: info.Kind, nonce: info.MessageNonce})
+	}
+	return failures
+}
+
+func sentMessageEvent(layer types.LayerType, nonce, blockNumber uint64, messageHash common.Hash) events.EventUnmarshaler {
+	eventType := types.L1SentMessage
+	if layer == types.Layer2 {
+		eventType = types.L2SentMessage
+	}
+	return &events.MessengerEventUnmarshaler{
+		Layer:        layer,
+		Type:         eventType,
+		Number:       blockNumber,
+		TxHash:       common.BigToHash(new(big.Int).SetUint64(blockNumber*1000 + nonce)),
+		MessageNonce: new(big.Int).SetUint64(nonce),
+		MessageHash:  messageHash,
+	}
+}
+
+func queueEvent(layer types.LayerType, queueIndex, blockNumber uint64, messageHash common.Hash, messengerMessage bool) events.MessageQueueEvent {
+	return events.MessageQueueEvent{
+		Layer:            layer,
+		Number:           blockNumber,
+		TxHash:           common.BigToHash(new(big.Int).SetUint64(blockNumber*1000 + queueIndex)),
+		QueueIndex:       queueIndex,
+		MessageHash:      messageHash,
+		MessengerMessage: messengerMessage,
+	}
+}
+
+func TestMessageNonceValidator(t *testing.T) {
+	hash5, hash6 := common.HexToHash("0x05"), common.HexToHash("0x06")
+
+	tests := []struct {
+		name            string
+		layer           types.LayerType
+		messengerEvents []events.EventUnmarshaler
+		queueEvents     []events.MessageQueueEvent
+		failures        []nonceFailure
+	}{
+		{
+			// the enforced transaction 11 and the replay 12 take queue indexes without a SentMessage.
+			name:            "l1EnforcedTxAndReplayFillGaps",
+			layer:           types.Layer1,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer1, 10, 100, common.Hash{}), sentMessageEvent(types.Layer1, 13, 103, common.Hash{})},
+			queueEvents: []events.MessageQueueEvent{
+				queueEvent(types.Layer1, 10, 100, common.Hash{}, true),
+				queueEvent(types.Layer1, 11, 101, common.Hash{}, false),
+				queueEvent(types.Layer1, 12, 102, common.Hash{}, false),
+				queueEvent(types.Layer1, 13, 103, common.Hash{}, true),
+			},
+		},
+		{
+			name:            "l1Gap",
+			layer:           types.Layer1,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer1, 10, 100, common.Hash{}), sentMessageEvent(types.Layer1, 12, 102, common.Hash{})},
+			queueEvents:     []events.MessageQueueEvent{queueEvent(types.Layer1, 10, 100, common.Hash{}, true), queueEvent(types.Layer1, 12, 102, common.Hash{}, true)},
+			failures:        []nonceFailure{{kind: messageNonceMissing, nonce: 11}},
+		},
+		{
+			name:            "l1Duplicated",
+			layer:           types.Layer1,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer1, 10, 100, common.Hash{}), sentMessageEvent(types.Layer1, 10, 101, common.Hash{})},
+			queueEvents:     []events.MessageQueueEvent{queueEvent(types.Layer1, 10, 100, common.Hash{}, true)},
+			failures:        []nonceFailure{{kind: messageNonceDuplicated, nonce: 10}},
+		},
+		{
+			name:            "l1NonceOfEnforcedTx",
+			layer:           types.Layer1,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer1, 10, 100, common.Hash{})},
+			queueEvents:     []events.MessageQueueEvent{queueEvent(types.Layer1, 10, 100, common.Hash{}, false)},
+			failures:        []nonceFailure{{kind: messageNonceNotMatch, nonce: 10}},
+		},
+		{
+			name:        "l1QueuedWithoutSentMessage",
+			layer:       types.Layer1,
+			queueEvents: []events.MessageQueueEvent{queueEvent(types.Layer1, 10, 100, common.Hash{}, true)},
+			failures:    []nonceFailure{{kind: messageNonceMissing, nonce: 10}},
+		},
+		{
+			// the gaps of the l1 nonces can't be told apart from the enforced transactions without the message queue.
+			name:            "l1WithoutMessageQueue",
+			layer:           types.Layer1,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer1, 10, 100, common.Hash{}), sentMessageEvent(types.Layer1, 12, 102, common.Hash{})},
+		},
+		{
+			name:            "l2WithoutMessageQueue",
+			layer:           types.Layer2,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer2, 5, 200, hash5), sentMessageEvent(types.Layer2, 7, 200, common.HexToHash("0x07"))},
+			failures:        []nonceFailure{{kind: messageNonceMissing, nonce: 6}},
+		},
+		{
+			name:            "l2Matched",
+			layer:           types.Layer2,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer2, 5, 200, hash5), sentMessageEvent(types.Layer2, 6, 200, hash6)},
+			queueEvents:     []events.MessageQueueEvent{queueEvent(types.Layer2, 5, 200, hash5, true), queueEvent(types.Layer2, 6, 200, hash6, true)},
+		},
+		{
+			// the message of nonce 5 was appended at queue index 6, and no message has nonce 6.
+			name:            "l2HashIndexMismatch",
+			layer:           types.Layer2,
+			messengerEvents: []events.EventUnmarshaler{sentMessageEvent(types.Layer2, 5, 200, hash5)},
+			queueEvents:     []events.MessageQueueEvent{queueEvent(types.Layer2, 6, 200, hash5, true)},
+			failures:        []nonceFailure{{kind: messageNonceNotMatch, nonce: 5}, {kind: messageNonceMissing, nonce: 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &MessageMatchAssembler{nonceChecker: NewMessageNonceChecker()}
+			failed := c.MessageNonceValidator(tt.layer, 100, 300, tt.messengerEvents, tt.queueEvents)
+			assert.Equal(t, tt.failures, nonceFailures(failed))
+		})
+	}
+
+	t.Run("l2HashIndexMismatchQueueIndex", func(t *testing.T) {
+		c := &MessageMatchAssembler{nonceChecker: NewMessageNonceChecker()}
+		failed := c.MessageNonceValidator(types.Layer2, 200, 200, []events.EventUnmarshaler{sentMessageEvent(types.Layer2, 5, 200, hash5)},
+			[]events.MessageQueueEvent{queueEvent(types.Layer2, 6, 200, hash5, true)})
+		if assert.NotEmpty(t, failed) && assert.NotNil(t, failed[0].QueueIndex) {
+			assert.Equal(t, uint64(6), *failed[0].QueueIndex)
+		}
+	})
+}
+
+func TestMessageNonceValidatorAcrossRanges(t *testing.T) {
+	c := &MessageMatchAssembler{nonceChecker: NewMessageNonceChecker()}
+	sent := func(nonces ...uint64) []events.EventUnmarshaler {
+		var sentEvents []events.EventUnmarshaler
+		for _, nonce := range nonces {
+			sentEvents = append(sentEvents, sentMessageEvent(types.Layer1, nonce, 100+nonce, common.Hash{}))
+		}
+		return sentEvents
+	}
+	queued := func(nonces ...uint64) []events.MessageQueueEvent {
+		var queueEvents []events.MessageQueueEvent
+		for _, nonce := range nonces {
+			queueEvents = append(queueEvents, queueEvent(types.Layer1, nonce, 100+nonce, common.Hash{}, true))
+		}
+		return queueEvents
+	}
+
+	// the first range after start only checks the continuity within it.
+	assert.Empty(t, c.MessageNonceValidator(types.Layer1, 100, 109, sent(5, 6), queued(5, 6)))
+
+	// a range without messages keeps the next nonce.
+	assert.Empty(t, c.MessageNonceValidator(types.Layer1, 110, 119, nil, nil))
+
+	// the next range continues at 7.
+	assert.Empty(t, c.MessageNonceValidator(types.Layer1, 120, 129, sent(7), queued(7)))
+
+	// nonce 8 is missing between the ranges.
+	failed := c.MessageNonceValidator(types.Layer1, 130, 139, sent(9), queued(9))
+	assert.Equal(t, []nonceFailure{{kind: messageNonceMissing, nonce: 8}}, nonceFailures(failed))
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, uint64(8), failed[0].LastMessageNonce)
+		assert.Equal(t, uint64(107), failed[0].StartBlockNumber)
+		assert.Equal(t, uint64(109), failed[0].EndBlockNumber)
+	}
+
+	// the layers are tracked separately.
+	assert.Empty(t, c.MessageNonceValidator(types.Layer2, 100, 109, []events.EventUnmarshaler{sentMessageEvent(types.Layer2, 1, 100, common.HexToHash("0x01"))},
+		[]events.MessageQueueEvent{queueEvent(types.Layer2, 1, 100, common.HexToHash("0x01"), true)}))
+}
+
+func TestMessageNonceValidatorSeeded(t *testing.T) {
+	c := &MessageMatchAssembler{nonceChecker: NewMessageNonceChecker()}
+	c.SeedMessageNonce(types.Layer2, 5, 90, common.HexToHash("0x04"))
+
+	// the first range after a restart is checked against the seeded next nonce.
+	failed := c.MessageNonceValidator(types.Layer2, 100, 109, []events.EventUnmarshaler{sentMessageEvent(types.Layer2, 6, 100, common.HexToHash("0x06"))},
+		[]events.MessageQueueEvent{queueEvent(types.Layer2, 6, 100, common.HexToHash("0x06"), true)})
+	assert.Equal(t, []nonceFailure{{kind: messageNonceMissing, nonce: 5}}, nonceFailures(failed))
+	if assert.Len(t, failed, 1) {
+		assert.Equal(t, uint64(90), failed[0].StartBlockNumber)
+		assert.Equal(t, common.HexToHash("0x04"), failed[0].TxHashes[0])
+	}
+}
@@ -0,0 +1,302 @@
+package assembler
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1erc1155gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1erc20gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1erc721gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2erc1155gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2erc20gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2erc721gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+type payloadMethod struct {
+	metaData *bind.MetaData
+	name     string
+}
+
+// gatewayPayloadMethods maps the gateway events that send a message to the counterpart gateway method the message calls.
+var gatewayPayloadMethods = map[types.EventType]payloadMethod{
+	types.L1DepositERC20:         {il2erc20gateway.Il2erc20gatewayMetaData, "finalizeDepositERC20"},
+	types.L2WithdrawERC20:        {il1erc20gateway.Il1erc20gatewayMetaData, "finalizeWithdrawERC20"},
+	types.L1DepositERC721:        {il2erc721gateway.Il2erc721gatewayMetaData, "finalizeDepositERC721"},
+	types.L1BatchDepositERC721:   {il2erc721gateway.Il2erc721gatewayMetaData, "finalizeBatchDepositERC721"},
+	types.L2WithdrawERC721:       {il1erc721gateway.Il1erc721gatewayMetaData, "finalizeWithdrawERC721"},
+	types.L2BatchWithdrawERC721:  {il1erc721gateway.Il1erc721gatewayMetaData, "finalizeBatchWithdrawERC721"},
+	types.L1DepositERC1155:       {il2erc1155gateway.Il2erc1155gatewayMetaData, "finalizeDepositERC1155"},
+	types.L1BatchDepositERC1155:  {il2erc1155gateway.Il2erc1155gatewayMetaData, "finalizeBatchDepositERC1155"},
+	types.L2WithdrawERC1155:      {il1erc1155gateway.Il1erc1155gatewayMetaData, "finalizeWithdrawERC1155"},
+	types.L2BatchWithdrawERC1155: {il1erc1155gateway.Il1erc1155gatewayMetaData, "finalizeBatchWithdrawERC1155"},
+}
+
+// gatewayPayload is the decoded finalize call carried by the messenger message, or the same fields of the gateway event.
+type gatewayPayload struct {
+	tokenType types.TokenType
+	l1Token   common.Address
+	l2Token   common.Address
+	from      common.Address
+	to        common.Address
+	tokenIds  []*big.Int
+	amounts   []*big.Int
+	data      []byte
+}
+
+// MessagePayloadValidator decodes the messenger messages sent along with the gateway deposit and withdraw events, and
+// compares the finalize call field by field with the gateway event. A discrepancy means the gateway emitted an event
+// which differs from what it actually bridges. It returns the mismatched gateway events, which are alerted once the
+// range is stored.
+func (c *MessageMatchAssembler) MessagePayloadValidator(gatewayEventsData, messengerEventsData []events.EventUnmarshaler) []slack.MessagePayloadInfo {
+	messageHashes := make(map[messageEventKey]common.Hash)
+	messages := make(map[common.Hash]*events.MessengerEventUnmarshaler)
+	for _, eventData := range messengerEventsData {
+		messengerEvent, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok || (messengerEvent.Type != types.L1SentMessage && messengerEvent.Type != types.L2SentMessage) {
+			continue
+		}
+		messageHashes[messageEventKey{TxHash: messengerEvent.TxHash, LogIndex: messengerEvent.Index}] = messengerEvent.MessageHash
+		messages[messengerEvent.MessageHash] = messengerEvent
+	}
+
+	var mismatched []slack.MessagePayloadInfo
+	for _, eventData := range gatewayEventsData {
+		event := gatewayEventPayload(eventData)
+		if event == nil {
+			continue
+		}
+		method, exists := gatewayPayloadMethods[event.eventType]
+		if !exists {
+			continue
+		}
+
+		messageHash, found := c.findPrevMessageEvent(event.txHash, event.index, messageHashes)
+		if !found {
+			log.Warn("sent message of the gateway event not found, skip payload check", "layer", event.layer, "event type", event.eventType, "tx hash", event.txHash.Hex())
+			continue
+		}
+
+		fields := compareGatewayPayload(event.payload, method, messages[messageHash].Message)
+		if len(fields) == 0 {
+			continue
+		}
+
+		log.Error("messenger message payload does not match the gateway event",
+			"layer", event.layer,
+			"event type", event.eventType,
+			"block number", event.blockNumber,
+			"tx hash", event.txHash.Hex(),
+			"message hash", messageHash.Hex(),
+			"fields", fields,
+		)
+		mismatched = append(mismatched, slack.MessagePayloadInfo{
+			Layer:       event.layer,
+			EventType:   event.eventType,
+			TokenType:   event.payload.tokenType,
+			BlockNumber: event.blockNumber,
+			TxHash:      event.txHash,
+			MessageHash: messageHash,
+			Fields:      fields,
+		})
+	}
+	return mismatched
+}
+
+// payloadEvent is a gateway event with the fields the message payload is compared with.
+type payloadEvent struct {
+	layer       types.LayerType
+	eventType   types.EventType
+	blockNumber uint64
+	txHash      common.Hash
+	index       uint
+	payload     gatewayPayload
+}
+
+func gatewayEventPayload(eventData events.EventUnmarshaler) *payloadEvent {
+	switch event := eventData.(type) {
+	case *events.ERC20GatewayEventUnmarshaler:
+		payload := gatewayPayload{
+			tokenType: types.TokenTypeERC20,
+			l1Token:   event.L1Token,
+			l2Token:   event.L2Token,
+			from:      event.From,
+			to:        event.To,
+			amounts:   []*big.Int{event.Amount},
+			data:      event.Data,
+		}
+		return &payloadEvent{layer: event.Layer, eventType: event.Type, blockNumber: event.Number, txHash: event.TxHash, index: event.Index, payload: payload}
+	case *events.ERC721GatewayEventUnmarshaler:
+		payload := gatewayPayload{
+			tokenType: types.TokenTypeERC721,
+			l1Token:   event.L1Token,
+			l2Token:   event.L2Token,
+			from:      event.From,
+			to:        event.To,
+			tokenIds:  event.TokenIds,
+		}
+		return &payloadEvent{layer: event.Layer, eventType: event.Type, blockNumber: event.Number, txHash: event.TxHash, index: event.Index, payload: payload}
+	case *events.ERC1155GatewayEventUnmarshaler:
+		payload := gatewayPayload{
+			tokenType: types.TokenTypeERC1155,
+			l1Token:   event.L1Token,
+			l2Token:   event.L2Token,
+			from:      event.From,
+			to:        event.To,
+			tokenIds:  event.TokenIds,
+			amounts:   event.Amounts,
+		}
+		return &payloadEvent{layer: event.Layer, eventType: event.Type, blockNumber: event.Number, txHash: event.TxHash, index: event.Index, payload: payload}
+	}
+	return nil
+}
+
+// decodeGatewayPayload decodes the finalize call of the messenger message, the parameter names differ between the
+// l1 and l2 gateways so the arguments are read by position.
+func decodeGatewayPayload(tokenType types.TokenType, method *abi.Method, message []byte) (*gatewayPayload, error) {
+	if len(message) < 4 || !bytes.Equal(message[:4], method.ID) {
+		return nil, fmt.Errorf("message selector is not %s", method.Sig)
+	}
+
+	args, err := method.Inputs.Unpack(message[4:])
+	if err != nil {
+		return nil, fmt.Errorf("unpack %s failed, err:%w", method.Sig, err)
+	}
+
+	payload := &gatewayPayload{
+		tokenType: tokenType,
+		l1Token:   args[0].(common.Address),
+		l2Token:   args[1].(common.Address),
+		from:      args[2].(common.Address),
+		to:        args[3].(common.Address),
+	}
+	switch tokenType {
+	case types.TokenTypeERC20:
+		payload.amounts = []*big.Int{args[4].(*big.Int)}
+		payload.data = args[5].([]byte)
+	case types.TokenTypeERC721:
+		if tokenIds, ok := args[4].([]*big.Int); ok {
+			payload.tokenIds = tokenIds
+		} else {
+			payload.tokenIds = []*big.Int{args[4].(*big.Int)}
+		}
+	case types.TokenTypeERC1155:
+		if tokenIds, ok := args[4].([]*big.Int); ok {
+			payload.tokenIds = tokenIds
+			payload.amounts = args[5].([]*big.Int)
+		} else {
+			payload.tokenIds = []*big.Int{args[4].(*big.Int)}
+			payload.amounts = []*big.Int{args[5].(*big.Int)}
+		}
+	}
+	return payload, nil
+}
+
+func compareGatewayPayload(expected gatewayPayload, method payloadMethod, message []byte) []slack.MessagePayloadField {
+	parsedABI, err := method.metaData.GetAbi()
+	if err != nil {
+		log.Error("get gateway abi failed", "method", method.name, "err", err)
+		return nil
+	}
+	abiMethod := parsedABI.Methods[method.name]
+
+	actual, err := decodeGatewayPayload(expected.tokenType, &abiMethod, message)
+	if err != nil {
+		return []slack.MessagePayloadField{{Name: "payload", Event: abiMethod.Sig, Payload: err.Error()}}
+	}
+
+	var fields []slack.MessagePayloadField
+	addressFields := []struct {
+		name            string
+		event, received common.Address
+	}{
+		{"l1 token", expected.l1Token, actual.l1Token},
+		{"l2 token", expected.l2Token, actual.l2Token},
+		{"from", expected.from, actual.from},
+		{"to", expected.to, actual.to},
+	}
+	for _, field := range addressFields {
+		if field.event != field.received {
+			fields = append(fields, slack.MessagePayloadField{Name: field.name, Event: field.event.Hex(), Payload: field.received.Hex()})
+		}
+	}
+
+	if !bigIntsEqual(expected.tokenIds, actual.tokenIds) {
+		fields = append(fields, slack.MessagePayloadField{Name: "token ids", Event: joinBigInts(expected.tokenIds), Payload: joinBigInts(actual.tokenIds)})
+	}
+	if !bigIntsEqual(expected.amounts, actual.amounts) {
+		fields = append(fields, slack.MessagePayloadField{Name: "amounts", Event: joinBigInts(expected.amounts), Payload: joinBigInts(actual.amounts)})
+	}
+	if expected.tokenType == types.TokenTypeERC20 && !payloadDataMatch(expected.data, actual.data) {
+		fields = append(fields, slack.MessagePayloadField{Name: "data", Event: common.Bytes2Hex(expected.data), Payload: common.Bytes2Hex(actual.data)})
+	}
+	return fields
+}
+
+var (
+	bytesType, _ = abi.NewType("bytes", "", nil)
+	boolType, _  = abi.NewType("bool", "", nil)
+
+	// wrappedDataArgs is abi.encode(needDeploy, l2Data) of the l1 standard erc20 gateway.
+	wrappedDataArgs = abi.Arguments{{Type: boolType}, {Type: bytesType}}
+	// deployDataArgs is abi.encode(data, deployData) of the l1 standard erc20 gateway if the l2 token needs deploy.
+	deployDataArgs = abi.Arguments{{Type: bytesType}, {Type: bytesType}}
+)
+
+// payloadDataMatch compares the data of the gateway event with the message data. The l1 standard erc20 gateway wraps
+// the data with whether the l2 token needs deploy and the token metadata, which is unwrapped before the comparison.
+func payloadDataMatch(eventData, payloadData []byte) bool {
+	if bytes.Equal(eventData, payloadData) {
+		return true
+	}
+
+	wrapped, err := wrappedDataArgs.Unpack(payloadData)
+	if err != nil {
+		return false
+	}
+	l2Data := wrapped[1].([]byte)
+	if !wrapped[0].(bool) {
+		return bytes.Equal(eventData, l2Data)
+	}
+
+	deploy, err := deployDataArgs.Unpack(l2Data)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(eventData, deploy[0].([]byte))
+}
+
+func bigIntsEqual(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] == nil || b[i] == nil {
+			if a[i] != b[i] {
+				return false
+			}
+			continue
+		}
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func joinBigInts(values []*big.Int) string {
+	var strs []string
+	for _, v := range values {
+		strs = append(strs, v.String())
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,202 @@
+package assembler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1erc20gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2erc1155gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2erc20gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2erc721gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+var (
+	payloadL1Token = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	payloadL2Token = common.HexToAddress("0xf55BEC9cafDbE8730f096Aa55dad6D22d44099Df")
+	payloadFrom    = common.HexToAddress("0x0000000000000000000000000000000000000d01")
+	payloadTo      = common.HexToAddress("0x0000000000000000000000000000000000000d02")
+	payloadTxHash  = common.HexToHash("0x0f")
+)
+
+// finalizeCalldata encodes the finalize call of the counterpart gateway the way the gateways encode the message.
+func finalizeCalldata(t *testing.T, metaData *bind.MetaData, method string, args ...interface{}) []byte {
+	parsedABI, err := metaData.GetAbi()
+	require.NoError(t, err)
+	calldata, err := parsedABI.Pack(method, args...)
+	require.NoError(t, err)
+	return calldata
+}
+
+// standardGatewayData wraps the deposit data like the l1 standard erc20 gateway, abi.encode(needDeploy, l2Data) with
+// l2Data abi.encode(data, deployData) if the l2 token needs deploy.
+func standardGatewayData(t *testing.T, needDeploy bool, data []byte) []byte {
+	l2Data := data
+	if needDeploy {
+		deployData, err := deployDataArgs.Pack([]byte{}, []byte("token metadata"))
+		require.NoError(t, err)
+		l2Data, err = deployDataArgs.Pack(data, deployData)
+		require.NoError(t, err)
+	}
+	wrapped, err := wrappedDataArgs.Pack(needDeploy, l2Data)
+	require.NoError(t, err)
+	return wrapped
+}
+
+func TestMessagePayloadValidator(t *testing.T) {
+	amount := big.NewInt(1000000)
+	data := []byte("call data")
+	sentMessage := func(layer types.LayerType, message []byte) events.EventUnmarshaler {
+		eventType := types.L1SentMessage
+		if layer == types.Layer2 {
+			eventType = types.L2SentMessage
+		}
+		return &events.MessengerEventUnmarshaler{Layer: layer, Type: eventType, TxHash: payloadTxHash, Index: 1, MessageHash: common.HexToHash("0x0e"), Message: message}
+	}
+	erc20Event := func(eventType types.EventType, to common.Address, amount *big.Int, data []byte) events.EventUnmarshaler {
+		return &events.ERC20GatewayEventUnmarshaler{Layer: types.Layer1, Type: eventType, TxHash: payloadTxHash, Index: 2,
+			L1Token: payloadL1Token, L2Token: payloadL2Token, From: payloadFrom, To: to, Amount: amount, Data: data}
+	}
+	erc721Event := func(eventType types.EventType, tokenIds ...int64) events.EventUnmarshaler {
+		event := &events.ERC721GatewayEventUnmarshaler{Layer: types.Layer1, Type: eventType, TxHash: payloadTxHash, Index: 2,
+			L1Token: payloadL1Token, L2Token: payloadL2Token, From: payloadFrom, To: payloadTo}
+		for _, tokenID := range tokenIds {
+			event.TokenIds = append(event.TokenIds, big.NewInt(tokenID))
+		}
+		return event
+	}
+	erc1155Event := func(eventType types.EventType, tokenIds, amounts []*big.Int) events.EventUnmarshaler {
+		return &events.ERC1155GatewayEventUnmarshaler{Layer: types.Layer1, Type: eventType, TxHash: payloadTxHash, Index: 2,
+			L1Token: payloadL1Token, L2Token: payloadL2Token, From: payloadFrom, To: payloadTo, TokenIds: tokenIds, Amounts: amounts}
+	}
+
+	tests := []struct {
+		name         string
+		gatewayEvent events.EventUnmarshaler
+		message      events.EventUnmarshaler
+		fields       []string
+	}{
+		{
+			name:         "erc20Deposit",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, data),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc20gateway.Il2erc20gatewayMetaData, "finalizeDepositERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, amount, data)),
+		},
+		{
+			// finalizeDepositERC20(l1Token, l2Token, from, to, 1000000, "") as sent by the l1 custom erc20 gateway.
+			name:         "erc20DepositCalldata",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, nil),
+			message: sentMessage(types.Layer1, common.FromHex("0x8431f5c1"+
+				"000000000000000000000000dac17f958d2ee523a2206206994597c13d831ec7"+
+				"000000000000000000000000f55bec9cafdbe8730f096aa55dad6d22d44099df"+
+				"0000000000000000000000000000000000000000000000000000000000000d01"+
+				"0000000000000000000000000000000000000000000000000000000000000d02"+
+				"00000000000000000000000000000000000000000000000000000000000f4240"+
+				"00000000000000000000000000000000000000000000000000000000000000c0"+
+				"0000000000000000000000000000000000000000000000000000000000000000")),
+		},
+		{
+			name:         "erc20StandardDepositDeployed",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, data),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc20gateway.Il2erc20gatewayMetaData, "finalizeDepositERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, amount, standardGatewayData(t, false, data))),
+		},
+		{
+			name:         "erc20StandardDepositNeedDeploy",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, data),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc20gateway.Il2erc20gatewayMetaData, "finalizeDepositERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, amount, standardGatewayData(t, true, data))),
+		},
+		{
+			name:         "erc20StandardDepositNeedDeployDataMismatch",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, data),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc20gateway.Il2erc20gatewayMetaData, "finalizeDepositERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, amount, standardGatewayData(t, true, []byte("other data")))),
+			fields: []string{"data"},
+		},
+		{
+			name:         "erc20DepositAmountAndRecipientMismatch",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, nil),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc20gateway.Il2erc20gatewayMetaData, "finalizeDepositERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadFrom, big.NewInt(2000000), []byte{})),
+			fields: []string{"to", "amounts"},
+		},
+		{
+			name: "erc20Withdraw",
+			gatewayEvent: &events.ERC20GatewayEventUnmarshaler{Layer: types.Layer2, Type: types.L2WithdrawERC20, TxHash: payloadTxHash, Index: 2,
+				L1Token: payloadL1Token, L2Token: payloadL2Token, From: payloadFrom, To: payloadTo, Amount: amount},
+			message: sentMessage(types.Layer2, finalizeCalldata(t, il1erc20gateway.Il1erc20gatewayMetaData, "finalizeWithdrawERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, amount, []byte{})),
+		},
+		{
+			name:         "erc20DepositSelectorMismatch",
+			gatewayEvent: erc20Event(types.L1DepositERC20, payloadTo, amount, data),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il1erc20gateway.Il1erc20gatewayMetaData, "finalizeWithdrawERC20",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, amount, data)),
+			fields: []string{"payload"},
+		},
+		{
+			name:         "erc721Deposit",
+			gatewayEvent: erc721Event(types.L1DepositERC721, 7),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc721gateway.Il2erc721gatewayMetaData, "finalizeDepositERC721",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, big.NewInt(7))),
+		},
+		{
+			name:         "erc721BatchDepositTokenIDMismatch",
+			gatewayEvent: erc721Event(types.L1BatchDepositERC721, 7, 8),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc721gateway.Il2erc721gatewayMetaData, "finalizeBatchDepositERC721",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, []*big.Int{big.NewInt(7), big.NewInt(9)})),
+			fields: []string{"token ids"},
+		},
+		{
+			name:         "erc1155Deposit",
+			gatewayEvent: erc1155Event(types.L1DepositERC1155, []*big.Int{big.NewInt(3)}, []*big.Int{big.NewInt(5)}),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc1155gateway.Il2erc1155gatewayMetaData, "finalizeDepositERC1155",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, big.NewInt(3), big.NewInt(5))),
+		},
+		{
+			name:         "erc1155BatchDepositAmountMismatch",
+			gatewayEvent: erc1155Event(types.L1BatchDepositERC1155, []*big.Int{big.NewInt(3), big.NewInt(4)}, []*big.Int{big.NewInt(5), big.NewInt(6)}),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc1155gateway.Il2erc1155gatewayMetaData, "finalizeBatchDepositERC1155",
+				payloadL1Token, payloadL2Token, payloadFrom, payloadTo, []*big.Int{big.NewInt(3), big.NewInt(4)}, []*big.Int{big.NewInt(5), big.NewInt(60)})),
+			fields: []string{"amounts"},
+		},
+		{
+			name:         "l1TokenMismatch",
+			gatewayEvent: erc1155Event(types.L1DepositERC1155, []*big.Int{big.NewInt(3)}, []*big.Int{big.NewInt(5)}),
+			message: sentMessage(types.Layer1, finalizeCalldata(t, il2erc1155gateway.Il2erc1155gatewayMetaData, "finalizeDepositERC1155",
+				payloadL2Token, payloadL2Token, payloadFrom, payloadTo, big.NewInt(3), big.NewInt(5))),
+			fields: []string{"l1 token"},
+		},
+	}
+
+	c := &MessageMatchAssembler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatched := c.MessagePayloadValidator([]events.EventUnmarshaler{tt.gatewayEvent}, []events.EventUnmarshaler{tt.message})
+			if tt.fields == nil {
+				assert.Empty(t, mismatched)
+				return
+			}
+
+			require.Len(t, mismatched, 1)
+			var fields []string
+			for _, field := range mismatched[0].Fields {
+				fields = append(fields, field.Name)
+			}
+			assert.Equal(t, tt.fields, fields)
+			assert.Equal(t, common.HexToHash("0x0e"), mismatched[0].MessageHash)
+		})
+	}
+
+	t.Run("sentMessageNotFound", func(t *testing.T) {
+		gatewayEvent := erc20Event(types.L1DepositERC20, payloadTo, amount, data)
+		assert.Empty(t, c.MessagePayloadValidator([]events.EventUnmarshaler{gatewayEvent}, nil))
+	})
+}
@@ -40,6 +40,10 @@ func (c *MessageMatchAssembler) messengerMessageMatchAssembler(messengerEvents [
 				L1BlockNumber: messengerEventUnmarshaler.Number,
 				L1TxHash:      messengerEventUnmarshaler.TxHash.Hex(),
 			}
+			// the relayed value is only trusted if the relay calldata reproduces the message hash.
+			if messengerEventUnmarshaler.RelayedMessageHash == messengerEventUnmarshaler.MessageHash {
+				tmpMessageMatch.RelayedETHAmount = decimal.NewFromBigInt(messengerEventUnmarshaler.Value, 0).String()
+			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
 		case types.L2SentMessage:
 			tmpMessageMatch = orm.MessengerMessageMatch{
@@ -59,6 +63,10 @@ func (c *MessageMatchAssembler) messengerMessageMatchAssembler(messengerEvents [
 				L2BlockNumber: messengerEventUnmarshaler.Number,
 				L2TxHash:      messengerEventUnmarshaler.TxHash.Hex(),
 			}
+			// the relayed value is only trusted if the relay calldata reproduces the message hash.
+			if messengerEventUnmarshaler.RelayedMessageHash == messengerEventUnmarshaler.MessageHash {
+				tmpMessageMatch.RelayedETHAmount = decimal.NewFromBigInt(messengerEventUnmarshaler.Value, 0).String()
+			}
 			messageMatches = append(messageMatches, tmpMessageMatch)
 		}
 	}
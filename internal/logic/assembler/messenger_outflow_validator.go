@@ -0,0 +1,189 @@
+package assembler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/scroll-tech/go-ethereum/rpc"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+	"github.com/scroll-tech/chain-monitor/internal/utils/calltrace"
+)
+
+// MessengerOutflowValidator traces the blocks with messenger events or a change of the messenger balance between
+// startBlockNumber and endBlockNumber, and attributes every value transfer out of the messenger to the messenger call
+// making it. A block whose outflows are exactly offset by its inflows without any messenger event is not traced. A relay may only transfer the relayed value to the target of a message
+// relayed in the same transaction, a send or replay may only transfer the fee and refund it is paid, and a drop may
+// only return the dropped value. It returns the messenger calls with unattributed value, which are alerted once the
+// range is stored.
+func (c *MessageMatchAssembler) MessengerOutflowValidator(ctx context.Context, layer types.LayerType, client *rpc.Client, messengerAddr common.Address, startBlockNumber, endBlockNumber uint64, messengerEvents []events.EventUnmarshaler) ([]slack.MessengerOutflowInfo, error) {
+	var messengerABI *abi.ABI
+	var err error
+	if layer == types.Layer1 {
+		messengerABI, err = il1scrollmessenger.Il1scrollmessengerMetaData.GetAbi()
+	} else {
+		messengerABI, err = il2scrollmessenger.Il2scrollmessengerMetaData.GetAbi()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	relayedHashes := make(map[common.Hash]map[common.Hash]bool)
+	blockNumbers := make(map[uint64]bool)
+	for _, eventData := range messengerEvents {
+		event, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok {
+			continue
+		}
+		blockNumbers[event.Number] = true
+		if event.Type == types.L1RelayedMessage || event.Type == types.L2RelayedMessage {
+			if relayedHashes[event.TxHash] == nil {
+				relayedHashes[event.TxHash] = make(map[common.Hash]bool)
+			}
+			relayedHashes[event.TxHash][event.MessageHash] = true
+		}
+	}
+
+	changedBlocks, err := balanceChangedBlocks(ctx, client, messengerAddr, startBlockNumber, endBlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get messenger balances failed, layer: %v, err: %w", layer, err)
+	}
+	for _, blockNumber := range changedBlocks {
+		blockNumbers[blockNumber] = true
+	}
+
+	var blockNums []uint64
+	for blockNumber := range blockNumbers {
+		blockNums = append(blockNums, blockNumber)
+	}
+	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] < blockNums[j] })
+
+	var unattributedCalls []slack.MessengerOutflowInfo
+	for _, blockNumber := range blockNums {
+		traces, traceErr := calltrace.TraceBlockByNumber(ctx, client, blockNumber)
+		if traceErr != nil {
+			return nil, fmt.Errorf("trace block failed, layer: %v, block number: %d, err: %w", layer, blockNumber, traceErr)
+		}
+
+		for _, call := range calltrace.AddressCalls(traces, messengerAddr) {
+			info := attributeMessengerOutflow(messengerABI, call, relayedHashes[call.TxHash])
+			if info.UnattributedValue.Sign() == 0 {
+				continue
+			}
+
+			info.Layer, info.BlockNumber, info.TxHash = layer, blockNumber, call.TxHash
+			log.Error("value transferred out of the messenger is not attributed to a message",
+				"layer", layer,
+				"block number", blockNumber,
+				"tx hash", call.TxHash.Hex(),
+				"method", info.Method,
+				"unattributed value", info.UnattributedValue.String(),
+			)
+			unattributedCalls = append(unattributedCalls, info)
+		}
+	}
+	return unattributedCalls, nil
+}
+
+// balanceChangedBlocks returns the blocks between startBlockNumber and endBlockNumber changing the balance of the address.
+func balanceChangedBlocks(ctx context.Context, client *rpc.Client, address common.Address, startBlockNumber, endBlockNumber uint64) ([]uint64, error) {
+	if startBlockNumber == 0 {
+		startBlockNumber = 1
+	}
+	if startBlockNumber > endBlockNumber {
+		return nil, nil
+	}
+
+	var blockNumbers []uint64
+	for blockNumber := startBlockNumber - 1; blockNumber <= endBlockNumber; blockNumber++ {
+		blockNumbers = append(blockNumbers, blockNumber)
+	}
+	balances, err := utils.GetBalancesForBlocks(ctx, client, address, blockNumbers)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []uint64
+	for blockNumber := startBlockNumber; blockNumber <= endBlockNumber; blockNumber++ {
+		if balances[blockNumber].Cmp(balances[blockNumber-1]) != 0 {
+			changed = append(changed, blockNumber)
+		}
+	}
+	return changed, nil
+}
+
+// attributeMessengerOutflow attributes the value transfers of the messenger call to its method, the value beyond
+// what the method may transfer is unattributed.
+func attributeMessengerOutflow(messengerABI *abi.ABI, call *calltrace.AddressCall, relayedHashes map[common.Hash]bool) slack.MessengerOutflowInfo {
+	info := slack.MessengerOutflowInfo{UnattributedValue: new(big.Int)}
+	for _, transfer := range call.Transfers {
+		info.Transfers = append(info.Transfers, slack.MessengerOutflowTransfer{To: transfer.To, Value: transfer.TransferredValue()})
+	}
+	if len(call.Transfers) == 0 {
+		return info
+	}
+
+	var args []interface{}
+	var method *abi.Method
+	if input := call.Frame.Input; len(input) >= 4 {
+		if m, err := messengerABI.MethodById(input[:4]); err == nil {
+			if unpacked, unpackErr := m.Inputs.Unpack(input[4:]); unpackErr == nil {
+				method, args = m, unpacked
+			}
+		}
+	}
+
+	callValue := call.Frame.TransferredValue()
+	allowance := new(big.Int)
+	var recipient *common.Address
+	switch {
+	case method == nil:
+		info.Method = "unknown"
+	case method.RawName == "relayMessage" || method.RawName == "relayMessageWithProof":
+		// relayMessage(from, to, value, nonce, message) and relayMessageWithProof(from, to, value, nonce, message, proof)
+		to, value := args[1].(common.Address), args[2].(*big.Int)
+		info.MessageHash = utils.ComputeMessageHash(args[0].(common.Address), to, value, args[3].(*big.Int), args[4].([]byte))
+		if relayedHashes[info.MessageHash] {
+			allowance, recipient = value, &to
+		}
+	case strings.HasPrefix(method.RawName, "sendMessage"):
+		// sendMessage(to, value, message, gasLimit[, refundAddress]), the call value beyond the message value is the fee.
+		allowance = new(big.Int).Sub(callValue, args[1].(*big.Int))
+	case method.RawName == "replayMessage":
+		allowance = callValue
+	case method.RawName == "dropMessage":
+		// dropMessage(from, to, value, nonce, message)
+		allowance = args[2].(*big.Int)
+	}
+	if method != nil {
+		info.Method = method.RawName
+	}
+	if allowance.Sign() < 0 {
+		allowance = new(big.Int)
+	}
+
+	for _, transfer := range info.Transfers {
+		if recipient != nil && transfer.To != *recipient {
+			info.UnattributedValue.Add(info.UnattributedValue, transfer.Value)
+			continue
+		}
+		if transfer.Value.Cmp(allowance) <= 0 {
+			allowance = new(big.Int).Sub(allowance, transfer.Value)
+			continue
+		}
+		info.UnattributedValue.Add(info.UnattributedValue, new(big.Int).Sub(transfer.Value, allowance))
+		allowance = new(big.Int)
+	}
+	return info
+}
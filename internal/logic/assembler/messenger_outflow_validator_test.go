@@ -0,0 +1,50 @@
+package assembler
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// standInBalanceAPI serves the balances of the messenger by block number in place of a node.
+type standInBalanceAPI struct {
+	balances map[uint64]int64
+}
+
+func (api *standInBalanceAPI) GetBalance(address common.Address, number hexutil.Uint64) (*hexutil.Big, error) {
+	return (*hexutil.Big)(big.NewInt(api.balances[uint64(number)])), nil
+}
+
+func TestBalanceChangedBlocks(t *testing.T) {
+	server := rpc.NewServer()
+	assert.NoError(t, server.RegisterName("eth", &standInBalanceAPI{balances: map[uint64]int64{
+		0: 100, 1: 100, 2: 90, 3: 90, 4: 120, 5: 120,
+	}}))
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+	messenger := common.HexToAddress("0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367")
+
+	tests := []struct {
+		name       string
+		start, end uint64
+		changed    []uint64
+	}{
+		{name: "changed", start: 1, end: 5, changed: []uint64{2, 4}},
+		{name: "changedAtStart", start: 2, end: 3, changed: []uint64{2}},
+		{name: "unchanged", start: 5, end: 5},
+		{name: "genesis", start: 0, end: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changed, err := balanceChangedBlocks(context.Background(), client, messenger, tt.start, tt.end)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.changed, changed)
+		})
+	}
+}
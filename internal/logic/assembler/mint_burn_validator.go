@@ -0,0 +1,189 @@
+package assembler
+
+import (
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+type mintBurnKey struct {
+	tokenAddress common.Address
+	// the token id in string, empty for erc20.
+	tokenID string
+	txHash  common.Hash
+	mint    bool
+}
+
+type mintBurnEvent struct {
+	key         mintBurnKey
+	tokenType   types.TokenType
+	blockNumber uint64
+	amount      *big.Int
+	// the order the key first appears in, to alert deterministically.
+	order int
+}
+
+// TokenAddresses returns the distinct token addresses of the gateway or transfer events.
+func TokenAddresses(eventsData []events.EventUnmarshaler) []common.Address {
+	seen := make(map[common.Address]struct{})
+	var tokens []common.Address
+	for _, eventData := range eventsData {
+		var token common.Address
+		switch event := eventData.(type) {
+		case *events.ERC20GatewayEventUnmarshaler:
+			token = event.TokenAddress
+		case *events.ERC721GatewayEventUnmarshaler:
+			token = event.TokenAddress
+		case *events.ERC1155GatewayEventUnmarshaler:
+			token = event.TokenAddress
+		default:
+			continue
+		}
+		if _, ok := seen[token]; !ok {
+			seen[token] = struct{}{}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// L2MintBurnValidator requires the mints of a bridged l2 token to add up to the gateway finalize deposit events, and
+// the burns to the gateway withdraw events, of the same token, token id and transaction. The transfer events only
+// contain the l2 mints and burns, an excess mint is a potential infinite mint exploit. It returns the mismatched mints
+// and burns, which are alerted once the range is stored.
+func (c *MessageMatchAssembler) L2MintBurnValidator(gatewayEventsData, transferEventsData []events.EventUnmarshaler, bridgedTokens map[common.Address]struct{}) []slack.UnauthorizedMintBurnInfo {
+	gatewayAmounts := sumMintBurnEvents(l2MintBurnEvents(gatewayEventsData, true), bridgedTokens)
+	transferAmounts := sumMintBurnEvents(l2MintBurnEvents(transferEventsData, false), bridgedTokens)
+
+	var mismatched []slack.UnauthorizedMintBurnInfo
+	for _, key := range mintBurnKeys(transferAmounts, gatewayAmounts) {
+		transferred := zeroMintBurnEvent(transferAmounts[key])
+		claimed := zeroMintBurnEvent(gatewayAmounts[key])
+		if transferred.amount.Cmp(claimed.amount) == 0 {
+			continue
+		}
+
+		event := transferred
+		if transferAmounts[key] == nil {
+			event = claimed
+		}
+		log.Error("bridged token minted or burned amount differs from gateway event",
+			"token address", key.tokenAddress.Hex(),
+			"token id", key.tokenID,
+			"mint", key.mint,
+			"amount", transferred.amount.String(),
+			"gateway amount", claimed.amount.String(),
+			"block number", event.blockNumber,
+			"tx hash", key.txHash.Hex(),
+		)
+		mismatched = append(mismatched, slack.UnauthorizedMintBurnInfo{
+			TokenAddress:  key.tokenAddress,
+			TokenType:     event.tokenType,
+			TokenID:       key.tokenID,
+			Mint:          key.mint,
+			Amount:        transferred.amount,
+			GatewayAmount: claimed.amount,
+			BlockNumber:   event.blockNumber,
+			TxHash:        key.txHash,
+		})
+	}
+	return mismatched
+}
+
+// sumMintBurnEvents sums the amounts of the mints and burns of the bridged tokens by key, in the order the keys
+// first appear.
+func sumMintBurnEvents(mintBurnEvents []mintBurnEvent, bridgedTokens map[common.Address]struct{}) map[mintBurnKey]*mintBurnEvent {
+	sums := make(map[mintBurnKey]*mintBurnEvent)
+	for _, event := range mintBurnEvents {
+		if _, ok := bridgedTokens[event.key.tokenAddress]; !ok {
+			continue
+		}
+
+		if exist, ok := sums[event.key]; ok {
+			exist.amount.Add(exist.amount, event.amount)
+			continue
+		}
+		sums[event.key] = &mintBurnEvent{
+			key:         event.key,
+			tokenType:   event.tokenType,
+			blockNumber: event.blockNumber,
+			amount:      new(big.Int).Set(event.amount),
+			order:       len(sums),
+		}
+	}
+	return sums
+}
+
+// mintBurnKeys the keys of the transfer sums in order, followed by the keys only in the gateway sums.
+func mintBurnKeys(transferAmounts, gatewayAmounts map[mintBurnKey]*mintBurnEvent) []mintBurnKey {
+	keys := make([]mintBurnKey, len(transferAmounts))
+	for key, event := range transferAmounts {
+		keys[event.order] = key
+	}
+	gatewayKeys := make([]mintBurnKey, len(gatewayAmounts))
+	for key, event := range gatewayAmounts {
+		gatewayKeys[event.order] = key
+	}
+	for _, key := range gatewayKeys {
+		if _, ok := transferAmounts[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func zeroMintBurnEvent(event *mintBurnEvent) *mintBurnEvent {
+	if event == nil {
+		return &mintBurnEvent{amount: new(big.Int)}
+	}
+	return event
+}
+
+// l2MintBurnEvents flattens the l2 gateway or transfer events into mints and burns with positive amounts.
+// The transfer events carry mints in negative amounts and burns in positive amounts.
+func l2MintBurnEvents(eventsData []events.EventUnmarshaler, gateway bool) []mintBurnEvent {
+	var mintBurnEvents []mintBurnEvent
+	appendEvent := func(token common.Address, tokenType types.TokenType, tokenID *big.Int, txHash common.Hash, blockNumber uint64, mint bool, amount *big.Int) {
+		key := mintBurnKey{tokenAddress: token, txHash: txHash, mint: mint}
+		if tokenID != nil {
+			key.tokenID = tokenID.String()
+		}
+		mintBurnEvents = append(mintBurnEvents, mintBurnEvent{key: key, tokenType: tokenType, blockNumber: blockNumber, amount: new(big.Int).Abs(amount)})
+	}
+
+	for _, eventData := range eventsData {
+		switch event := eventData.(type) {
+		case *events.ERC20GatewayEventUnmarshaler:
+			mint := event.Amount.Sign() < 0
+			if gateway {
+				mint = event.Type == types.L2FinalizeDepositERC20
+			}
+			appendEvent(event.TokenAddress, types.TokenTypeERC20, nil, event.TxHash, event.Number, mint, event.Amount)
+		case *events.ERC721GatewayEventUnmarshaler:
+			for idx, tokenID := range event.TokenIds {
+				mint := idx < len(event.Amounts) && event.Amounts[idx].Sign() < 0
+				if gateway {
+					mint = event.Type == types.L2FinalizeDepositERC721 || event.Type == types.L2FinalizeBatchDepositERC721
+				}
+				appendEvent(event.TokenAddress, types.TokenTypeERC721, tokenID, event.TxHash, event.Number, mint, big.NewInt(1))
+			}
+		case *events.ERC1155GatewayEventUnmarshaler:
+			for idx, tokenID := range event.TokenIds {
+				if idx >= len(event.Amounts) {
+					break
+				}
+				mint := event.Amounts[idx].Sign() < 0
+				if gateway {
+					mint = event.Type == types.L2FinalizeDepositERC1155 || event.Type == types.L2FinalizeBatchDepositERC1155
+				}
+				appendEvent(event.TokenAddress, types.TokenTypeERC1155, tokenID, event.TxHash, event.Number, mint, event.Amounts[idx])
+			}
+		}
+	}
+	return mintBurnEvents
+}
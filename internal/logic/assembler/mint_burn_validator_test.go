@@ -0,0 +1,88 @@
+package assembler
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+func TestL2MintBurnValidator(t *testing.T) {
+	token := common.HexToAddress("0x0000000000000000000000000000000000000b01")
+	unbridged := common.HexToAddress("0x0000000000000000000000000000000000000b02")
+	bridgedTokens := map[common.Address]struct{}{token: {}}
+	txHash := common.HexToHash("0x01")
+
+	finalizeDeposit := func(amount int64) events.EventUnmarshaler {
+		return &events.ERC20GatewayEventUnmarshaler{Type: types.L2FinalizeDepositERC20, TxHash: txHash, TokenAddress: token, Amount: big.NewInt(amount)}
+	}
+	withdraw := func(amount int64) events.EventUnmarshaler {
+		return &events.ERC20GatewayEventUnmarshaler{Type: types.L2WithdrawERC20, TxHash: txHash, TokenAddress: token, Amount: big.NewInt(amount)}
+	}
+	// the transfer events carry mints in negative amounts and burns in positive amounts.
+	transfer := func(token common.Address, amount int64) events.EventUnmarshaler {
+		return &events.ERC20GatewayEventUnmarshaler{TxHash: txHash, TokenAddress: token, Amount: big.NewInt(amount)}
+	}
+
+	tests := []struct {
+		name           string
+		gatewayEvents  []events.EventUnmarshaler
+		transferEvents []events.EventUnmarshaler
+		mismatched     int
+	}{
+		{
+			name:           "matched",
+			gatewayEvents:  []events.EventUnmarshaler{finalizeDeposit(100), withdraw(30)},
+			transferEvents: []events.EventUnmarshaler{transfer(token, -100), transfer(token, 30)},
+		},
+		{
+			name:           "summed",
+			gatewayEvents:  []events.EventUnmarshaler{finalizeDeposit(100), finalizeDeposit(50)},
+			transferEvents: []events.EventUnmarshaler{transfer(token, -120), transfer(token, -30)},
+		},
+		{
+			name:           "mintWithoutGateway",
+			transferEvents: []events.EventUnmarshaler{transfer(token, -100)},
+			mismatched:     1,
+		},
+		{
+			name:           "mintBeyondGateway",
+			gatewayEvents:  []events.EventUnmarshaler{finalizeDeposit(100)},
+			transferEvents: []events.EventUnmarshaler{transfer(token, -101)},
+			mismatched:     1,
+		},
+		{
+			name:           "burnLessThanGateway",
+			gatewayEvents:  []events.EventUnmarshaler{withdraw(30)},
+			transferEvents: []events.EventUnmarshaler{transfer(token, 20)},
+			mismatched:     1,
+		},
+		{
+			name:          "gatewayWithoutMint",
+			gatewayEvents: []events.EventUnmarshaler{finalizeDeposit(100)},
+			mismatched:    1,
+		},
+		{
+			name:           "unbridgedToken",
+			transferEvents: []events.EventUnmarshaler{transfer(unbridged, -100)},
+		},
+	}
+
+	c := &MessageMatchAssembler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, c.L2MintBurnValidator(tt.gatewayEvents, tt.transferEvents, bridgedTokens), tt.mismatched)
+		})
+	}
+
+	mismatched := c.L2MintBurnValidator([]events.EventUnmarshaler{finalizeDeposit(100)}, []events.EventUnmarshaler{transfer(token, -60), transfer(token, -41)}, bridgedTokens)
+	if assert.Len(t, mismatched, 1) {
+		assert.True(t, mismatched[0].Mint)
+		assert.Equal(t, "101", mismatched[0].Amount.String())
+		assert.Equal(t, "100", mismatched[0].GatewayAmount.String())
+	}
+}
@@ -0,0 +1,45 @@
+package assembler
+
+import (
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// RelayedMessageValidator requires the message hash recomputed from the relay transaction calldata to equal the message
+// hash of the relayed event, the relayed events whose relay transaction wasn't decoded are skipped. It returns the
+// mismatched relayed events, which are alerted once the range is stored.
+func (c *MessageMatchAssembler) RelayedMessageValidator(messengerEvents []events.EventUnmarshaler) []slack.RelayedMessageHashInfo {
+	var mismatched []slack.RelayedMessageHashInfo
+	for _, eventData := range messengerEvents {
+		event, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok || (event.Type != types.L1RelayedMessage && event.Type != types.L2RelayedMessage) {
+			continue
+		}
+		if event.RelayedMessageHash == (common.Hash{}) || event.RelayedMessageHash == event.MessageHash {
+			continue
+		}
+
+		log.Error("relay transaction calldata does not reproduce the relayed message hash",
+			"layer", event.Layer,
+			"block number", event.Number,
+			"tx hash", event.TxHash.Hex(),
+			"message hash", event.MessageHash.Hex(),
+			"relayed message hash", event.RelayedMessageHash.Hex(),
+		)
+		mismatched = append(mismatched, slack.RelayedMessageHashInfo{
+			Layer:              event.Layer,
+			EventType:          event.Type,
+			BlockNumber:        event.Number,
+			TxHash:             event.TxHash,
+			MessageHash:        event.MessageHash,
+			RelayedMessageHash: event.RelayedMessageHash,
+			MessageNonce:       event.MessageNonce,
+			Value:              event.Value,
+		})
+	}
+	return mismatched
+}
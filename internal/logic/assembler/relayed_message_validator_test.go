@@ -0,0 +1,47 @@
+package assembler
+
+import (
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+func TestRelayedMessageValidator(t *testing.T) {
+	messageHash := common.HexToHash("0x01")
+	otherHash := common.HexToHash("0x02")
+
+	tests := []struct {
+		name       string
+		event      *events.MessengerEventUnmarshaler
+		mismatched bool
+	}{
+		{name: "l1Matched", event: &events.MessengerEventUnmarshaler{Type: types.L1RelayedMessage, MessageHash: messageHash, RelayedMessageHash: messageHash}},
+		{name: "l2Matched", event: &events.MessengerEventUnmarshaler{Type: types.L2RelayedMessage, MessageHash: messageHash, RelayedMessageHash: messageHash}},
+		{name: "l1Mismatched", event: &events.MessengerEventUnmarshaler{Type: types.L1RelayedMessage, MessageHash: messageHash, RelayedMessageHash: otherHash}, mismatched: true},
+		{name: "l2Mismatched", event: &events.MessengerEventUnmarshaler{Type: types.L2RelayedMessage, MessageHash: messageHash, RelayedMessageHash: otherHash}, mismatched: true},
+		// the relay transaction didn't call the messenger directly, it wasn't decoded.
+		{name: "undecoded", event: &events.MessengerEventUnmarshaler{Type: types.L2RelayedMessage, MessageHash: messageHash}},
+		{name: "sentMessage", event: &events.MessengerEventUnmarshaler{Type: types.L1SentMessage, MessageHash: messageHash, RelayedMessageHash: otherHash}},
+	}
+
+	c := &MessageMatchAssembler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatched := c.RelayedMessageValidator([]events.EventUnmarshaler{tt.event})
+			if !tt.mismatched {
+				assert.Empty(t, mismatched)
+				return
+			}
+
+			if assert.Len(t, mismatched, 1) {
+				assert.Equal(t, tt.event.Type, mismatched[0].EventType)
+				assert.Equal(t, messageHash, mismatched[0].MessageHash)
+				assert.Equal(t, otherHash, mismatched[0].RelayedMessageHash)
+			}
+		})
+	}
+}
@@ -16,28 +16,36 @@ import (
 	"github.com/scroll-tech/chain-monitor/internal/utils/msgproof"
 )
 
-func (c *MessageMatchAssembler) checkL2WithdrawRoots(ctx context.Context, startBlockNumber, endBlockNumber uint64, client *rpc.Client, messageQueueAddr common.Address) (*orm.MessengerMessageMatch, error) {
+func (c *MessageMatchAssembler) checkL2WithdrawRoots(ctx context.Context, startBlockNumber, endBlockNumber uint64, client *rpc.Client, messageQueueAddr common.Address, checkInterval uint64) (*orm.MessengerMessageMatch, []orm.WithdrawTrieCheckpoint, error) {
 	log.Info("checking l2 withdraw roots", "start", startBlockNumber, "end", endBlockNumber)
 
 	if startBlockNumber > endBlockNumber {
-		return nil, nil
+		return nil, nil, nil
 	}
-	// recover latest withdraw trie.
+	// recover latest withdraw trie, from the latest checkpoint if it's not behind the latest valid message.
 	withdrawTrie := msgproof.NewWithdrawTrie()
 	msg, err := c.messengerMessageMatchOrm.GetLatestValidL2SentMessageMatch(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("get largest message nonce l2 message match failed, err: %w", err)
+		return nil, nil, fmt.Errorf("get largest message nonce l2 message match failed, err: %w", err)
 	}
-	if msg != nil {
+	checkpoint, err := c.withdrawTrieCheckpointOrm.GetLatestWithdrawTrieCheckpoint(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get latest withdraw trie checkpoint failed, err: %w", err)
+	}
+	// the withdraw root of the blocks without SentMessage events is unknown until the trie is recovered or appended.
+	trieRecovered := msg != nil || checkpoint != nil
+	if checkpoint != nil && (msg == nil || checkpoint.NextMessageNonce >= msg.NextMessageNonce) {
+		withdrawTrie.InitializeFromBranches(checkpoint.NextMessageNonce, msgproof.DecodeBytesToMerkleProof(checkpoint.Branches))
+	} else if msg != nil {
 		withdrawTrie.Initialize(msg.NextMessageNonce-1, common.HexToHash(msg.MessageHash), msg.MessageProof)
 	}
 
 	l2SentMessages, err := c.messengerMessageMatchOrm.GetL2SentMessagesInBlockRange(ctx, startBlockNumber, endBlockNumber)
 	if err != nil {
-		return nil, fmt.Errorf("get l2 sent messages in block range failed, err: %w", err)
+		return nil, nil, fmt.Errorf("get l2 sent messages in block range failed, err: %w", err)
 	}
-	if len(l2SentMessages) == 0 {
-		return nil, nil
+	if len(l2SentMessages) == 0 && checkInterval == 0 {
+		return nil, nil, nil
 	}
 
 	sentMessageEventHashesMap := make(map[uint64][]common.Hash)
@@ -49,16 +57,26 @@ func (c *MessageMatchAssembler) checkL2WithdrawRoots(ctx context.Context, startB
 	for blockNumber := range sentMessageEventHashesMap {
 		blockNums = append(blockNums, blockNumber)
 	}
+	for _, blockNumber := range sampleWithdrawRootBlocks(startBlockNumber, endBlockNumber, checkInterval) {
+		if _, exists := sentMessageEventHashesMap[blockNumber]; !exists {
+			blockNums = append(blockNums, blockNumber)
+		}
+	}
 	sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] < blockNums[j] })
 
 	withdrawRoots, err := utils.GetL2WithdrawRootsForBlocks(ctx, client, messageQueueAddr, blockNums)
 	if err != nil {
-		return nil, fmt.Errorf("get l2 withdraw roots failed, message queue addr: %v, blocks: %v, err: %w", messageQueueAddr, blockNums, err)
+		return nil, nil, fmt.Errorf("get l2 withdraw roots failed, message queue addr: %v, blocks: %v, err: %w", messageQueueAddr, blockNums, err)
 	}
 
 	var lastMessage *orm.MessengerMessageMatch
+	var checkpoints []orm.WithdrawTrieCheckpoint
 	for _, blockNum := range blockNums {
 		eventHashes := sentMessageEventHashesMap[blockNum]
+		if len(eventHashes) == 0 && !trieRecovered {
+			continue
+		}
+		trieRecovered = true
 		proofs := withdrawTrie.AppendMessages(eventHashes)
 		lastWithdrawRoot := withdrawTrie.MessageRoot()
 		if lastWithdrawRoot != withdrawRoots[blockNum] {
@@ -66,14 +84,16 @@ func (c *MessageMatchAssembler) checkL2WithdrawRoots(ctx context.Context, startB
 				BlockNumber:          blockNum,
 				LastWithdrawRoot:     lastWithdrawRoot,
 				ExpectedWithdrawRoot: withdrawRoots[blockNum],
+				SentMessageCount:     len(eventHashes),
 			}
 			log.Error("withdraw root mismatch",
 				"block number", blockNum,
+				"sent messages", len(eventHashes),
 				"got", lastWithdrawRoot,
 				"except", withdrawRoots[blockNum],
 			)
 			slack.Notify(slack.MrkDwnWithdrawRootMessage(info))
-			return nil, fmt.Errorf("withdraw root mismatch in %v, got: %v, expected %v", blockNum, lastWithdrawRoot, withdrawRoots[blockNum])
+			return nil, nil, fmt.Errorf("withdraw root mismatch in %v, got: %v, expected %v", blockNum, lastWithdrawRoot, withdrawRoots[blockNum])
 		}
 		// current block has SentMessage events.
 		numEvents := len(eventHashes)
@@ -87,7 +107,26 @@ func (c *MessageMatchAssembler) checkL2WithdrawRoots(ctx context.Context, startB
 				NextMessageNonce:      withdrawTrie.NextMessageNonce,
 				L2BlockNumber:         blockNum,
 			}
+			checkpoints = append(checkpoints, orm.WithdrawTrieCheckpoint{
+				L2BlockNumber:    blockNum,
+				NextMessageNonce: withdrawTrie.NextMessageNonce,
+				WithdrawRoot:     lastWithdrawRoot.Hex(),
+				Branches:         msgproof.EncodeMerkleProofToBytes(withdrawTrie.Branches()),
+			})
 		}
 	}
-	return lastMessage, nil
+	return lastMessage, checkpoints, nil
+}
+
+// sampleWithdrawRootBlocks returns the blocks of every checkInterval blocks between the startBlockNumber and
+// endBlockNumber along with the endBlockNumber, nothing is sampled if the checkInterval is 0.
+func sampleWithdrawRootBlocks(startBlockNumber, endBlockNumber, checkInterval uint64) []uint64 {
+	if checkInterval == 0 || startBlockNumber > endBlockNumber {
+		return nil
+	}
+	var blockNums []uint64
+	for blockNumber := (startBlockNumber + checkInterval - 1) / checkInterval * checkInterval; blockNumber < endBlockNumber; blockNumber += checkInterval {
+		blockNums = append(blockNums, blockNumber)
+	}
+	return append(blockNums, endBlockNumber)
 }
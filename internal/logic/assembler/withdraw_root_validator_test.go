@@ -0,0 +1,32 @@
+package assembler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleWithdrawRootBlocks(t *testing.T) {
+	tests := []struct {
+		name             string
+		startBlockNumber uint64
+		endBlockNumber   uint64
+		checkInterval    uint64
+		blocks           []uint64
+	}{
+		{name: "intervalZero", startBlockNumber: 3, endBlockNumber: 25, checkInterval: 0, blocks: nil},
+		{name: "intervalOne", startBlockNumber: 5, endBlockNumber: 8, checkInterval: 1, blocks: []uint64{5, 6, 7, 8}},
+		{name: "startAligned", startBlockNumber: 10, endBlockNumber: 25, checkInterval: 10, blocks: []uint64{10, 20, 25}},
+		{name: "startNotAligned", startBlockNumber: 3, endBlockNumber: 25, checkInterval: 10, blocks: []uint64{10, 20, 25}},
+		{name: "endAligned", startBlockNumber: 3, endBlockNumber: 20, checkInterval: 10, blocks: []uint64{10, 20}},
+		{name: "noIntervalBlockInRange", startBlockNumber: 11, endBlockNumber: 15, checkInterval: 10, blocks: []uint64{15}},
+		{name: "singleBlock", startBlockNumber: 7, endBlockNumber: 7, checkInterval: 10, blocks: []uint64{7}},
+		{name: "startAfterEnd", startBlockNumber: 8, endBlockNumber: 7, checkInterval: 10, blocks: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.blocks, sampleWithdrawRootBlocks(tt.startBlockNumber, tt.endBlockNumber, tt.checkInterval))
+		})
+	}
+}
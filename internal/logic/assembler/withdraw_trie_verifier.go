@@ -0,0 +1,88 @@
+package assembler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/utils/msgproof"
+)
+
+const withdrawTrieCheckpointBatchSize = 1000
+
+// VerifyWithdrawTrieCheckpoints replays the stored l2 sent messages into a new withdraw trie and confirms the next
+// nonce, withdraw root and frontier branches of every checkpoint. The trie is recovered from a mismatched checkpoint,
+// so the checkpoints after it are still verified. It returns the number of verified and mismatched checkpoints.
+func (c *MessageMatchAssembler) VerifyWithdrawTrieCheckpoints(ctx context.Context) (int, int, error) {
+	var verified, mismatched int
+	withdrawTrie := msgproof.NewWithdrawTrie()
+	var afterBlockNumber uint64
+	for {
+		checkpoints, err := c.withdrawTrieCheckpointOrm.GetWithdrawTrieCheckpoints(ctx, afterBlockNumber, withdrawTrieCheckpointBatchSize)
+		if err != nil {
+			return verified, mismatched, fmt.Errorf("get withdraw trie checkpoints failed, err: %w", err)
+		}
+		if len(checkpoints) == 0 {
+			return verified, mismatched, nil
+		}
+
+		for _, checkpoint := range checkpoints {
+			afterBlockNumber = checkpoint.L2BlockNumber
+			if mismatch := c.replayWithdrawTrieCheckpoint(ctx, withdrawTrie, checkpoint.L2BlockNumber, checkpoint.NextMessageNonce); mismatch != "" {
+				mismatched++
+				log.Error("withdraw trie checkpoint mismatch", "l2 block number", checkpoint.L2BlockNumber,
+					"next message nonce", checkpoint.NextMessageNonce, "reason", mismatch)
+				withdrawTrie.InitializeFromBranches(checkpoint.NextMessageNonce, msgproof.DecodeBytesToMerkleProof(checkpoint.Branches))
+				continue
+			}
+
+			switch {
+			case withdrawTrie.MessageRoot() != common.HexToHash(checkpoint.WithdrawRoot):
+				mismatched++
+				log.Error("withdraw trie checkpoint root mismatch", "l2 block number", checkpoint.L2BlockNumber,
+					"replayed root", withdrawTrie.MessageRoot().Hex(), "checkpoint root", checkpoint.WithdrawRoot)
+				withdrawTrie.InitializeFromBranches(checkpoint.NextMessageNonce, msgproof.DecodeBytesToMerkleProof(checkpoint.Branches))
+			case !bytes.Equal(msgproof.EncodeMerkleProofToBytes(withdrawTrie.Branches()), checkpoint.Branches):
+				mismatched++
+				log.Error("withdraw trie checkpoint branches mismatch", "l2 block number", checkpoint.L2BlockNumber,
+					"withdraw root", checkpoint.WithdrawRoot)
+				withdrawTrie.InitializeFromBranches(checkpoint.NextMessageNonce, msgproof.DecodeBytesToMerkleProof(checkpoint.Branches))
+			default:
+				verified++
+			}
+		}
+		log.Info("verified withdraw trie checkpoints", "to l2 block number", afterBlockNumber, "verified", verified, "mismatched", mismatched)
+	}
+}
+
+// replayWithdrawTrieCheckpoint appends the stored messages up to the next nonce of the checkpoint, it returns the
+// reason if the stored messages can't reach the checkpoint.
+func (c *MessageMatchAssembler) replayWithdrawTrieCheckpoint(ctx context.Context, withdrawTrie *msgproof.WithdrawTrie, blockNumber, nextMessageNonce uint64) string {
+	if nextMessageNonce < withdrawTrie.NextMessageNonce {
+		return fmt.Sprintf("next message nonce goes back from %d", withdrawTrie.NextMessageNonce)
+	}
+
+	messages, err := c.messengerMessageMatchOrm.GetL2SentMessagesByNonceRange(ctx, withdrawTrie.NextMessageNonce, nextMessageNonce)
+	if err != nil {
+		return fmt.Sprintf("get l2 sent messages failed, err: %v", err)
+	}
+
+	hashes := make([]common.Hash, 0, len(messages))
+	for i, message := range messages {
+		if message.NextMessageNonce != withdrawTrie.NextMessageNonce+uint64(i)+1 {
+			return fmt.Sprintf("l2 sent message of nonce %d is missing", withdrawTrie.NextMessageNonce+uint64(i))
+		}
+		if message.L2BlockNumber > blockNumber {
+			return fmt.Sprintf("l2 sent message of nonce %d is in the later block %d", message.NextMessageNonce-1, message.L2BlockNumber)
+		}
+		hashes = append(hashes, common.HexToHash(message.MessageHash))
+	}
+	if uint64(len(hashes)) != nextMessageNonce-withdrawTrie.NextMessageNonce {
+		return fmt.Sprintf("l2 sent messages of nonce %d to %d are missing", withdrawTrie.NextMessageNonce+uint64(len(hashes)), nextMessageNonce-1)
+	}
+	withdrawTrie.AppendMessages(hashes)
+	return ""
+}
@@ -0,0 +1,65 @@
+package contracts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/scroll-tech/go-ethereum/rpc"
+)
+
+// gatewaySelector is the selector of gateway(), which the scroll bridged tokens expose to return their minter.
+var gatewaySelector = crypto.Keccak256([]byte("gateway()"))[:4]
+
+// GetL2BridgedTokens returns the tokens among the given ones which are bridged through the configured l2 gateways.
+// The tokens of the gatewayTokens are bridged by definition, others are bridged if their gateway() is a configured
+// l2 gateway. The result of each token is cached since a token doesn't change its gateway in practice.
+func (l *Contracts) GetL2BridgedTokens(ctx context.Context, tokens, gatewayTokens []common.Address) (map[common.Address]struct{}, error) {
+	l.l2Contracts.bridgedTokensMutex.Lock()
+	defer l.l2Contracts.bridgedTokensMutex.Unlock()
+
+	for _, token := range gatewayTokens {
+		l.l2Contracts.bridgedTokens[token] = true
+	}
+
+	bridgedTokens := make(map[common.Address]struct{})
+	for _, token := range tokens {
+		bridged, cached := l.l2Contracts.bridgedTokens[token]
+		if !cached {
+			var err error
+			bridged, err = l.isL2BridgedToken(ctx, token)
+			if err != nil {
+				return nil, err
+			}
+			l.l2Contracts.bridgedTokens[token] = bridged
+		}
+
+		if bridged {
+			bridgedTokens[token] = struct{}{}
+		}
+	}
+	return bridgedTokens, nil
+}
+
+func (l *Contracts) isL2BridgedToken(ctx context.Context, token common.Address) (bool, error) {
+	output, err := l.l2Contracts.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: gatewaySelector}, nil)
+	if err != nil {
+		// An error reported by the node means the call reverted, the token doesn't expose gateway().
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			log.Debug("token has no gateway, it is not bridged", "token", token, "err", err)
+			return false, nil
+		}
+		return false, err
+	}
+
+	if len(output) != common.HashLength {
+		return false, nil
+	}
+
+	_, ok := l.l2Contracts.gatewayAddresses[common.BytesToAddress(output)]
+	return ok, nil
+}
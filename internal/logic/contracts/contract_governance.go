@@ -0,0 +1,142 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// governanceABI contains the privileged events of the bridge contracts, the parameter names of UpdateTokenMapping
+// are filled by layer since l1 and l2 custom gateways emit the same signature with different names.
+const governanceABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"implementation","type":"address"}],"name":"Upgraded","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"previousAdmin","type":"address"},{"indexed":false,"name":"newAdmin","type":"address"}],"name":"AdminChanged","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"previousOwner","type":"address"},{"indexed":true,"name":"newOwner","type":"address"}],"name":"OwnershipTransferred","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"account","type":"address"}],"name":"Paused","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"account","type":"address"}],"name":"Unpaused","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"role","type":"bytes32"},{"indexed":true,"name":"account","type":"address"},{"indexed":true,"name":"sender","type":"address"}],"name":"RoleGranted","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"role","type":"bytes32"},{"indexed":true,"name":"account","type":"address"},{"indexed":true,"name":"sender","type":"address"}],"name":"RoleRevoked","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"%[1]sToken","type":"address"},{"indexed":true,"name":"old%[2]sToken","type":"address"},{"indexed":true,"name":"new%[2]sToken","type":"address"}],"name":"UpdateTokenMapping","type":"event"}
+]`
+
+var governanceEventTypes = map[string]types.GovernanceEventType{
+	"Upgraded":             types.GovernanceEventTypeUpgraded,
+	"AdminChanged":         types.GovernanceEventTypeAdminChanged,
+	"OwnershipTransferred": types.GovernanceEventTypeOwnershipTransferred,
+	"Paused":               types.GovernanceEventTypePaused,
+	"Unpaused":             types.GovernanceEventTypeUnpaused,
+	"RoleGranted":          types.GovernanceEventTypeRoleGranted,
+	"RoleRevoked":          types.GovernanceEventTypeRoleRevoked,
+	"UpdateTokenMapping":   types.GovernanceEventTypeUpdateTokenMapping,
+}
+
+// GetGovernanceEvents returns the privileged events raised by every configured bridge contract of the layer
+// between the startBlockNumber and endBlockNumber.
+func (l *Contracts) GetGovernanceEvents(ctx context.Context, startBlockNumber, endBlockNumber uint64, layerType types.LayerType) ([]events.GovernanceEvent, error) {
+	var client ethereum.LogFilterer
+	var addresses []common.Address
+	var parsedABI abi.ABI
+	var err error
+	switch layerType {
+	case types.Layer1:
+		client = l.l1Contracts.client
+		addresses = l.l1Contracts.governanceAddresses
+		parsedABI, err = abi.JSON(strings.NewReader(fmt.Sprintf(governanceABI, "l1", "L2")))
+	case types.Layer2:
+		client = l.l2Contracts.client
+		addresses = l.l2Contracts.governanceAddresses
+		parsedABI, err = abi.JSON(strings.NewReader(fmt.Sprintf(governanceABI, "l2", "L1")))
+	default:
+		return nil, fmt.Errorf("invalid type, layerType: %v", layerType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(addresses) == 0 {
+		return nil, nil
+	}
+
+	var topics []common.Hash
+	for _, event := range parsedABI.Events {
+		topics = append(topics, event.ID)
+	}
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlockNumber),
+		ToBlock:   new(big.Int).SetUint64(endBlockNumber),
+		Addresses: addresses,
+		Topics:    [][]common.Hash{topics},
+	}
+
+	logs, err := client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var governanceEvents []events.GovernanceEvent
+	for _, vLog := range logs {
+		event, eventErr := parsedABI.EventByID(vLog.Topics[0])
+		if eventErr != nil {
+			log.Debug("unknown governance event", "tx hash", vLog.TxHash.String(), "err", eventErr)
+			continue
+		}
+
+		args := make(map[string]interface{})
+		if len(vLog.Data) > 0 {
+			if unpackErr := event.Inputs.UnpackIntoMap(args, vLog.Data); unpackErr != nil {
+				log.Debug("unpack into map failed", "tx hash", vLog.TxHash.String(), "event", event.Name, "err", unpackErr)
+				continue
+			}
+		}
+
+		var indexed abi.Arguments
+		for _, arg := range event.Inputs {
+			if arg.Indexed {
+				indexed = append(indexed, arg)
+			}
+		}
+		if parseErr := abi.ParseTopicsIntoMap(args, indexed, vLog.Topics[1:]); parseErr != nil {
+			log.Debug("parse topics into map failed", "tx hash", vLog.TxHash.String(), "event", event.Name, "err", parseErr)
+			continue
+		}
+
+		governanceEvents = append(governanceEvents, events.GovernanceEvent{
+			Layer:           layerType,
+			Type:            governanceEventTypes[event.Name],
+			Number:          vLog.BlockNumber,
+			TxHash:          vLog.TxHash,
+			Index:           vLog.Index,
+			ContractAddress: vLog.Address,
+			Args:            formatEventArgs(args),
+		})
+	}
+	return governanceEvents, nil
+}
+
+func formatEventArgs(args map[string]interface{}) map[string]string {
+	formatted := make(map[string]string, len(args))
+	for name, value := range args {
+		switch v := value.(type) {
+		case common.Address:
+			formatted[name] = v.Hex()
+		case [32]byte:
+			formatted[name] = common.Hash(v).Hex()
+		case []byte:
+			formatted[name] = hexutil.Encode(v)
+		default:
+			formatted[name] = fmt.Sprintf("%v", v)
+		}
+	}
+	return formatted
+}
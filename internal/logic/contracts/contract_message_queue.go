@@ -0,0 +1,196 @@
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+// l1MessageQueueABI only contains the events of the l1 message queue that the monitor needs.
+const l1MessageQueueABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":true,"name":"target","type":"address"},{"indexed":false,"name":"value","type":"uint256"},{"indexed":false,"name":"queueIndex","type":"uint64"},{"indexed":false,"name":"gasLimit","type":"uint256"},{"indexed":false,"name":"data","type":"bytes"}],"name":"QueueTransaction","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"index","type":"uint256"}],"name":"DropTransaction","type":"event"}
+]`
+
+// l1ToL2AliasOffset is the offset applied by the l1 message queue to contract senders.
+var l1ToL2AliasOffset = new(big.Int).SetBytes(common.FromHex("0x1111000000000000000000000000000000001111"))
+
+type l1QueueTransaction struct {
+	Sender     common.Address
+	Target     common.Address
+	Value      *big.Int
+	QueueIndex uint64
+	GasLimit   *big.Int
+	Data       []byte
+}
+
+type l1DropTransaction struct {
+	Index *big.Int
+}
+
+// GetL1MessengerReplayAndDrop returns the messages replayed or dropped through the l1 scroll messenger
+// between the startBlockNumber and endBlockNumber.
+//
+// The l1 messenger emits no event for replays and drops, so they are detected through the message queue:
+// a replay appends the original relayMessage calldata at a queue index that differs from the message nonce,
+// and a drop emits DropTransaction in a dropMessage transaction whose calldata carries the message.
+func (l *Contracts) GetL1MessengerReplayAndDrop(ctx context.Context, startBlockNumber, endBlockNumber uint64) ([]events.EventUnmarshaler, error) {
+	if l.l1Contracts.messageQueueAddress == (common.Address{}) {
+		return nil, nil
+	}
+
+	queueABI := &l.l1Contracts.messageQueueABI
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlockNumber),
+		ToBlock:   new(big.Int).SetUint64(endBlockNumber),
+		Addresses: []common.Address{l.l1Contracts.messageQueueAddress},
+		Topics:    [][]common.Hash{{queueABI.Events["QueueTransaction"].ID, queueABI.Events["DropTransaction"].ID}},
+	}
+
+	logs, err := l.l1Contracts.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var messengerEvents []events.EventUnmarshaler
+	droppedTxs := make(map[common.Hash]struct{})
+	for _, vLog := range logs {
+		switch vLog.Topics[0] {
+		case queueABI.Events["QueueTransaction"].ID:
+			event := l1QueueTransaction{}
+			if err := utils.UnpackLog(queueABI, &event, "QueueTransaction", vLog); err != nil {
+				log.Debug("unpack into interface failed", "tx hash", vLog.TxHash.String(), "err", err)
+				continue
+			}
+
+			replayEvent, replayErr := l.l1ReplayMessage(vLog.BlockNumber, vLog.TxHash, vLog.Index, event)
+			if replayErr != nil {
+				return nil, replayErr
+			}
+			if replayEvent != nil {
+				messengerEvents = append(messengerEvents, replayEvent)
+			}
+		case queueABI.Events["DropTransaction"].ID:
+			event := l1DropTransaction{}
+			if err := utils.UnpackLog(queueABI, &event, "DropTransaction", vLog); err != nil {
+				log.Debug("unpack into interface failed", "tx hash", vLog.TxHash.String(), "err", err)
+				continue
+			}
+
+			if _, ok := droppedTxs[vLog.TxHash]; ok {
+				continue
+			}
+			droppedTxs[vLog.TxHash] = struct{}{}
+
+			dropEvent, dropErr := l.l1DropMessage(ctx, vLog.BlockNumber, vLog.TxHash, vLog.Index, event)
+			if dropErr != nil {
+				return nil, dropErr
+			}
+			if dropEvent != nil {
+				messengerEvents = append(messengerEvents, dropEvent)
+			}
+		}
+	}
+	return messengerEvents, nil
+}
+
+func (l *Contracts) l1ReplayMessage(blockNumber uint64, txHash common.Hash, logIndex uint, event l1QueueTransaction) (events.EventUnmarshaler, error) {
+	messengerAlias := common.BigToAddress(new(big.Int).Add(new(big.Int).SetBytes(l.l1Contracts.messengerAddress.Bytes()), l1ToL2AliasOffset))
+	if event.Sender != messengerAlias && event.Sender != l.l1Contracts.messengerAddress {
+		return nil, nil
+	}
+
+	l2MessengerABI, err := il2scrollmessenger.Il2scrollmessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	method := l2MessengerABI.Methods["relayMessage"]
+	if len(event.Data) < 4 || !bytes.Equal(event.Data[:4], method.ID) {
+		return nil, nil
+	}
+
+	args, err := method.Inputs.Unpack(event.Data[4:])
+	if err != nil {
+		log.Warn("unpack relayMessage calldata failed", "tx hash", txHash.String(), "err", err)
+		return nil, nil
+	}
+
+	// The first append of a message uses its nonce as queue index, any later append of the same calldata is a replay.
+	messageNonce := args[3].(*big.Int)
+	if messageNonce.IsUint64() && messageNonce.Uint64() == event.QueueIndex {
+		return nil, nil
+	}
+
+	return &events.MessengerEventUnmarshaler{
+		Layer:        types.Layer1,
+		Type:         types.L1ReplayMessage,
+		Number:       blockNumber,
+		TxHash:       txHash,
+		Index:        logIndex,
+		MessageNonce: messageNonce,
+		Message:      args[4].([]byte),
+		MessageHash:  common.BytesToHash(crypto.Keccak256(event.Data)),
+		Value:        args[2].(*big.Int),
+	}, nil
+}
+
+func (l *Contracts) l1DropMessage(ctx context.Context, blockNumber uint64, txHash common.Hash, logIndex uint, event l1DropTransaction) (events.EventUnmarshaler, error) {
+	tx, _, err := l.l1Contracts.client.TransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("get drop message transaction failed, tx hash:%v, err:%w", txHash.Hex(), err)
+	}
+
+	if tx.To() == nil || *tx.To() != l.l1Contracts.messengerAddress {
+		log.Warn("drop message transaction is not sent to l1 messenger, skip it", "tx hash", txHash.String(), "queue index", event.Index)
+		return nil, nil
+	}
+
+	l1MessengerABI, err := il1scrollmessenger.Il1scrollmessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	method := l1MessengerABI.Methods["dropMessage"]
+	if len(tx.Data()) < 4 || !bytes.Equal(tx.Data()[:4], method.ID) {
+		log.Warn("drop message transaction calldata is not dropMessage, skip it", "tx hash", txHash.String(), "queue index", event.Index)
+		return nil, nil
+	}
+
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		log.Warn("unpack dropMessage calldata failed", "tx hash", txHash.String(), "err", err)
+		return nil, nil
+	}
+
+	from := args[0].(common.Address)
+	to := args[1].(common.Address)
+	value := args[2].(*big.Int)
+	messageNonce := args[3].(*big.Int)
+	message := args[4].([]byte)
+
+	return &events.MessengerEventUnmarshaler{
+		Layer:        types.Layer1,
+		Type:         types.L1DropMessage,
+		Number:       blockNumber,
+		TxHash:       txHash,
+		Index:        logIndex,
+		MessageNonce: messageNonce,
+		Message:      message,
+		MessageHash:  utils.ComputeMessageHash(from, to, value, messageNonce, message),
+		Value:        value,
+	}, nil
+}
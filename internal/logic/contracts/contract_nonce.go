@@ -0,0 +1,149 @@
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+// l2MessageQueueABI only contains the events of the l2 message queue that the monitor needs.
+const l2MessageQueueABI = `[
+	{"anonymous":false,"inputs":[{"indexed":false,"name":"index","type":"uint256"},{"indexed":false,"name":"messageHash","type":"bytes32"}],"name":"AppendMessage","type":"event"}
+]`
+
+// nextCrossDomainMessageIndexSelector is the selector of nextCrossDomainMessageIndex() of the l1 message queue.
+var nextCrossDomainMessageIndexSelector = crypto.Keccak256([]byte("nextCrossDomainMessageIndex()"))[:4]
+
+type l2AppendMessage struct {
+	Index       *big.Int
+	MessageHash [32]byte
+}
+
+// GetL1NextQueueIndex returns the next queue index of the l1 message queue at the blockNumber, it returns false if
+// the l1 message queue is unconfigured.
+func (l *Contracts) GetL1NextQueueIndex(ctx context.Context, blockNumber uint64) (uint64, bool, error) {
+	if l.l1Contracts.messageQueueAddress == (common.Address{}) {
+		return 0, false, nil
+	}
+
+	msg := ethereum.CallMsg{To: &l.l1Contracts.messageQueueAddress, Data: nextCrossDomainMessageIndexSelector}
+	output, err := l.l1Contracts.client.CallContract(ctx, msg, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(output) != common.HashLength {
+		return 0, false, fmt.Errorf("invalid nextCrossDomainMessageIndex output length:%d", len(output))
+	}
+
+	nextQueueIndex := new(big.Int).SetBytes(output)
+	if !nextQueueIndex.IsUint64() {
+		return 0, false, fmt.Errorf("invalid nextCrossDomainMessageIndex:%v", nextQueueIndex)
+	}
+	return nextQueueIndex.Uint64(), true, nil
+}
+
+// GetL1MessageQueueEvents returns the entries appended to the l1 message queue between the startBlockNumber and
+// endBlockNumber.
+func (l *Contracts) GetL1MessageQueueEvents(ctx context.Context, startBlockNumber, endBlockNumber uint64) ([]events.MessageQueueEvent, error) {
+	if l.l1Contracts.messageQueueAddress == (common.Address{}) {
+		return nil, nil
+	}
+
+	queueABI := &l.l1Contracts.messageQueueABI
+
+	l2MessengerABI, err := il2scrollmessenger.Il2scrollmessengerMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	relayMethod := l2MessengerABI.Methods["relayMessage"]
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlockNumber),
+		ToBlock:   new(big.Int).SetUint64(endBlockNumber),
+		Addresses: []common.Address{l.l1Contracts.messageQueueAddress},
+		Topics:    [][]common.Hash{{queueABI.Events["QueueTransaction"].ID}},
+	}
+
+	logs, err := l.l1Contracts.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	messengerAlias := common.BigToAddress(new(big.Int).Add(new(big.Int).SetBytes(l.l1Contracts.messengerAddress.Bytes()), l1ToL2AliasOffset))
+	queueEvents := make([]events.MessageQueueEvent, 0, len(logs))
+	for _, vLog := range logs {
+		event := l1QueueTransaction{}
+		if unpackErr := utils.UnpackLog(queueABI, &event, "QueueTransaction", vLog); unpackErr != nil {
+			log.Debug("unpack into interface failed", "tx hash", vLog.TxHash.String(), "err", unpackErr)
+			continue
+		}
+
+		messengerMessage := false
+		if (event.Sender == messengerAlias || event.Sender == l.l1Contracts.messengerAddress) &&
+			len(event.Data) >= 4 && bytes.Equal(event.Data[:4], relayMethod.ID) {
+			if args, unpackErr := relayMethod.Inputs.Unpack(event.Data[4:]); unpackErr == nil {
+				messageNonce := args[3].(*big.Int)
+				messengerMessage = messageNonce.IsUint64() && messageNonce.Uint64() == event.QueueIndex
+			}
+		}
+		queueEvents = append(queueEvents, events.MessageQueueEvent{
+			Layer:            types.Layer1,
+			Number:           vLog.BlockNumber,
+			TxHash:           vLog.TxHash,
+			QueueIndex:       event.QueueIndex,
+			MessengerMessage: messengerMessage,
+		})
+	}
+	return queueEvents, nil
+}
+
+// GetL2MessageQueueEvents returns the message hashes appended to the l2 message queue between the startBlockNumber
+// and endBlockNumber, only the l2 messenger appends to the l2 message queue.
+func (l *Contracts) GetL2MessageQueueEvents(ctx context.Context, startBlockNumber, endBlockNumber uint64) ([]events.MessageQueueEvent, error) {
+	if l.l2Contracts.messageQueueAddress == (common.Address{}) {
+		return nil, nil
+	}
+
+	queueABI := &l.l2Contracts.messageQueueABI
+
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(startBlockNumber),
+		ToBlock:   new(big.Int).SetUint64(endBlockNumber),
+		Addresses: []common.Address{l.l2Contracts.messageQueueAddress},
+		Topics:    [][]common.Hash{{queueABI.Events["AppendMessage"].ID}},
+	}
+
+	logs, err := l.l2Contracts.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	queueEvents := make([]events.MessageQueueEvent, 0, len(logs))
+	for _, vLog := range logs {
+		event := l2AppendMessage{}
+		if unpackErr := utils.UnpackLog(queueABI, &event, "AppendMessage", vLog); unpackErr != nil {
+			log.Debug("unpack into interface failed", "tx hash", vLog.TxHash.String(), "err", unpackErr)
+			continue
+		}
+		queueEvents = append(queueEvents, events.MessageQueueEvent{
+			Layer:            types.Layer2,
+			Number:           vLog.BlockNumber,
+			TxHash:           vLog.TxHash,
+			QueueIndex:       event.Index.Uint64(),
+			MessageHash:      common.Hash(event.MessageHash),
+			MessengerMessage: true,
+		})
+	}
+	return queueEvents, nil
+}
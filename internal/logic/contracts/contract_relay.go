@@ -0,0 +1,108 @@
+package contracts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+// the reasons a relay transaction is not decoded.
+const (
+	relayUndecodedIndirectCall = "indirect_call"
+	relayUndecodedUnpackFailed = "unpack_failed"
+)
+
+var relayedMessageUndecodedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+	Name: "relayed_message_undecoded_total",
+	Help: "The total number of relayed messages whose relay transaction calldata was not decoded.",
+}, []string{"layer", "reason"})
+
+// DecodeRelayedMessages fetches the relay transactions of the relayed messages, decodes the relayMessageWithProof (l1)
+// or relayMessage (l2) calldata, and fills the value, nonce and message of the relayed events along with the message
+// hash recomputed from them. Relay transactions which don't call the messenger directly are skipped and counted in
+// relayed_message_undecoded_total.
+func (l *Contracts) DecodeRelayedMessages(ctx context.Context, layerType types.LayerType, messengerEvents []events.EventUnmarshaler) error {
+	var client *ethclient.Client
+	var messengerAddress common.Address
+	var method abi.Method
+	var relayedType types.EventType
+	switch layerType {
+	case types.Layer1:
+		l1MessengerABI, err := il1scrollmessenger.Il1scrollmessengerMetaData.GetAbi()
+		if err != nil {
+			return err
+		}
+		client, messengerAddress = l.l1Contracts.client, l.l1Contracts.messengerAddress
+		method, relayedType = l1MessengerABI.Methods["relayMessageWithProof"], types.L1RelayedMessage
+	case types.Layer2:
+		l2MessengerABI, err := il2scrollmessenger.Il2scrollmessengerMetaData.GetAbi()
+		if err != nil {
+			return err
+		}
+		client, messengerAddress = l.l2Contracts.client, l.l2Contracts.messengerAddress
+		method, relayedType = l2MessengerABI.Methods["relayMessage"], types.L2RelayedMessage
+	default:
+		return fmt.Errorf("invalid type, layerType: %v", layerType)
+	}
+
+	txs := make(map[common.Hash]*gethTypes.Transaction)
+	for _, eventData := range messengerEvents {
+		event, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok || event.Type != relayedType {
+			continue
+		}
+
+		tx, exists := txs[event.TxHash]
+		if !exists {
+			var err error
+			tx, _, err = client.TransactionByHash(ctx, event.TxHash)
+			if err != nil {
+				return fmt.Errorf("get relay message transaction failed, layer:%v, tx hash:%v, err:%w", layerType, event.TxHash.Hex(), err)
+			}
+			txs[event.TxHash] = tx
+		}
+
+		if reason := decodeRelayTransaction(tx, messengerAddress, method, event); reason != "" {
+			relayedMessageUndecodedTotal.WithLabelValues(layerType.String(), reason).Inc()
+			log.Warn("relay transaction not decoded, skip the relayed message hash check", "layer", layerType, "tx hash", event.TxHash.Hex(), "reason", reason)
+		}
+	}
+	return nil
+}
+
+// decodeRelayTransaction fills the value, nonce, message and recomputed message hash of the relayed event from the
+// relay transaction calldata, it returns the reason if the transaction is not decoded.
+func decodeRelayTransaction(tx *gethTypes.Transaction, messengerAddress common.Address, method abi.Method, event *events.MessengerEventUnmarshaler) string {
+	if tx.To() == nil || *tx.To() != messengerAddress || len(tx.Data()) < 4 || !bytes.Equal(tx.Data()[:4], method.ID) {
+		return relayUndecodedIndirectCall
+	}
+
+	args, err := method.Inputs.Unpack(tx.Data()[4:])
+	if err != nil {
+		log.Debug("unpack relay message calldata failed", "tx hash", event.TxHash.Hex(), "err", err)
+		return relayUndecodedUnpackFailed
+	}
+
+	from := args[0].(common.Address)
+	to := args[1].(common.Address)
+	event.Value = args[2].(*big.Int)
+	event.MessageNonce = args[3].(*big.Int)
+	event.Message = args[4].([]byte)
+	event.RelayedMessageHash = utils.ComputeMessageHash(from, to, event.Value, event.MessageNonce, event.Message)
+	return ""
+}
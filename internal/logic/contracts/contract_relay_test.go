@@ -0,0 +1,76 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il2scrollmessenger"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+func TestDecodeRelayTransaction(t *testing.T) {
+	l1MessengerABI, err := il1scrollmessenger.Il1scrollmessengerMetaData.GetAbi()
+	require.NoError(t, err)
+	l2MessengerABI, err := il2scrollmessenger.Il2scrollmessengerMetaData.GetAbi()
+	require.NoError(t, err)
+
+	messenger := common.HexToAddress("0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367")
+	relayer := common.HexToAddress("0x0000000000000000000000000000000000000e01")
+	from := common.HexToAddress("0x0000000000000000000000000000000000000e02")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000e03")
+	value, nonce, message := big.NewInt(1e18), big.NewInt(42), []byte("message")
+	messageHash := utils.ComputeMessageHash(from, to, value, nonce, message)
+
+	l1Calldata, err := l1MessengerABI.Pack("relayMessageWithProof", from, to, value, nonce, message,
+		il1scrollmessenger.IL1ScrollMessengerL2MessageProof{BatchIndex: big.NewInt(1), MerkleProof: []byte{}})
+	require.NoError(t, err)
+	l2Calldata, err := l2MessengerABI.Pack("relayMessage", from, to, value, nonce, message)
+	require.NoError(t, err)
+
+	newTx := func(to *common.Address, data []byte) *gethTypes.Transaction {
+		return gethTypes.NewTx(&gethTypes.LegacyTx{To: to, Data: data})
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		tx     *gethTypes.Transaction
+		reason string
+	}{
+		{name: "l1RelayMessageWithProof", method: "l1", tx: newTx(&messenger, l1Calldata)},
+		{name: "l2RelayMessage", method: "l2", tx: newTx(&messenger, l2Calldata)},
+		{name: "throughRelayerContract", method: "l2", tx: newTx(&relayer, l2Calldata), reason: relayUndecodedIndirectCall},
+		{name: "contractCreation", method: "l2", tx: newTx(nil, l2Calldata), reason: relayUndecodedIndirectCall},
+		{name: "otherMethod", method: "l1", tx: newTx(&messenger, l2Calldata), reason: relayUndecodedIndirectCall},
+		{name: "shortCalldata", method: "l2", tx: newTx(&messenger, l2Calldata[:2]), reason: relayUndecodedIndirectCall},
+		{name: "truncatedCalldata", method: "l2", tx: newTx(&messenger, l2Calldata[:40]), reason: relayUndecodedUnpackFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := l2MessengerABI.Methods["relayMessage"]
+			if tt.method == "l1" {
+				method = l1MessengerABI.Methods["relayMessageWithProof"]
+			}
+
+			event := &events.MessengerEventUnmarshaler{MessageHash: messageHash}
+			assert.Equal(t, tt.reason, decodeRelayTransaction(tt.tx, messenger, method, event))
+			if tt.reason != "" {
+				assert.Equal(t, common.Hash{}, event.RelayedMessageHash)
+				return
+			}
+
+			assert.Equal(t, messageHash, event.RelayedMessageHash)
+			assert.Equal(t, value, event.Value)
+			assert.Equal(t, nonce, event.MessageNonce)
+			assert.Equal(t, message, event.Message)
+		})
+	}
+}
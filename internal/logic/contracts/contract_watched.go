@@ -0,0 +1,159 @@
+package contracts
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+type watchedContract struct {
+	name    string
+	layer   types.LayerType
+	address common.Address
+	abi     abi.ABI
+	// events the configured events keyed by their topic.
+	events map[common.Hash]config.WatchedEvent
+}
+
+func (l *Contracts) registerWatchedContracts(contracts []config.WatchedContract) error {
+	for _, contract := range contracts {
+		var layer types.LayerType
+		switch contract.Layer {
+		case "l1":
+			layer = types.Layer1
+		case "l2":
+			layer = types.Layer2
+		default:
+			return fmt.Errorf("register watched contract failed, name:%s, invalid layer:%s", contract.Name, contract.Layer)
+		}
+
+		file, err := os.Open(contract.ABIFile)
+		if err != nil {
+			return fmt.Errorf("register watched contract failed, name:%s, open abi file err:%w", contract.Name, err)
+		}
+		parsedABI, err := abi.JSON(file)
+		if closeErr := file.Close(); closeErr != nil {
+			log.Warn("close abi file failed", "file", contract.ABIFile, "err", closeErr)
+		}
+		if err != nil {
+			return fmt.Errorf("register watched contract failed, name:%s, parse abi file err:%w", contract.Name, err)
+		}
+
+		watched := &watchedContract{
+			name:    contract.Name,
+			layer:   layer,
+			address: contract.Address,
+			abi:     parsedABI,
+			events:  make(map[common.Hash]config.WatchedEvent),
+		}
+		for _, watchedEvent := range contract.Events {
+			event, exists := parsedABI.Events[watchedEvent.Name]
+			if !exists {
+				return fmt.Errorf("register watched contract failed, name:%s, event %s not found in abi", contract.Name, watchedEvent.Name)
+			}
+			watched.events[event.ID] = watchedEvent
+		}
+		if len(watched.events) == 0 {
+			log.Warn("watched contract has no event configured", "name", contract.Name, "address", contract.Address)
+			continue
+		}
+		l.watchedContracts = append(l.watchedContracts, watched)
+	}
+	return nil
+}
+
+// GetWatchedEvents returns the configured events of the watched contracts of the layer between the
+// startBlockNumber and endBlockNumber, only the events matching their configured filters are returned.
+func (l *Contracts) GetWatchedEvents(ctx context.Context, startBlockNumber, endBlockNumber uint64, layerType types.LayerType) ([]events.WatchedEvent, error) {
+	var client ethereum.LogFilterer
+	switch layerType {
+	case types.Layer1:
+		client = l.l1Contracts.client
+	case types.Layer2:
+		client = l.l2Contracts.client
+	default:
+		return nil, fmt.Errorf("invalid type, layerType: %v", layerType)
+	}
+
+	var watchedEvents []events.WatchedEvent
+	for _, contract := range l.watchedContracts {
+		if contract.layer != layerType {
+			continue
+		}
+
+		var topics []common.Hash
+		for topic := range contract.events {
+			topics = append(topics, topic)
+		}
+
+		query := ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(startBlockNumber),
+			ToBlock:   new(big.Int).SetUint64(endBlockNumber),
+			Addresses: []common.Address{contract.address},
+			Topics:    [][]common.Hash{topics},
+		}
+		logs, err := client.FilterLogs(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, vLog := range logs {
+			watchedEvent, exists := contract.events[vLog.Topics[0]]
+			if !exists {
+				continue
+			}
+			event, eventErr := contract.abi.EventByID(vLog.Topics[0])
+			if eventErr != nil {
+				log.Debug("unknown watched event", "contract", contract.name, "tx hash", vLog.TxHash.String(), "err", eventErr)
+				continue
+			}
+
+			args := make(map[string]interface{})
+			if len(vLog.Data) > 0 {
+				if unpackErr := event.Inputs.UnpackIntoMap(args, vLog.Data); unpackErr != nil {
+					log.Warn("unpack watched event failed", "contract", contract.name, "tx hash", vLog.TxHash.String(), "event", event.Name, "err", unpackErr)
+					continue
+				}
+			}
+
+			var indexed abi.Arguments
+			for _, arg := range event.Inputs {
+				if arg.Indexed {
+					indexed = append(indexed, arg)
+				}
+			}
+			if parseErr := abi.ParseTopicsIntoMap(args, indexed, vLog.Topics[1:]); parseErr != nil {
+				log.Warn("parse watched event topics failed", "contract", contract.name, "tx hash", vLog.TxHash.String(), "event", event.Name, "err", parseErr)
+				continue
+			}
+
+			formattedArgs := formatEventArgs(args)
+			if !events.MatchConditions(formattedArgs, watchedEvent.Filters) {
+				continue
+			}
+
+			watchedEvents = append(watchedEvents, events.WatchedEvent{
+				Layer:           layerType,
+				ContractName:    contract.name,
+				ContractAddress: vLog.Address,
+				EventName:       event.Name,
+				Number:          vLog.BlockNumber,
+				TxHash:          vLog.TxHash,
+				Index:           vLog.Index,
+				Args:            formattedArgs,
+				Alert:           watchedEvent.Alert,
+			})
+		}
+	}
+	return watchedEvents, nil
+}
@@ -16,6 +16,8 @@ import (
 type Contracts struct {
 	l1Contracts *l1Contracts
 	l2Contracts *l2Contracts
+
+	watchedContracts []*watchedContract
 }
 
 // NewContracts creates a new instance of Contracts which can be used to filter log fetchers
@@ -38,6 +40,10 @@ func (l *Contracts) Register(conf *config.Config) error {
 		return err
 	}
 
+	if err := l.registerWatchedContracts(conf.WatchedContracts); err != nil {
+		return err
+	}
+
 	return nil
 }
 
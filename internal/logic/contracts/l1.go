@@ -2,7 +2,9 @@ package contracts
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
 	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/ethclient"
 	"github.com/scroll-tech/go-ethereum/log"
@@ -23,7 +25,10 @@ type erc20GatewayMapping struct {
 type l1Contracts struct {
 	client *ethclient.Client
 
-	messenger *il1scrollmessenger.Il1scrollmessenger
+	messenger           *il1scrollmessenger.Il1scrollmessenger
+	messengerAddress    common.Address
+	messageQueueAddress common.Address
+	messageQueueABI     abi.ABI
 
 	erc20Gateways      map[types.ERC20]*il1erc20gateway.Il1erc20gateway
 	erc20GatewayTokens []erc20GatewayMapping
@@ -32,6 +37,8 @@ type l1Contracts struct {
 	erc721GatewayAddress  common.Address
 	ERC1155Gateway        *il1erc1155gateway.Il1erc1155gateway
 	ERC1155GatewayAddress common.Address
+
+	governanceAddresses []common.Address
 }
 
 func newL1Contracts(c *ethclient.Client) *l1Contracts {
@@ -48,6 +55,16 @@ func (l *l1Contracts) register(conf *config.Config) error {
 		log.Error("registerERC20Gateway failed", "address", conf.L1Config.L1Contracts.ScrollMessenger, "err", err)
 		return fmt.Errorf("register l2 scroll messenger contract failed, address:%v, err:%w", conf.L1Config.L1Contracts.ScrollMessenger.Hex(), err)
 	}
+	l.messengerAddress = conf.L1Config.L1Contracts.ScrollMessenger
+
+	l.messageQueueAddress = conf.L1Config.L1Contracts.MessageQueue
+	if l.messageQueueAddress == (common.Address{}) {
+		log.Warn("l1 message queue unconfigured, replayed and dropped messages are not monitored", "address", l.messageQueueAddress)
+	}
+	l.messageQueueABI, err = abi.JSON(strings.NewReader(l1MessageQueueABI))
+	if err != nil {
+		return fmt.Errorf("parse l1 message queue abi failed, err:%w", err)
+	}
 
 	erc20Gateways := []struct {
 		address common.Address
@@ -81,6 +98,10 @@ func (l *l1Contracts) register(conf *config.Config) error {
 		return err
 	}
 
+	for _, contract := range conf.L1Config.L1Contracts.Addresses() {
+		l.governanceAddresses = append(l.governanceAddresses, contract.Address)
+	}
+
 	return nil
 }
 
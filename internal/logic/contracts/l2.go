@@ -2,7 +2,10 @@ package contracts
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
 	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/ethclient"
 	"github.com/scroll-tech/go-ethereum/log"
@@ -18,7 +21,10 @@ import (
 type l2Contracts struct {
 	client *ethclient.Client
 
-	messenger *il2scrollmessenger.Il2scrollmessenger
+	messenger           *il2scrollmessenger.Il2scrollmessenger
+	messengerAddress    common.Address
+	messageQueueAddress common.Address
+	messageQueueABI     abi.ABI
 
 	erc20Gateways      map[types.ERC20]*il2erc20gateway.Il2erc20gateway
 	erc20GatewayTokens []erc20GatewayMapping
@@ -27,12 +33,20 @@ type l2Contracts struct {
 	erc721GatewayAddress  common.Address
 	ERC1155Gateway        *il2erc1155gateway.Il2erc1155gateway
 	ERC1155GatewayAddress common.Address
+
+	governanceAddresses []common.Address
+
+	gatewayAddresses   map[common.Address]struct{}
+	bridgedTokens      map[common.Address]bool
+	bridgedTokensMutex sync.Mutex
 }
 
 func newL2Contracts(c *ethclient.Client) *l2Contracts {
 	return &l2Contracts{
-		client:        c,
-		erc20Gateways: make(map[types.ERC20]*il2erc20gateway.Il2erc20gateway),
+		client:           c,
+		erc20Gateways:    make(map[types.ERC20]*il2erc20gateway.Il2erc20gateway),
+		gatewayAddresses: make(map[common.Address]struct{}),
+		bridgedTokens:    make(map[common.Address]bool),
 	}
 }
 
@@ -43,6 +57,12 @@ func (l *l2Contracts) register(conf *config.Config) error {
 		log.Error("registerERC20Gateway failed", "address", conf.L2Config.L2Contracts.ScrollMessenger, "err", err)
 		return fmt.Errorf("register l2 scroll messenger contract failed, address:%v, err:%w", conf.L2Config.L2Contracts.ScrollMessenger.Hex(), err)
 	}
+	l.messengerAddress = conf.L2Config.L2Contracts.ScrollMessenger
+	l.messageQueueAddress = conf.L2Config.L2Contracts.MessageQueue
+	l.messageQueueABI, err = abi.JSON(strings.NewReader(l2MessageQueueABI))
+	if err != nil {
+		return fmt.Errorf("parse l2 message queue abi failed, err:%w", err)
+	}
 
 	erc20Gateways := []struct {
 		Address common.Address
@@ -76,6 +96,14 @@ func (l *l2Contracts) register(conf *config.Config) error {
 		return err
 	}
 
+	for _, contract := range conf.L2Config.L2Contracts.Addresses() {
+		l.governanceAddresses = append(l.governanceAddresses, contract.Address)
+	}
+
+	for _, gateway := range conf.L2Config.L2Contracts.Gateway.Addresses() {
+		l.gatewayAddresses[gateway.Address] = struct{}{}
+	}
+
 	return nil
 }
 
@@ -56,11 +56,17 @@ func (c *LogicGatewayCrossChain) CheckCrossChainGatewayMessage(ctx context.Conte
 	var messageMatchIds []int64
 	for _, message := range messages {
 		c.crossChainGatewayCheckTotal.WithLabelValues(layerType.String()).Inc()
-		checkResult := c.checker.GatewayCrossChainCheck(layerType, message)
+		checkResult, diffs := c.checker.GatewayCrossChainCheck(layerType, message)
 		if checkResult == types.MismatchTypeValid {
 			messageMatchIds = append(messageMatchIds, message.ID)
 			continue
 		}
+
+		diff, marshalErr := marshalMismatchDiffs(diffs)
+		if marshalErr != nil {
+			log.Error("marshal cross chain gateway mismatch diffs failed", "id", message.ID, "error", marshalErr)
+		}
+
 		log.Error("checking cross chain gateway messages failed",
 			"layer", layerType.String(),
 			"l1_number", message.L1BlockNumber,
@@ -68,8 +74,13 @@ func (c *LogicGatewayCrossChain) CheckCrossChainGatewayMessage(ctx context.Conte
 			"l1_event_type", message.L1EventType,
 			"l2_event_type", message.L2EventType,
 			"mismatch_type", checkResult.String(),
+			"diff", diff,
 		)
 		slack.Notify(slack.MrkDwnGatewayCrossChainMessage(message, checkResult))
+
+		if updateErr := c.gatewayMessageOrm.UpdateCrossChainMismatch(ctx, message.ID, layerType, checkResult, diff); updateErr != nil {
+			log.Error("Logic.CheckCrossChainMessage UpdateCrossChainMismatch failed", "id", message.ID, "error", updateErr)
+		}
 	}
 
 	if err = c.gatewayMessageOrm.UpdateCrossChainStatus(ctx, messageMatchIds, layerType, types.CrossChainStatusTypeValid); err != nil {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/scroll-tech/chain-monitor/internal/orm"
 	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/log"
 )
 
@@ -38,190 +39,222 @@ func NewGatewayCrossEventMatcher() *GatewayCrossEventMatcher {
 	return c
 }
 
-// GatewayCrossChainCheck checks the cross chain events.
-func (c *GatewayCrossEventMatcher) GatewayCrossChainCheck(layer types.LayerType, messageMatch orm.GatewayMessageMatch) types.MismatchType {
+// GatewayCrossChainCheck checks the cross chain events, and returns the mismatch type with the fields which differ
+// between the layers.
+func (c *GatewayCrossEventMatcher) GatewayCrossChainCheck(layer types.LayerType, messageMatch orm.GatewayMessageMatch) (types.MismatchType, []MismatchDiff) {
 	switch layer {
 	case types.Layer1:
 		return c.checkL1EventAndAmountMatchL2(messageMatch)
 	case types.Layer2:
 		return c.checkL2EventAndAmountMatchL1(messageMatch)
 	}
-	return types.MismatchTypeValid
+	return types.MismatchTypeValid, nil
 }
 
 // checkL1EventAndAmountMatchL2 checks that every L1FinalizeWithdraw/L1RelayedMessage has a corresponding L2 event.
-func (c *GatewayCrossEventMatcher) checkL1EventAndAmountMatchL2(messageMatch orm.GatewayMessageMatch) types.MismatchType {
-	if !c.checkL1EventMatchL2(messageMatch) {
-		return types.MismatchTypeL1EventNotMatch
+func (c *GatewayCrossEventMatcher) checkL1EventAndAmountMatchL2(messageMatch orm.GatewayMessageMatch) (types.MismatchType, []MismatchDiff) {
+	if diffs := c.checkL1EventMatchL2(messageMatch); len(diffs) != 0 {
+		return types.MismatchTypeL1EventNotMatch, diffs
 	}
 
-	if !c.crossChainAmountMatch(messageMatch) {
-		return types.MismatchTypeL1AmountNotMatch
+	amountDiffs := c.crossChainAmountMatch(types.Layer1, messageMatch)
+	tokenDiffs := c.crossChainTokenMatch(types.Layer1, messageMatch)
+	recipientDiffs := crossChainAddressMatch(types.Layer1, "to", messageMatch.L1To, messageMatch.L2To)
+	senderDiffs := crossChainAddressMatch(types.Layer1, "from", messageMatch.L1From, messageMatch.L2From)
+	var diffs []MismatchDiff
+	diffs = append(diffs, amountDiffs...)
+	diffs = append(diffs, tokenDiffs...)
+	diffs = append(diffs, recipientDiffs...)
+	diffs = append(diffs, senderDiffs...)
+
+	switch {
+	case len(amountDiffs) != 0:
+		return types.MismatchTypeL1AmountNotMatch, diffs
+	case len(tokenDiffs) != 0:
+		return types.MismatchTypeL1TokenNotMatch, diffs
+	case len(recipientDiffs) != 0:
+		return types.MismatchTypeL1RecipientNotMatch, diffs
+	case len(senderDiffs) != 0:
+		return types.MismatchTypeL1SenderNotMatch, diffs
 	}
 
-	return types.MismatchTypeValid
+	return types.MismatchTypeValid, nil
 }
 
-func (c *GatewayCrossEventMatcher) checkL1EventMatchL2(messageMatch orm.GatewayMessageMatch) bool {
+func (c *GatewayCrossEventMatcher) checkL1EventMatchL2(messageMatch orm.GatewayMessageMatch) []MismatchDiff {
 	matchingEvent, isPresent := c.eventMatchMap[types.EventType(messageMatch.L1EventType)]
 	if !isPresent {
 		// If the L1 event type is not in the checklist, skip the check
-		return true
+		return nil
 	}
 
 	if matchingEvent != types.EventType(messageMatch.L2EventType) {
-		// If the matching event is not equal to the L2 event type, return false
-		return false
+		// If the matching event is not equal to the L2 event type, the events do not match
+		return []MismatchDiff{{Field: "l2_event_type", Expected: matchingEvent.String(), Actual: types.EventType(messageMatch.L2EventType).String()}}
 	}
 
-	if messageMatch.L2Amounts == "" {
-		return false
-	}
-
-	if messageMatch.L2TxHash == "" {
-		return false
-	}
-
-	if messageMatch.L2BlockNumber == 0 {
-		return false
-	}
-	return true
+	return missingEventInfoDiffs("l2", messageMatch.L2Amounts, messageMatch.L2TxHash, messageMatch.L2BlockNumber)
 }
 
 // checkL2EventAndAmountMatchL1  checks that every L2FinalizeDeposit/L2RelayedMessage has a corresponding L1 event.
-func (c *GatewayCrossEventMatcher) checkL2EventAndAmountMatchL1(messageMatch orm.GatewayMessageMatch) types.MismatchType {
-	if !c.checkL2EventMatchL1(messageMatch) {
-		return types.MismatchTypeL2EventNotMatch
+func (c *GatewayCrossEventMatcher) checkL2EventAndAmountMatchL1(messageMatch orm.GatewayMessageMatch) (types.MismatchType, []MismatchDiff) {
+	if diffs := c.checkL2EventMatchL1(messageMatch); len(diffs) != 0 {
+		return types.MismatchTypeL2EventNotMatch, diffs
 	}
 
-	if !c.crossChainAmountMatch(messageMatch) {
-		return types.MismatchTypeL2AmountNotMatch
+	amountDiffs := c.crossChainAmountMatch(types.Layer2, messageMatch)
+	tokenDiffs := c.crossChainTokenMatch(types.Layer2, messageMatch)
+	recipientDiffs := crossChainAddressMatch(types.Layer2, "to", messageMatch.L1To, messageMatch.L2To)
+	senderDiffs := crossChainAddressMatch(types.Layer2, "from", messageMatch.L1From, messageMatch.L2From)
+	var diffs []MismatchDiff
+	diffs = append(diffs, amountDiffs...)
+	diffs = append(diffs, tokenDiffs...)
+	diffs = append(diffs, recipientDiffs...)
+	diffs = append(diffs, senderDiffs...)
+
+	switch {
+	case len(amountDiffs) != 0:
+		return types.MismatchTypeL2AmountNotMatch, diffs
+	case len(tokenDiffs) != 0:
+		return types.MismatchTypeL2TokenNotMatch, diffs
+	case len(recipientDiffs) != 0:
+		return types.MismatchTypeL2RecipientNotMatch, diffs
+	case len(senderDiffs) != 0:
+		return types.MismatchTypeL2SenderNotMatch, diffs
 	}
 
-	return types.MismatchTypeValid
+	return types.MismatchTypeValid, nil
 }
 
 // checkL2EventMatchL1 checks that every L2FinalizeDeposit/L2RelayedMessage has a corresponding L1 event.
-func (c *GatewayCrossEventMatcher) checkL2EventMatchL1(messageMatch orm.GatewayMessageMatch) bool {
+func (c *GatewayCrossEventMatcher) checkL2EventMatchL1(messageMatch orm.GatewayMessageMatch) []MismatchDiff {
 	matchingEvent, isPresent := c.eventMatchMap[types.EventType(messageMatch.L2EventType)]
 	if !isPresent {
 		// If the L2 event type is not in the checklist, skip the check
-		return true
+		return nil
 	}
 
 	if matchingEvent != types.EventType(messageMatch.L1EventType) {
-		// If the matching event is not equal to the L1 event type, return false
-		return false
+		// If the matching event is not equal to the L1 event type, the events do not match
+		return []MismatchDiff{{Field: "l1_event_type", Expected: matchingEvent.String(), Actual: types.EventType(messageMatch.L1EventType).String()}}
 	}
 
-	if messageMatch.L1Amounts == "" {
-		return false
-	}
+	return missingEventInfoDiffs("l1", messageMatch.L1Amounts, messageMatch.L1TxHash, messageMatch.L1BlockNumber)
+}
 
-	if messageMatch.L1TxHash == "" {
-		return false
+// missingEventInfoDiffs returns the diffs of the counterpart event info which the matching event lacks.
+func missingEventInfoDiffs(prefix, amounts, txHash string, blockNumber uint64) []MismatchDiff {
+	var diffs []MismatchDiff
+	if amounts == "" {
+		diffs = append(diffs, MismatchDiff{Field: prefix + "_amounts", Expected: "not empty", Actual: amounts})
 	}
-
-	if messageMatch.L1BlockNumber == 0 {
-		return false
+	if txHash == "" {
+		diffs = append(diffs, MismatchDiff{Field: prefix + "_tx_hash", Expected: "not empty", Actual: txHash})
 	}
-
-	return true
+	if blockNumber == 0 {
+		diffs = append(diffs, MismatchDiff{Field: prefix + "_block_number", Expected: "not zero", Actual: "0"})
+	}
+	return diffs
 }
 
-// crossChainAmountMatch checks if the amounts and token IDs match for cross-chain events.
-func (c *GatewayCrossEventMatcher) crossChainAmountMatch(messageMatch orm.GatewayMessageMatch) bool {
-	var l1Amounts, l2Amounts []*big.Int
-	var l1TokenIds, l2TokenIds []*big.Int
-
-	if messageMatch.L1Amounts != "" {
-		l1AmountSplits := strings.Split(messageMatch.L1Amounts, ",")
-		for _, l1AmountSplit := range l1AmountSplits {
-			l1Amount, ok := new(big.Int).SetString(l1AmountSplit, 0)
-			if !ok {
-				log.Error("failed to parse l1AmountSplit", "l1AmountSplit", l1AmountSplit)
-				return false
-			}
-			l1Amounts = append(l1Amounts, l1Amount)
-		}
+func parseBigInts(field, value string) ([]*big.Int, bool) {
+	if value == "" {
+		return nil, true
 	}
-
-	if messageMatch.L1TokenIds != "" {
-		l1TokenIDSplits := strings.Split(messageMatch.L1TokenIds, ",")
-		for _, l1TokenIDSplit := range l1TokenIDSplits {
-			l1Token, ok := new(big.Int).SetString(l1TokenIDSplit, 0)
-			if !ok {
-				log.Error("failed to parse l1TokenIDSplit", "l1TokenIDSplit", l1TokenIDSplit)
-				return false
-			}
-			l1TokenIds = append(l1TokenIds, l1Token)
+	var values []*big.Int
+	for _, split := range strings.Split(value, ",") {
+		v, ok := new(big.Int).SetString(split, 0)
+		if !ok {
+			log.Error("failed to parse "+field, field, split)
+			return nil, false
 		}
+		values = append(values, v)
 	}
+	return values, true
+}
 
-	if messageMatch.L2Amounts != "" {
-		l2AmountSplits := strings.Split(messageMatch.L2Amounts, ",")
-		for _, l2AmountSplit := range l2AmountSplits {
-			l2Amount, ok := new(big.Int).SetString(l2AmountSplit, 0)
-			if !ok {
-				log.Error("failed to parse l2AmountSplit", "l2AmountSplit", l2AmountSplit)
-				return false
-			}
-			l2Amounts = append(l2Amounts, l2Amount)
-		}
+// crossChainAmountMatch checks if the amounts and token IDs match for cross-chain events, and returns the diffs.
+func (c *GatewayCrossEventMatcher) crossChainAmountMatch(layer types.LayerType, messageMatch orm.GatewayMessageMatch) []MismatchDiff {
+	l1Amounts, l1AmountsOk := parseBigInts("l1AmountSplit", messageMatch.L1Amounts)
+	l1TokenIds, l1TokenIdsOk := parseBigInts("l1TokenIDSplit", messageMatch.L1TokenIds)
+	l2Amounts, l2AmountsOk := parseBigInts("l2AmountSplit", messageMatch.L2Amounts)
+	l2TokenIds, l2TokenIdsOk := parseBigInts("l2TokenIDSplit", messageMatch.L2TokenIds)
+	if !l1AmountsOk || !l2AmountsOk {
+		return []MismatchDiff{newMismatchDiff(layer, "amounts", "", messageMatch.L1Amounts, messageMatch.L2Amounts)}
 	}
-
-	if messageMatch.L2TokenIds != "" {
-		l2TokenIDSplits := strings.Split(messageMatch.L2TokenIds, ",")
-		for _, l2TokenIDSplit := range l2TokenIDSplits {
-			l2TokenID, ok := new(big.Int).SetString(l2TokenIDSplit, 0)
-			if !ok {
-				log.Error("failed to parse l2TokenIDSplit", "l2TokenIDSplit", l2TokenIDSplit)
-				return false
-			}
-			l2TokenIds = append(l2TokenIds, l2TokenID)
-		}
+	if !l1TokenIdsOk || !l2TokenIdsOk {
+		return []MismatchDiff{newMismatchDiff(layer, "token_ids", "", messageMatch.L1TokenIds, messageMatch.L2TokenIds)}
 	}
 
+	var diffs []MismatchDiff
 	switch types.TokenType(messageMatch.TokenType) {
 	case types.TokenTypeETH, types.TokenTypeERC20:
 		if len(l1Amounts) != len(l2Amounts) || len(l1Amounts) != 1 {
 			log.Error("invalid amounts length", "len l1Amounts", len(l1Amounts), "len l2Amounts", len(l2Amounts))
-			return false
+			return []MismatchDiff{newMismatchDiff(layer, "amounts", "", messageMatch.L1Amounts, messageMatch.L2Amounts)}
 		}
 		if l1Amounts[0].Cmp(l2Amounts[0]) != 0 {
 			log.Error("mismatch in ETH/ERC20 L1 and L2 token amounts.", "l1Amount", l1Amounts[0], "l2Amount", l2Amounts[0])
-			return false
+			diffs = append(diffs, newMismatchDiff(layer, "amount", "", l1Amounts[0].String(), l2Amounts[0].String()))
 		}
 	case types.TokenTypeERC721:
 		if len(l1TokenIds) != len(l2TokenIds) {
 			log.Error("mismatch in ERC721 L1 and L2 token IDs length")
-			return false
+			return []MismatchDiff{newMismatchDiff(layer, "token_ids", "", messageMatch.L1TokenIds, messageMatch.L2TokenIds)}
 		}
 		for l1Idx, l1TokenID := range l1TokenIds {
 			l2TokenID := l2TokenIds[l1Idx]
 			if l1TokenID.Cmp(l2TokenID) != 0 {
 				log.Error("mismatch in ERC721 token IDs", "l1TokenID", l1TokenID, "l2TokenID", l2TokenID)
-				return false
+				diffs = append(diffs, newMismatchDiff(layer, "token_id", "", l1TokenID.String(), l2TokenID.String()))
 			}
 		}
 	case types.TokenTypeERC1155:
 		if len(l1TokenIds) != len(l2TokenIds) || len(l1Amounts) != len(l2Amounts) || len(l1TokenIds) != len(l1Amounts) {
 			log.Error("mismatch in ERC1155 token IDs or amounts length")
-			return false
+			return []MismatchDiff{
+				newMismatchDiff(layer, "token_ids", "", messageMatch.L1TokenIds, messageMatch.L2TokenIds),
+				newMismatchDiff(layer, "amounts", "", messageMatch.L1Amounts, messageMatch.L2Amounts),
+			}
 		}
 		for l1TokenIdx, l1TokenID := range l1TokenIds {
 			l2TokenID := l2TokenIds[l1TokenIdx]
 			if l1TokenID.Cmp(l2TokenID) != 0 {
 				log.Error("mismatch in ERC1155 token IDs", "l1TokenID", l1TokenID, "l2TokenID", l2TokenID)
-				return false
+				diffs = append(diffs, newMismatchDiff(layer, "token_id", "", l1TokenID.String(), l2TokenID.String()))
+				continue
 			}
 			l1Amount := l1Amounts[l1TokenIdx]
 			l2Amount := l2Amounts[l1TokenIdx]
 			if l1Amount.Cmp(l2Amount) != 0 {
 				log.Error("mismatch in ERC1155 token amounts", "l1Amount", l1Amount, "l2Amount", l2Amount)
-				return false
+				diffs = append(diffs, newMismatchDiff(layer, "amount", l1TokenID.String(), l1Amount.String(), l2Amount.String()))
 			}
 		}
 	}
-	return true
+	return diffs
+}
+
+// crossChainTokenMatch checks if the l1 and l2 token addresses reported by both layers match for cross-chain events.
+func (c *GatewayCrossEventMatcher) crossChainTokenMatch(layer types.LayerType, messageMatch orm.GatewayMessageMatch) []MismatchDiff {
+	diffs := crossChainAddressMatch(layer, "l1_token", messageMatch.L1L1Token, messageMatch.L2L1Token)
+	diffs = append(diffs, crossChainAddressMatch(layer, "l2_token", messageMatch.L1L2Token, messageMatch.L2L2Token)...)
+	if len(diffs) != 0 {
+		log.Error("mismatch in token address",
+			"l1 event l1Token", messageMatch.L1L1Token, "l2 event l1Token", messageMatch.L2L1Token,
+			"l1 event l2Token", messageMatch.L1L2Token, "l2 event l2Token", messageMatch.L2L2Token)
+	}
+	return diffs
+}
+
+// crossChainAddressMatch compares the addresses reported by both layers. An empty address is skipped, since the
+// address columns were added after the table and ETH messages carry no token address.
+func crossChainAddressMatch(layer types.LayerType, field, l1Address, l2Address string) []MismatchDiff {
+	if l1Address == "" || l2Address == "" {
+		return nil
+	}
+	if common.HexToAddress(l1Address) == common.HexToAddress(l2Address) {
+		return nil
+	}
+	return []MismatchDiff{newMismatchDiff(layer, field, "", l1Address, l2Address)}
 }
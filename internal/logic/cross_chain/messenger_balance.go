@@ -0,0 +1,150 @@
+package crosschain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// LogicMessengerBalance reconciles the locally tracked messenger eth balance with the real balance on chain, and
+// rebases the tracked balance from a trusted snapshot.
+type LogicMessengerBalance struct {
+	messengerMessageOrm *orm.MessengerMessageMatch
+	l1Client            *ethclient.Client
+	l2Client            *ethclient.Client
+	l1MessengerAddr     common.Address
+	l2MessengerAddr     common.Address
+
+	// the tracked block of the last drift alert of each layer, a drift is alerted once until the tracked block moves.
+	alertedBlockNumbers map[types.LayerType]uint64
+}
+
+// NewLogicMessengerBalance is a constructor for LogicMessengerBalance.
+func NewLogicMessengerBalance(db *gorm.DB, l1Client, l2Client *ethclient.Client, l1MessengerAddr, l2MessengerAddr common.Address) *LogicMessengerBalance {
+	return &LogicMessengerBalance{
+		messengerMessageOrm: orm.NewMessengerMessageMatch(db),
+		l1Client:            l1Client,
+		l2Client:            l2Client,
+		l1MessengerAddr:     l1MessengerAddr,
+		l2MessengerAddr:     l2MessengerAddr,
+		alertedBlockNumbers: make(map[types.LayerType]uint64),
+	}
+}
+
+// ReconcileETHBalance compares the tracked messenger eth balance of the layer with the balance on chain at the latest
+// fully processed block. The tracked balance holds from its block until the block before the next unchecked eth
+// message, and the blocks after processedBlockNumber may have messages not stored yet.
+func (c *LogicMessengerBalance) ReconcileETHBalance(ctx context.Context, layer types.LayerType, processedBlockNumber uint64) {
+	tracked, err := c.messengerMessageOrm.GetLatestETHBalanceValidMessageMatch(ctx, layer)
+	if err != nil {
+		log.Error("get latest eth balance valid message match failed", "layer", layer, "error", err)
+		return
+	}
+	if tracked == nil {
+		return
+	}
+
+	trackedBlockNumber, trackedBalance := tracked.L1BlockNumber, tracked.L1MessengerETHBalance.BigInt()
+	if layer == types.Layer2 {
+		trackedBlockNumber, trackedBalance = tracked.L2BlockNumber, tracked.L2MessengerETHBalance.BigInt()
+	}
+
+	reconcileBlockNumber := processedBlockNumber
+	unchecked, err := c.messengerMessageOrm.GetUncheckedLatestETHMessageMatch(ctx, layer, 1)
+	if err != nil {
+		log.Error("get unchecked eth message match failed", "layer", layer, "error", err)
+		return
+	}
+	if len(unchecked) != 0 {
+		nextBlockNumber := unchecked[0].L1BlockNumber
+		if layer == types.Layer2 {
+			nextBlockNumber = unchecked[0].L2BlockNumber
+		}
+		if nextBlockNumber > 0 && nextBlockNumber-1 < reconcileBlockNumber {
+			reconcileBlockNumber = nextBlockNumber - 1
+		}
+	}
+	if reconcileBlockNumber < trackedBlockNumber {
+		return
+	}
+
+	client, messengerAddr := c.layerClient(layer)
+	actualBalance, err := client.BalanceAt(ctx, messengerAddr, new(big.Int).SetUint64(reconcileBlockNumber))
+	if err != nil {
+		log.Error("get messenger balance failed", "layer", layer, "addr", messengerAddr, "block number", reconcileBlockNumber, "err", err)
+		return
+	}
+
+	if actualBalance.Cmp(trackedBalance) == 0 {
+		log.Debug("messenger eth balance reconciled", "layer", layer, "block number", reconcileBlockNumber, "balance", actualBalance.String())
+		return
+	}
+
+	log.Error("messenger eth balance drift",
+		"layer", layer,
+		"tracked block number", trackedBlockNumber,
+		"block number", reconcileBlockNumber,
+		"tracked balance", trackedBalance.String(),
+		"actual balance", actualBalance.String(),
+	)
+	if c.alertedBlockNumbers[layer] == trackedBlockNumber {
+		return
+	}
+	c.alertedBlockNumbers[layer] = trackedBlockNumber
+	slack.Notify(slack.MrkDwnMessengerBalanceDriftMessage(slack.MessengerBalanceDriftInfo{
+		Layer:              layer,
+		TrackedBlockNumber: trackedBlockNumber,
+		BlockNumber:        reconcileBlockNumber,
+		TrackedBalance:     trackedBalance,
+		ActualBalance:      actualBalance,
+	}))
+}
+
+// RebaseETHBalance replaces the tracked messenger eth balance of the layer at its latest tracked block with the
+// trusted balance, the balance on chain at the tracked block is used if the balance is nil. It returns the tracked
+// block number and the balances before and after the rebase.
+func (c *LogicMessengerBalance) RebaseETHBalance(ctx context.Context, layer types.LayerType, balance *big.Int) (uint64, *big.Int, *big.Int, error) {
+	tracked, err := c.messengerMessageOrm.GetLatestETHBalanceValidMessageMatch(ctx, layer)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if tracked == nil {
+		return 0, nil, nil, fmt.Errorf("no tracked messenger eth balance, layer: %v", layer)
+	}
+
+	trackedBlockNumber, trackedBalance := tracked.L1BlockNumber, tracked.L1MessengerETHBalance.BigInt()
+	if layer == types.Layer2 {
+		trackedBlockNumber, trackedBalance = tracked.L2BlockNumber, tracked.L2MessengerETHBalance.BigInt()
+	}
+
+	if balance == nil {
+		client, messengerAddr := c.layerClient(layer)
+		balance, err = client.BalanceAt(ctx, messengerAddr, new(big.Int).SetUint64(trackedBlockNumber))
+		if err != nil {
+			return 0, nil, nil, fmt.Errorf("get messenger balance failed, layer: %v, block number: %d, err: %w", layer, trackedBlockNumber, err)
+		}
+	}
+
+	if _, err = c.messengerMessageOrm.RebaseMessengerETHBalance(ctx, layer, trackedBlockNumber, decimal.NewFromBigInt(balance, 0)); err != nil {
+		return 0, nil, nil, err
+	}
+	delete(c.alertedBlockNumbers, layer)
+	return trackedBlockNumber, trackedBalance, balance, nil
+}
+
+func (c *LogicMessengerBalance) layerClient(layer types.LayerType) (*ethclient.Client, common.Address) {
+	if layer == types.Layer1 {
+		return c.l1Client, c.l1MessengerAddr
+	}
+	return c.l2Client, c.l2MessengerAddr
+}
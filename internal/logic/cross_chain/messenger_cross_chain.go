@@ -113,7 +113,7 @@ func (c *LogicMessengerCrossChain) CheckETHBalance(ctx context.Context, layerTyp
 
 	var truncateBlockNumber uint64
 	for _, messageMatch := range messageMatches {
-		if types.ETHAmountStatus(messageMatch.ETHAmountStatus) != types.ETHAmountStatusTypeSet {
+		if types.ETHAmountStatus(messageMatch.ETHAmountStatus) != types.ETHAmountStatusTypeSet && relayedETHAmount(layerType, messageMatch) == "" {
 			if layerType == types.Layer1 {
 				truncateBlockNumber = messageMatch.L1BlockNumber
 			} else {
@@ -255,11 +255,9 @@ func (c *LogicMessengerCrossChain) checkBalance(layer types.LayerType, startBala
 	for _, message := range messages {
 		c.crossChainETHTotal.WithLabelValues(layer.String()).Inc()
 
-		var amount *big.Int
-		var ok bool
-		amount, ok = new(big.Int).SetString(message.ETHAmount, 10)
+		amount, ok := messageETHAmount(layer, message)
 		if !ok {
-			return false, nil, nil, fmt.Errorf("database id:%d invalid ETHAmount value: %v, layer: %v", message.ID, message.ETHAmount, layer)
+			return false, nil, nil, fmt.Errorf("database id:%d invalid ETHAmount value: %v, relayed value: %v, layer: %v", message.ID, message.ETHAmount, message.RelayedETHAmount, layer)
 		}
 
 		if layer == types.Layer1 {
@@ -300,9 +298,9 @@ func (c *LogicMessengerCrossChain) computeBlockBalance(ctx context.Context, laye
 				blockNumberAmountMap[message.L1BlockNumber] = new(big.Int)
 			}
 
-			amount, ok := new(big.Int).SetString(message.ETHAmount, 10)
+			amount, ok := messageETHAmount(layer, message)
 			if !ok {
-				log.Error("invalid L1 ETH Amount value", "amount", message.ETHAmount)
+				log.Error("invalid L1 ETH Amount value", "amount", message.ETHAmount, "relayed amount", message.RelayedETHAmount)
 				return
 			}
 
@@ -320,9 +318,9 @@ func (c *LogicMessengerCrossChain) computeBlockBalance(ctx context.Context, laye
 				blockNumberAmountMap[message.L2BlockNumber] = new(big.Int)
 			}
 
-			amount, ok := new(big.Int).SetString(message.ETHAmount, 10)
+			amount, ok := messageETHAmount(layer, message)
 			if !ok {
-				log.Error("invalid L2 ETH Amount value", "amount", message.ETHAmount)
+				log.Error("invalid L2 ETH Amount value", "amount", message.ETHAmount, "relayed amount", message.RelayedETHAmount)
 				return
 			}
 
@@ -341,9 +339,17 @@ func (c *LogicMessengerCrossChain) computeBlockBalance(ctx context.Context, laye
 	lastBlockNumber := uint64(0)
 	for _, v := range messages {
 		crossChainStatus := types.CrossChainStatusTypeValid
-		crossCheckMatchResult := c.checker.MessengerCrossChainCheck(layer, v)
+		mismatchType := types.MismatchTypeUnknown
+		var mismatchDiff string
+		crossCheckMatchResult, diffs := c.checker.MessengerCrossChainCheck(layer, v)
 		if crossCheckMatchResult != types.MismatchTypeValid {
 			crossChainStatus = types.CrossChainStatusTypeInvalid
+			mismatchType = crossCheckMatchResult
+
+			var marshalErr error
+			if mismatchDiff, marshalErr = marshalMismatchDiffs(diffs); marshalErr != nil {
+				log.Error("marshal cross chain eth mismatch diffs failed", "id", v.ID, "error", marshalErr)
+			}
 			log.Error("checking cross chain eth event messages failed",
 				"layer", layer.String(),
 				"l1_number", v.L1BlockNumber,
@@ -351,6 +357,7 @@ func (c *LogicMessengerCrossChain) computeBlockBalance(ctx context.Context, laye
 				"l1_event_type", v.L1EventType,
 				"l2_event_type", v.L2EventType,
 				"mismatch_type", crossCheckMatchResult.String(),
+				"diff", mismatchDiff,
 			)
 			slack.Notify(slack.MrkDwnETHCrossChainMessage(*v, crossCheckMatchResult))
 		}
@@ -369,10 +376,14 @@ func (c *LogicMessengerCrossChain) computeBlockBalance(ctx context.Context, laye
 		mm := orm.MessengerMessageMatch{ID: v.ID}
 		if layer == types.Layer1 {
 			mm.L1CrossChainStatus = int(crossChainStatus)
+			mm.L1MismatchType = int(mismatchType)
+			mm.L1MismatchDiff = mismatchDiff
 			mm.L1MessengerETHBalance = decimal.NewFromBigInt(lastBlockBalance, 0)
 			mm.L1ETHBalanceStatus = int(types.ETHBalanceStatusTypeValid)
 		} else {
 			mm.L2CrossChainStatus = int(crossChainStatus)
+			mm.L2MismatchType = int(mismatchType)
+			mm.L2MismatchDiff = mismatchDiff
 			mm.L2MessengerETHBalance = decimal.NewFromBigInt(lastBlockBalance, 0)
 			mm.L2ETHBalanceStatus = int(types.ETHBalanceStatusTypeValid)
 		}
@@ -399,6 +410,24 @@ func (c *LogicMessengerCrossChain) computeBlockBalance(ctx context.Context, laye
 	}
 }
 
+// relayedETHAmount returns the value decoded from the relay transaction if the message is relayed on the layer.
+func relayedETHAmount(layer types.LayerType, message *orm.MessengerMessageMatch) string {
+	if (layer == types.Layer1 && types.EventType(message.L1EventType) == types.L1RelayedMessage) ||
+		(layer == types.Layer2 && types.EventType(message.L2EventType) == types.L2RelayedMessage) {
+		return message.RelayedETHAmount
+	}
+	return ""
+}
+
+// messageETHAmount returns the eth amount the message moves through the messenger of the layer, the relayed messages
+// use the value of the relay transaction itself rather than the amount of the sent side.
+func messageETHAmount(layer types.LayerType, message *orm.MessengerMessageMatch) (*big.Int, bool) {
+	if amount := relayedETHAmount(layer, message); amount != "" {
+		return new(big.Int).SetString(amount, 10)
+	}
+	return new(big.Int).SetString(message.ETHAmount, 10)
+}
+
 func (c *LogicMessengerCrossChain) getLatestBlockNumber(ctx context.Context, layerType types.LayerType) (uint64, error) {
 	switch layerType {
 	case types.Layer1:
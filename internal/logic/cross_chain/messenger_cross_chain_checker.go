@@ -22,75 +22,101 @@ func NewMessengerCrossEventMatcher() *MessengerCrossEventMatcher {
 	return c
 }
 
-// MessengerCrossChainCheck checks the cross chain events.
-func (c *MessengerCrossEventMatcher) MessengerCrossChainCheck(layer types.LayerType, messageMatch *orm.MessengerMessageMatch) types.MismatchType {
+// MessengerCrossChainCheck checks the cross chain events, and returns the mismatch type with the fields which differ
+// between the layers.
+func (c *MessengerCrossEventMatcher) MessengerCrossChainCheck(layer types.LayerType, messageMatch *orm.MessengerMessageMatch) (types.MismatchType, []MismatchDiff) {
 	switch layer {
 	case types.Layer1:
 		return c.checkL1EventAndAmountMatchL2(messageMatch)
 	case types.Layer2:
 		return c.checkL2EventAndAmountMatchL1(messageMatch)
 	}
-	return types.MismatchTypeValid
+	return types.MismatchTypeValid, nil
 }
 
 // checkL1EventAndAmountMatchL2 checks that every L1FinalizeWithdraw/L1RelayedMessage has a corresponding L2 event.
-func (c *MessengerCrossEventMatcher) checkL1EventAndAmountMatchL2(messageMatch *orm.MessengerMessageMatch) types.MismatchType {
-	if !c.checkL1EventMatchL2(messageMatch) {
-		return types.MismatchTypeL1EventNotMatch
+func (c *MessengerCrossEventMatcher) checkL1EventAndAmountMatchL2(messageMatch *orm.MessengerMessageMatch) (types.MismatchType, []MismatchDiff) {
+	if diffs := c.checkL1EventMatchL2(messageMatch); len(diffs) != 0 {
+		return types.MismatchTypeL1EventNotMatch, diffs
 	}
-	return types.MismatchTypeValid
+	if diffs := c.checkRelayedAmountMatch(types.Layer1, messageMatch); len(diffs) != 0 {
+		return types.MismatchTypeL1AmountNotMatch, diffs
+	}
+	return types.MismatchTypeValid, nil
 }
 
-func (c *MessengerCrossEventMatcher) checkL1EventMatchL2(messageMatch *orm.MessengerMessageMatch) bool {
+func (c *MessengerCrossEventMatcher) checkL1EventMatchL2(messageMatch *orm.MessengerMessageMatch) []MismatchDiff {
 	matchingEvent, isPresent := c.eventMatchMap[types.EventType(messageMatch.L1EventType)]
 	if !isPresent {
 		// If the L1 event type is not in the checklist, skip the check
-		return true
+		return nil
 	}
 
 	if matchingEvent != types.EventType(messageMatch.L2EventType) {
-		// If the matching event is not equal to the L2 event type, return false
-		return false
+		// If the matching event is not equal to the L2 event type, the events do not match
+		return []MismatchDiff{{Field: "l2_event_type", Expected: matchingEvent.String(), Actual: types.EventType(messageMatch.L2EventType).String()}}
 	}
 
+	var diffs []MismatchDiff
 	if messageMatch.L2TxHash == "" {
-		return false
+		diffs = append(diffs, MismatchDiff{Field: "l2_tx_hash", Expected: "not empty", Actual: messageMatch.L2TxHash})
 	}
 
 	if messageMatch.L2BlockNumber == 0 {
-		return false
+		diffs = append(diffs, MismatchDiff{Field: "l2_block_number", Expected: "not zero", Actual: "0"})
 	}
-	return true
+	return diffs
 }
 
 // checkL2EventAndAmountMatchL1  checks that every L2FinalizeDeposit/L2RelayedMessage has a corresponding L1 event.
-func (c *MessengerCrossEventMatcher) checkL2EventAndAmountMatchL1(messageMatch *orm.MessengerMessageMatch) types.MismatchType {
-	if !c.checkL2EventMatchL1(messageMatch) {
-		return types.MismatchTypeL2EventNotMatch
+func (c *MessengerCrossEventMatcher) checkL2EventAndAmountMatchL1(messageMatch *orm.MessengerMessageMatch) (types.MismatchType, []MismatchDiff) {
+	if diffs := c.checkL2EventMatchL1(messageMatch); len(diffs) != 0 {
+		return types.MismatchTypeL2EventNotMatch, diffs
+	}
+	if diffs := c.checkRelayedAmountMatch(types.Layer2, messageMatch); len(diffs) != 0 {
+		return types.MismatchTypeL2AmountNotMatch, diffs
 	}
-	return types.MismatchTypeValid
+	return types.MismatchTypeValid, nil
 }
 
 // checkL2EventMatchL1 checks that every L2FinalizeDeposit/L2RelayedMessage has a corresponding L1 event.
-func (c *MessengerCrossEventMatcher) checkL2EventMatchL1(messageMatch *orm.MessengerMessageMatch) bool {
+func (c *MessengerCrossEventMatcher) checkL2EventMatchL1(messageMatch *orm.MessengerMessageMatch) []MismatchDiff {
 	matchingEvent, isPresent := c.eventMatchMap[types.EventType(messageMatch.L2EventType)]
 	if !isPresent {
 		// If the L2 event type is not in the checklist, skip the check
-		return true
+		return nil
 	}
 
 	if matchingEvent != types.EventType(messageMatch.L1EventType) {
-		// If the matching event is not equal to the L1 event type, return false
-		return false
+		// If the matching event is not equal to the L1 event type, the events do not match
+		return []MismatchDiff{{Field: "l1_event_type", Expected: matchingEvent.String(), Actual: types.EventType(messageMatch.L1EventType).String()}}
 	}
 
+	var diffs []MismatchDiff
 	if messageMatch.L1TxHash == "" {
-		return false
+		diffs = append(diffs, MismatchDiff{Field: "l1_tx_hash", Expected: "not empty", Actual: messageMatch.L1TxHash})
 	}
 
 	if messageMatch.L1BlockNumber == 0 {
-		return false
+		diffs = append(diffs, MismatchDiff{Field: "l1_block_number", Expected: "not zero", Actual: "0"})
+	}
+
+	return diffs
+}
+
+// checkRelayedAmountMatch checks that the value of the relay transaction equals the value of the sent message.
+func (c *MessengerCrossEventMatcher) checkRelayedAmountMatch(layer types.LayerType, messageMatch *orm.MessengerMessageMatch) []MismatchDiff {
+	if messageMatch.RelayedETHAmount == "" || types.ETHAmountStatus(messageMatch.ETHAmountStatus) != types.ETHAmountStatusTypeSet {
+		return nil
+	}
+	if messageMatch.RelayedETHAmount == messageMatch.ETHAmount {
+		return nil
 	}
 
-	return true
+	// the relayed value belongs to the layer of the relayed event, the sent amount to its counterpart.
+	l1Amount, l2Amount := messageMatch.ETHAmount, messageMatch.RelayedETHAmount
+	if types.EventType(messageMatch.L1EventType) == types.L1RelayedMessage {
+		l1Amount, l2Amount = messageMatch.RelayedETHAmount, messageMatch.ETHAmount
+	}
+	return []MismatchDiff{newMismatchDiff(layer, "eth_amount", "", l1Amount, l2Amount)}
 }
@@ -0,0 +1,35 @@
+package crosschain
+
+import (
+	"encoding/json"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// MismatchDiff is a field of a message match that differs between the layers. Expected is the value reported by
+// the counterpart layer and Actual is the value reported by the checked layer.
+type MismatchDiff struct {
+	Field    string `json:"field"`
+	TokenID  string `json:"token_id,omitempty"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+func newMismatchDiff(layer types.LayerType, field, tokenID, l1Value, l2Value string) MismatchDiff {
+	if layer == types.Layer1 {
+		return MismatchDiff{Field: field, TokenID: tokenID, Expected: l2Value, Actual: l1Value}
+	}
+	return MismatchDiff{Field: field, TokenID: tokenID, Expected: l1Value, Actual: l2Value}
+}
+
+// marshalMismatchDiffs encodes the diffs into the json persisted along with the mismatch type.
+func marshalMismatchDiffs(diffs []MismatchDiff) (string, error) {
+	if len(diffs) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(diffs)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -22,6 +22,10 @@ type ERC1155GatewayEventUnmarshaler struct {
 	Index        uint
 	MessageHash  common.Hash
 	TokenAddress common.Address
+	L1Token      common.Address
+	L2Token      common.Address
+	From         common.Address
+	To           common.Address
 }
 
 // Unmarshal takes a context, layer type, and a list of iterators and unmarshals each iterator
@@ -50,6 +54,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      []*big.Int{iter.Event.Amount},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1BatchDepositERC1155:
 		iter := it.(*il1erc1155gateway.Il1erc1155gatewayBatchDepositERC1155Iterator)
@@ -62,6 +70,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      iter.Event.Amounts,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1FinalizeWithdrawERC1155:
 		iter := it.(*il1erc1155gateway.Il1erc1155gatewayFinalizeWithdrawERC1155Iterator)
@@ -74,6 +86,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      []*big.Int{iter.Event.Amount},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1FinalizeBatchWithdrawERC1155:
 		iter := it.(*il1erc1155gateway.Il1erc1155gatewayFinalizeBatchWithdrawERC1155Iterator)
@@ -86,6 +102,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      iter.Event.Amounts,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1RefundERC1155:
 		iter := it.(*il1erc1155gateway.Il1erc1155gatewayRefundERC1155Iterator)
@@ -98,6 +118,8 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      []*big.Int{iter.Event.Amount},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.Token,
+			L1Token:      iter.Event.Token,
+			To:           iter.Event.Recipient,
 		}
 	case types.L1BatchRefundERC1155:
 		iter := it.(*il1erc1155gateway.Il1erc1155gatewayBatchRefundERC1155Iterator)
@@ -110,6 +132,8 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      iter.Event.Amounts,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.Token,
+			L1Token:      iter.Event.Token,
+			To:           iter.Event.Recipient,
 		}
 	case types.L2WithdrawERC1155:
 		iter := it.(*il2erc1155gateway.Il2erc1155gatewayWithdrawERC1155Iterator)
@@ -122,6 +146,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      []*big.Int{iter.Event.Amount},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L2BatchWithdrawERC1155:
 		iter := it.(*il2erc1155gateway.Il2erc1155gatewayBatchWithdrawERC1155Iterator)
@@ -134,6 +162,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      iter.Event.Amounts,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L2FinalizeDepositERC1155:
 		iter := it.(*il2erc1155gateway.Il2erc1155gatewayFinalizeDepositERC1155Iterator)
@@ -146,6 +178,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      []*big.Int{iter.Event.Amount},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L2FinalizeBatchDepositERC1155:
 		iter := it.(*il2erc1155gateway.Il2erc1155gatewayFinalizeBatchDepositERC1155Iterator)
@@ -158,6 +194,10 @@ func (e *ERC1155GatewayEventUnmarshaler) erc1155(layerType types.LayerType, it t
 			Amounts:      iter.Event.Amounts,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	}
 	return event
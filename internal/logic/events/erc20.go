@@ -21,6 +21,11 @@ type ERC20GatewayEventUnmarshaler struct {
 	Index        uint
 	MessageHash  common.Hash
 	TokenAddress common.Address
+	L1Token      common.Address
+	L2Token      common.Address
+	From         common.Address
+	To           common.Address
+	Data         []byte
 }
 
 // Unmarshal takes a context, layer type, and a list of iterators and unmarshals each iterator
@@ -48,6 +53,11 @@ func (e *ERC20GatewayEventUnmarshaler) erc20(layerType types.LayerType, it types
 			Amount:       iter.Event.Amount,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
+			Data:         iter.Event.Data,
 		}
 	case types.L1FinalizeWithdrawERC20:
 		iter := it.(*il1erc20gateway.Il1erc20gatewayFinalizeWithdrawERC20Iterator)
@@ -59,6 +69,11 @@ func (e *ERC20GatewayEventUnmarshaler) erc20(layerType types.LayerType, it types
 			Amount:       iter.Event.Amount,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
+			Data:         iter.Event.Data,
 		}
 	case types.L1RefundERC20:
 		iter := it.(*il1erc20gateway.Il1erc20gatewayRefundERC20Iterator)
@@ -70,6 +85,8 @@ func (e *ERC20GatewayEventUnmarshaler) erc20(layerType types.LayerType, it types
 			Amount:       iter.Event.Amount,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.Token,
+			L1Token:      iter.Event.Token,
+			To:           iter.Event.Recipient,
 		}
 	case types.L2WithdrawERC20:
 		iter := it.(*il2erc20gateway.Il2erc20gatewayWithdrawERC20Iterator)
@@ -81,6 +98,11 @@ func (e *ERC20GatewayEventUnmarshaler) erc20(layerType types.LayerType, it types
 			Amount:       iter.Event.Amount,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
+			Data:         iter.Event.Data,
 		}
 	case types.L2FinalizeDepositERC20:
 		iter := it.(*il2erc20gateway.Il2erc20gatewayFinalizeDepositERC20Iterator)
@@ -92,6 +114,11 @@ func (e *ERC20GatewayEventUnmarshaler) erc20(layerType types.LayerType, it types
 			Amount:       iter.Event.Amount,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
+			Data:         iter.Event.Data,
 		}
 	}
 	return event
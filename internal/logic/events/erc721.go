@@ -22,6 +22,10 @@ type ERC721GatewayEventUnmarshaler struct {
 	Index        uint
 	MessageHash  common.Hash
 	TokenAddress common.Address
+	L1Token      common.Address
+	L2Token      common.Address
+	From         common.Address
+	To           common.Address
 }
 
 // Unmarshal takes a context, layer type, and a list of iterators and unmarshals each iterator
@@ -49,6 +53,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     []*big.Int{iter.Event.TokenId},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1BatchDepositERC721:
 		iter := it.(*il1erc721gateway.Il1erc721gatewayBatchDepositERC721Iterator)
@@ -60,6 +68,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     iter.Event.TokenIds,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 
 	case types.L1FinalizeWithdrawERC721:
@@ -72,6 +84,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     []*big.Int{iter.Event.TokenId},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1FinalizeBatchWithdrawERC721:
 		iter := it.(*il1erc721gateway.Il1erc721gatewayFinalizeBatchWithdrawERC721Iterator)
@@ -83,6 +99,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     iter.Event.TokenIds,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L1Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L1RefundERC721:
 		iter := it.(*il1erc721gateway.Il1erc721gatewayRefundERC721Iterator)
@@ -94,6 +114,8 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     []*big.Int{iter.Event.TokenId},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.Token,
+			L1Token:      iter.Event.Token,
+			To:           iter.Event.Recipient,
 		}
 	case types.L1BatchRefundERC721:
 		iter := it.(*il1erc721gateway.Il1erc721gatewayBatchRefundERC721Iterator)
@@ -105,6 +127,8 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     iter.Event.TokenIds,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.Token,
+			L1Token:      iter.Event.Token,
+			To:           iter.Event.Recipient,
 		}
 	case types.L2WithdrawERC721:
 		iter := it.(*il2erc721gateway.Il2erc721gatewayWithdrawERC721Iterator)
@@ -116,6 +140,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     []*big.Int{iter.Event.TokenId},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L2BatchWithdrawERC721:
 		iter := it.(*il2erc721gateway.Il2erc721gatewayBatchWithdrawERC721Iterator)
@@ -127,6 +155,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     iter.Event.TokenIds,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L2FinalizeDepositERC721:
 		iter := it.(*il2erc721gateway.Il2erc721gatewayFinalizeDepositERC721Iterator)
@@ -138,6 +170,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     []*big.Int{iter.Event.TokenId},
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	case types.L2FinalizeBatchDepositERC721:
 		iter := it.(*il2erc721gateway.Il2erc721gatewayFinalizeBatchDepositERC721Iterator)
@@ -149,6 +185,10 @@ func (e *ERC721GatewayEventUnmarshaler) erc721(layerType types.LayerType, it typ
 			TokenIds:     iter.Event.TokenIds,
 			Index:        iter.Event.Raw.Index,
 			TokenAddress: iter.Event.L2Token,
+			L1Token:      iter.Event.L1Token,
+			L2Token:      iter.Event.L2Token,
+			From:         iter.Event.From,
+			To:           iter.Event.To,
 		}
 	}
 	return event
@@ -0,0 +1,19 @@
+package events
+
+import (
+	"github.com/scroll-tech/go-ethereum/common"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// GovernanceEvent is a privileged event (upgrade, ownership change, pause, role change or token mapping update)
+// raised by one of the L1/L2 bridge contracts, with its decoded parameters.
+type GovernanceEvent struct {
+	Layer           types.LayerType
+	Type            types.GovernanceEventType
+	Number          uint64
+	TxHash          common.Hash
+	Index           uint
+	ContractAddress common.Address
+	Args            map[string]string
+}
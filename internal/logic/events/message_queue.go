@@ -0,0 +1,19 @@
+package events
+
+import (
+	"github.com/scroll-tech/go-ethereum/common"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// MessageQueueEvent is an entry appended to the L1/L2 message queue. MessengerMessage is true if the entry is the
+// first append of a messenger message, whose queue index must be the message nonce, and false for the enforced
+// transactions and the replays.
+type MessageQueueEvent struct {
+	Layer            types.LayerType
+	Number           uint64
+	TxHash           common.Hash
+	QueueIndex       uint64
+	MessageHash      common.Hash
+	MessengerMessage bool
+}
@@ -23,6 +23,10 @@ type MessengerEventUnmarshaler struct {
 	Message      []byte
 	MessageHash  common.Hash
 	Value        *big.Int
+
+	// RelayedMessageHash is recomputed from the relay transaction calldata of the relayed messages, it's zero if the
+	// relay transaction is not a direct call of the messenger.
+	RelayedMessageHash common.Hash
 }
 
 // Unmarshal takes a context, layer type, and a list of iterators, and unmarshals the SentMessage events
@@ -88,6 +92,26 @@ func (e *MessengerEventUnmarshaler) messenger(layerType types.LayerType, it type
 			Index:       iter.Event.Raw.Index,
 			MessageHash: iter.Event.MessageHash,
 		}
+	case types.L1FailedRelayedMessage:
+		iter := it.(*il1scrollmessenger.Il1scrollmessengerFailedRelayedMessageIterator)
+		event = &MessengerEventUnmarshaler{
+			Layer:       layerType,
+			Type:        eventType,
+			Number:      iter.Event.Raw.BlockNumber,
+			TxHash:      iter.Event.Raw.TxHash,
+			Index:       iter.Event.Raw.Index,
+			MessageHash: iter.Event.MessageHash,
+		}
+	case types.L2FailedRelayedMessage:
+		iter := it.(*il2scrollmessenger.Il2scrollmessengerFailedRelayedMessageIterator)
+		event = &MessengerEventUnmarshaler{
+			Layer:       layerType,
+			Type:        eventType,
+			Number:      iter.Event.Raw.BlockNumber,
+			TxHash:      iter.Event.Raw.TxHash,
+			Index:       iter.Event.Raw.Index,
+			MessageHash: iter.Event.MessageHash,
+		}
 	}
 	return event
 }
@@ -0,0 +1,67 @@
+package events
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/common"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// WatchedEvent is an event of a contract watched by config, with its decoded parameters.
+type WatchedEvent struct {
+	Layer           types.LayerType
+	ContractName    string
+	ContractAddress common.Address
+	EventName       string
+	Number          uint64
+	TxHash          common.Hash
+	Index           uint
+	Args            map[string]string
+	// Alert is the alert config of the event, nil if the event is not alertable.
+	Alert *config.WatchedEventAlert
+}
+
+// MatchConditions returns true if the args match all the conditions, a condition on a missing field never matches.
+func MatchConditions(args map[string]string, conditions []config.WatchedEventCondition) bool {
+	for _, condition := range conditions {
+		value, exists := args[condition.Field]
+		if !exists || !matchCondition(value, condition) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchCondition(value string, condition config.WatchedEventCondition) bool {
+	actual, actualOK := new(big.Int).SetString(value, 10)
+	expected, expectedOK := new(big.Int).SetString(condition.Value, 10)
+	if actualOK && expectedOK {
+		cmp := actual.Cmp(expected)
+		switch condition.Op {
+		case "eq":
+			return cmp == 0
+		case "ne":
+			return cmp != 0
+		case "gt":
+			return cmp > 0
+		case "gte":
+			return cmp >= 0
+		case "lt":
+			return cmp < 0
+		case "lte":
+			return cmp <= 0
+		}
+		return false
+	}
+
+	switch condition.Op {
+	case "eq":
+		return strings.EqualFold(value, condition.Value)
+	case "ne":
+		return !strings.EqualFold(value, condition.Value)
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package explorer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// placeholder the placeholder of the explorer url templates replaced with the hash or the address.
+const placeholder = "{}"
+
+var registry *Registry
+
+type labelKey struct {
+	layer   types.LayerType
+	address common.Address
+}
+
+// Registry the explorer url templates of both layers and the labels of the known addresses used by the alert messages.
+type Registry struct {
+	explorers  map[types.LayerType]*config.ExplorerConfig
+	messageURL string
+	labels     map[labelKey]string
+}
+
+// NewRegistry labels the bridge contracts and the watched contracts of the config, loads the address label file, and
+// creates the registry used by the alert messages. The labels of the file take precedence.
+func NewRegistry(cfg *config.Config) (*Registry, error) {
+	r := &Registry{
+		explorers:  make(map[types.LayerType]*config.ExplorerConfig),
+		messageURL: cfg.MessageExplorerURL,
+		labels:     make(map[labelKey]string),
+	}
+
+	if cfg.L1Config != nil {
+		r.explorers[types.Layer1] = cfg.L1Config.Explorer
+		if cfg.L1Config.L1Contracts != nil {
+			for _, contract := range cfg.L1Config.L1Contracts.Addresses() {
+				r.labels[labelKey{layer: types.Layer1, address: contract.Address}] = "l1 " + contract.Name
+			}
+		}
+	}
+	if cfg.L2Config != nil {
+		r.explorers[types.Layer2] = cfg.L2Config.Explorer
+		if cfg.L2Config.L2Contracts != nil {
+			for _, contract := range cfg.L2Config.L2Contracts.Addresses() {
+				r.labels[labelKey{layer: types.Layer2, address: contract.Address}] = "l2 " + contract.Name
+			}
+		}
+	}
+	for _, contract := range cfg.WatchedContracts {
+		layer, err := parseLayer(contract.Layer)
+		if err != nil {
+			return nil, fmt.Errorf("watched contract %s: %w", contract.Name, err)
+		}
+		r.labels[labelKey{layer: layer, address: contract.Address}] = contract.Name
+	}
+
+	if cfg.AddressLabelFile != "" {
+		if err := r.loadLabels(cfg.AddressLabelFile); err != nil {
+			return nil, err
+		}
+	}
+
+	registry = r
+	return r, nil
+}
+
+// addressLabel a known address of the address label file.
+type addressLabel struct {
+	// Layer the layer of the address, l1 or l2.
+	Layer   string         `json:"layer"`
+	Address common.Address `json:"address"`
+	Name    string         `json:"name"`
+}
+
+func (r *Registry) loadLabels(file string) error {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+	var labelFile struct {
+		Labels []addressLabel `json:"labels"`
+	}
+	if err = json.Unmarshal(data, &labelFile); err != nil {
+		return fmt.Errorf("parse address label file %s failed: %w", file, err)
+	}
+
+	for _, label := range labelFile.Labels {
+		layer, layerErr := parseLayer(label.Layer)
+		if layerErr != nil {
+			return fmt.Errorf("address %s of the address label file: %w", label.Address.Hex(), layerErr)
+		}
+		r.labels[labelKey{layer: layer, address: label.Address}] = label.Name
+	}
+	log.Info("address labels loaded", "file", file, "labels", len(labelFile.Labels))
+	return nil
+}
+
+func parseLayer(layer string) (types.LayerType, error) {
+	switch layer {
+	case "l1":
+		return types.Layer1, nil
+	case "l2":
+		return types.Layer2, nil
+	}
+	return types.LayerUnknown, fmt.Errorf("invalid layer %q", layer)
+}
+
+// Label returns the label of the address, empty if the address isn't known.
+func Label(layer types.LayerType, address common.Address) string {
+	if registry == nil {
+		return ""
+	}
+	return registry.labels[labelKey{layer: layer, address: address}]
+}
+
+// Tx returns the tx hash as a slack link to the explorer of the layer, or the tx hash itself if the layer has no
+// tx url template.
+func Tx(layer types.LayerType, txHash string) string {
+	if txHash == "" || registry == nil || registry.explorers[layer] == nil {
+		return txHash
+	}
+	return link(registry.explorers[layer].TxURL, txHash)
+}
+
+// Message returns the message hash as a slack link to the message explorer, or the message hash itself if the message
+// explorer isn't configured.
+func Message(messageHash string) string {
+	if messageHash == "" || registry == nil {
+		return messageHash
+	}
+	return link(registry.messageURL, messageHash)
+}
+
+// AddressLink returns the address as a slack link to the explorer of the layer, or the address itself if the layer has
+// no address url template.
+func AddressLink(layer types.LayerType, address common.Address) string {
+	if registry == nil || registry.explorers[layer] == nil {
+		return address.Hex()
+	}
+	return link(registry.explorers[layer].AddressURL, address.Hex())
+}
+
+// Address returns the address link annotated with the label of the address, e.g. <...|0x6774...> (l1 scroll_messenger).
+func Address(layer types.LayerType, address common.Address) string {
+	if label := Label(layer, address); label != "" {
+		return fmt.Sprintf("%s (%s)", AddressLink(layer, address), label)
+	}
+	return AddressLink(layer, address)
+}
+
+func link(template, value string) string {
+	if template == "" {
+		return value
+	}
+	return fmt.Sprintf("<%s|%s>", strings.ReplaceAll(template, placeholder, value), value)
+}
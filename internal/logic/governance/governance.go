@@ -0,0 +1,67 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+)
+
+// LogicGovernance records the privileged events of the bridge contracts and alerts on them.
+type LogicGovernance struct {
+	governanceEventOrm *orm.GovernanceEvent
+}
+
+// NewLogicGovernance creates a new LogicGovernance instance.
+func NewLogicGovernance(db *gorm.DB) *LogicGovernance {
+	return &LogicGovernance{
+		governanceEventOrm: orm.NewGovernanceEvent(db),
+	}
+}
+
+// InsertGovernanceEvents stores the governance events in the audit table, and returns the events recorded for the
+// first time, which are alerted once the transaction is committed.
+func (g *LogicGovernance) InsertGovernanceEvents(ctx context.Context, governanceEvents []events.GovernanceEvent, dbTX *gorm.DB) ([]events.GovernanceEvent, error) {
+	var recorded []events.GovernanceEvent
+	for _, event := range governanceEvents {
+		args, err := json.Marshal(event.Args)
+		if err != nil {
+			return nil, fmt.Errorf("marshal governance event args failed, err: %w, tx hash:%s", err, event.TxHash.Hex())
+		}
+
+		governanceEvent := orm.GovernanceEvent{
+			Layer:           int(event.Layer),
+			EventType:       int(event.Type),
+			ContractAddress: event.ContractAddress.Hex(),
+			BlockNumber:     event.Number,
+			TxHash:          event.TxHash.Hex(),
+			LogIndex:        event.Index,
+			Args:            string(args),
+		}
+		effectRow, err := g.governanceEventOrm.InsertGovernanceEvent(ctx, governanceEvent, dbTX)
+		if err != nil {
+			return nil, fmt.Errorf("governance event orm insert failed, err: %w, layer:%s", err, event.Layer.String())
+		}
+
+		if effectRow == 0 {
+			log.Debug("governance event already recorded", "layer", event.Layer, "tx hash", event.TxHash, "log index", event.Index)
+			continue
+		}
+		recorded = append(recorded, event)
+	}
+	return recorded, nil
+}
+
+// NotifyGovernanceEvents raises a high-severity alert for each newly recorded governance event.
+func (g *LogicGovernance) NotifyGovernanceEvents(governanceEvents []events.GovernanceEvent) {
+	for _, event := range governanceEvents {
+		log.Warn("bridge contract governance event", "layer", event.Layer, "event type", event.Type, "contract", event.ContractAddress, "tx hash", event.TxHash, "args", event.Args)
+		slack.Notify(slack.MrkDwnGovernanceEventMessage(event))
+	}
+}
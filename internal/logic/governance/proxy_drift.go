@@ -0,0 +1,268 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/scroll-tech/go-ethereum/rpc"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+var (
+	// eip1967ImplementationSlot is bytes32(uint256(keccak256('eip1967.proxy.implementation')) - 1).
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+	// eip1967AdminSlot is bytes32(uint256(keccak256('eip1967.proxy.admin')) - 1).
+	eip1967AdminSlot = common.HexToHash("0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+)
+
+// ProxyState is the eip1967 proxy state of a bridge contract at a block.
+type ProxyState struct {
+	Implementation         common.Address
+	Admin                  common.Address
+	CodeHash               common.Hash
+	ImplementationCodeHash common.Hash
+}
+
+type layerProxies struct {
+	client    *ethclient.Client
+	confirm   rpc.BlockNumber
+	contracts []config.ContractAddress
+	// the drift of each contract already alerted, to alert once per drift.
+	alerted map[string]string
+}
+
+// LogicProxyDrift checks the bridge proxies against their pinned baseline.
+type LogicProxyDrift struct {
+	contractBaselineOrm *orm.ContractBaseline
+	governanceEventOrm  *orm.GovernanceEvent
+
+	layers map[types.LayerType]*layerProxies
+}
+
+// NewLogicProxyDrift creates a new LogicProxyDrift instance.
+func NewLogicProxyDrift(cfg *config.Config, db *gorm.DB, l1Client, l2Client *ethclient.Client) *LogicProxyDrift {
+	return &LogicProxyDrift{
+		contractBaselineOrm: orm.NewContractBaseline(db),
+		governanceEventOrm:  orm.NewGovernanceEvent(db),
+		layers: map[types.LayerType]*layerProxies{
+			types.Layer1: {
+				client:    l1Client,
+				confirm:   cfg.L1Config.Confirm,
+				contracts: cfg.L1Config.L1Contracts.Addresses(),
+				alerted:   make(map[string]string),
+			},
+			types.Layer2: {
+				client:    l2Client,
+				confirm:   cfg.L2Config.Confirm,
+				contracts: cfg.L2Config.L2Contracts.Addresses(),
+				alerted:   make(map[string]string),
+			},
+		},
+	}
+}
+
+// SnapshotBaseline pins the current proxy state of every configured bridge contract of the layer as the baseline.
+func (p *LogicProxyDrift) SnapshotBaseline(ctx context.Context, layer types.LayerType) ([]orm.ContractBaseline, error) {
+	proxies, ok := p.layers[layer]
+	if !ok {
+		return nil, fmt.Errorf("invalid type, layerType: %v", layer)
+	}
+
+	blockNumber, err := utils.GetLatestConfirmedBlockNumber(ctx, proxies.client, proxies.confirm)
+	if err != nil {
+		return nil, fmt.Errorf("get latest confirmed block number failed, layer:%s, err:%w", layer.String(), err)
+	}
+
+	var baselines []orm.ContractBaseline
+	for _, contract := range proxies.contracts {
+		state, stateErr := GetProxyState(ctx, proxies.client, contract.Address, blockNumber)
+		if stateErr != nil {
+			return nil, fmt.Errorf("get proxy state failed, layer:%s, contract:%s, err:%w", layer.String(), contract.Name, stateErr)
+		}
+
+		baselines = append(baselines, orm.ContractBaseline{
+			Layer:                  int(layer),
+			Name:                   contract.Name,
+			ContractAddress:        contract.Address.Hex(),
+			Implementation:         state.Implementation.Hex(),
+			Admin:                  state.Admin.Hex(),
+			CodeHash:               state.CodeHash.Hex(),
+			ImplementationCodeHash: state.ImplementationCodeHash.Hex(),
+			BlockNumber:            blockNumber,
+		})
+	}
+
+	if _, err = p.contractBaselineOrm.InsertOrUpdateContractBaselines(ctx, baselines); err != nil {
+		return nil, fmt.Errorf("contract baseline orm insert failed, layer:%s, err:%w", layer.String(), err)
+	}
+	return baselines, nil
+}
+
+// CheckProxyDrift compares the current proxy state of the bridge contracts of the layer with the pinned baseline,
+// and alerts once for each drift.
+func (p *LogicProxyDrift) CheckProxyDrift(ctx context.Context, layer types.LayerType) {
+	proxies, ok := p.layers[layer]
+	if !ok {
+		log.Error("invalid layer type", "layer", layer)
+		return
+	}
+
+	baselines, err := p.contractBaselineOrm.GetContractBaselines(ctx, layer)
+	if err != nil {
+		log.Error("get contract baselines failed", "layer", layer, "error", err)
+		return
+	}
+
+	baselineMap := make(map[common.Address]orm.ContractBaseline, len(baselines))
+	for _, baseline := range baselines {
+		baselineMap[common.HexToAddress(baseline.ContractAddress)] = baseline
+	}
+
+	blockNumber, err := utils.GetLatestConfirmedBlockNumber(ctx, proxies.client, proxies.confirm)
+	if err != nil {
+		log.Error("get latest confirmed block number failed", "layer", layer, "error", err)
+		return
+	}
+
+	for _, contract := range proxies.contracts {
+		baseline, exist := baselineMap[contract.Address]
+		if !exist {
+			log.Warn("bridge contract has no pinned baseline, snapshot the baseline first", "layer", layer, "contract", contract.Name, "address", contract.Address)
+			continue
+		}
+
+		state, stateErr := GetProxyState(ctx, proxies.client, contract.Address, blockNumber)
+		if stateErr != nil {
+			log.Error("get proxy state failed", "layer", layer, "contract", contract.Name, "error", stateErr)
+			continue
+		}
+
+		fields, driftErr := p.driftFields(ctx, layer, baseline, state)
+		if driftErr != nil {
+			log.Error("check proxy drift failed", "layer", layer, "contract", contract.Name, "error", driftErr)
+			continue
+		}
+
+		if len(fields) == 0 {
+			delete(proxies.alerted, baseline.ContractAddress)
+			continue
+		}
+
+		drift := fmt.Sprintf("%v", fields)
+		if proxies.alerted[baseline.ContractAddress] == drift {
+			continue
+		}
+		proxies.alerted[baseline.ContractAddress] = drift
+
+		log.Warn("bridge proxy drift from baseline", "layer", layer, "contract", contract.Name, "address", contract.Address, "drift", drift)
+		slack.Notify(slack.MrkDwnProxyDriftMessage(slack.ProxyDriftInfo{
+			Layer:               layer,
+			Name:                contract.Name,
+			ContractAddress:     contract.Address,
+			BaselineBlockNumber: baseline.BlockNumber,
+			BlockNumber:         blockNumber,
+			Fields:              fields,
+		}))
+	}
+}
+
+func (p *LogicProxyDrift) driftFields(ctx context.Context, layer types.LayerType, baseline orm.ContractBaseline, state ProxyState) ([]slack.ProxyDriftField, error) {
+	var fields []slack.ProxyDriftField
+	if common.HexToAddress(baseline.Implementation) != state.Implementation {
+		txHash, err := p.announcedBy(ctx, layer, baseline, types.GovernanceEventTypeUpgraded, "implementation", state.Implementation)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, slack.ProxyDriftField{Field: "implementation", Baseline: baseline.Implementation, Current: state.Implementation.Hex(), GovernanceTxHash: txHash})
+	}
+
+	if common.HexToAddress(baseline.Admin) != state.Admin {
+		txHash, err := p.announcedBy(ctx, layer, baseline, types.GovernanceEventTypeAdminChanged, "newAdmin", state.Admin)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, slack.ProxyDriftField{Field: "admin", Baseline: baseline.Admin, Current: state.Admin.Hex(), GovernanceTxHash: txHash})
+	}
+
+	// No event announces a code change of the proxy itself.
+	if common.HexToHash(baseline.CodeHash) != state.CodeHash {
+		fields = append(fields, slack.ProxyDriftField{Field: "code hash", Baseline: baseline.CodeHash, Current: state.CodeHash.Hex()})
+	}
+
+	// The implementation code changing in place is never announced, a new implementation is announced by its upgrade.
+	if common.HexToHash(baseline.ImplementationCodeHash) != state.ImplementationCodeHash {
+		field := slack.ProxyDriftField{Field: "implementation code hash", Baseline: baseline.ImplementationCodeHash, Current: state.ImplementationCodeHash.Hex()}
+		if len(fields) > 0 && fields[0].Field == "implementation" {
+			field.GovernanceTxHash = fields[0].GovernanceTxHash
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// announcedBy returns the tx hash of the governance event raised after the baseline that set the argument to the current value.
+func (p *LogicProxyDrift) announcedBy(ctx context.Context, layer types.LayerType, baseline orm.ContractBaseline, eventType types.GovernanceEventType, argName string, current common.Address) (string, error) {
+	governanceEvents, err := p.governanceEventOrm.GetContractGovernanceEvents(ctx, layer, baseline.ContractAddress, eventType, baseline.BlockNumber)
+	if err != nil {
+		return "", err
+	}
+
+	for i := len(governanceEvents) - 1; i >= 0; i-- {
+		args := make(map[string]string)
+		if err = json.Unmarshal([]byte(governanceEvents[i].Args), &args); err != nil {
+			log.Warn("unmarshal governance event args failed", "tx hash", governanceEvents[i].TxHash, "error", err)
+			continue
+		}
+		if common.HexToAddress(args[argName]) == current {
+			return governanceEvents[i].TxHash, nil
+		}
+	}
+	return "", nil
+}
+
+// GetProxyState reads the eip1967 implementation and admin slots, and the code hash of the proxy and its implementation.
+func GetProxyState(ctx context.Context, client *ethclient.Client, address common.Address, blockNumber uint64) (ProxyState, error) {
+	number := new(big.Int).SetUint64(blockNumber)
+
+	implementation, err := client.StorageAt(ctx, address, eip1967ImplementationSlot, number)
+	if err != nil {
+		return ProxyState{}, fmt.Errorf("get implementation slot failed, err:%w", err)
+	}
+
+	admin, err := client.StorageAt(ctx, address, eip1967AdminSlot, number)
+	if err != nil {
+		return ProxyState{}, fmt.Errorf("get admin slot failed, err:%w", err)
+	}
+
+	code, err := client.CodeAt(ctx, address, number)
+	if err != nil {
+		return ProxyState{}, fmt.Errorf("get code failed, err:%w", err)
+	}
+
+	state := ProxyState{
+		Implementation: common.BytesToAddress(implementation),
+		Admin:          common.BytesToAddress(admin),
+		CodeHash:       crypto.Keccak256Hash(code),
+	}
+
+	if state.Implementation != (common.Address{}) {
+		implementationCode, codeErr := client.CodeAt(ctx, state.Implementation, number)
+		if codeErr != nil {
+			return ProxyState{}, fmt.Errorf("get implementation code failed, err:%w", codeErr)
+		}
+		state.ImplementationCodeHash = crypto.Keccak256Hash(implementationCode)
+	}
+	return state, nil
+}
@@ -0,0 +1,228 @@
+package invariant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils/expr"
+)
+
+type invariant struct {
+	conf       config.Invariant
+	layer      types.LayerType
+	method     abi.Method
+	args       []interface{}
+	expression *expr.Expression
+
+	// previous the first output of the last evaluation, nil before the first evaluation.
+	previous        interface{}
+	lastBlockNumber uint64
+	lastCheckTime   time.Time
+	// violated is set once the violation is alerted, and reset when the invariant holds again.
+	violated bool
+}
+
+// LogicInvariant evaluates the configured contract state invariants with eth_call, exports the results as metrics
+// and alerts on violations.
+type LogicInvariant struct {
+	l1Client   *ethclient.Client
+	l2Client   *ethclient.Client
+	invariants []*invariant
+
+	invariantValue             *prometheus.GaugeVec
+	invariantViolated          *prometheus.GaugeVec
+	invariantCheckFailureTotal *prometheus.CounterVec
+}
+
+// NewLogicInvariant parses the configured invariants and creates a new LogicInvariant instance.
+func NewLogicInvariant(cfg *config.Config, l1Client, l2Client *ethclient.Client) (*LogicInvariant, error) {
+	l := &LogicInvariant{
+		l1Client: l1Client,
+		l2Client: l2Client,
+		invariantValue: promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "invariant_value",
+			Help: "The first output of the last evaluation of the contract state invariant, bool outputs are 0 or 1.",
+		}, []string{"layer", "name"}),
+		invariantViolated: promauto.With(prometheus.DefaultRegisterer).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "invariant_violated",
+			Help: "Whether the contract state invariant is violated on the last evaluation.",
+		}, []string{"layer", "name"}),
+		invariantCheckFailureTotal: promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+			Name: "invariant_check_failure_total",
+			Help: "The total number of contract state invariant evaluation failure.",
+		}, []string{"layer", "name"}),
+	}
+
+	for _, conf := range cfg.Invariants {
+		inv := &invariant{conf: conf}
+		switch conf.Layer {
+		case "l1":
+			inv.layer = types.Layer1
+		case "l2":
+			inv.layer = types.Layer2
+		default:
+			return nil, fmt.Errorf("invariant %s has invalid layer %q", conf.Name, conf.Layer)
+		}
+
+		var err error
+		if inv.method, err = parseMethod(conf.Method); err != nil {
+			return nil, fmt.Errorf("invariant %s: %w", conf.Name, err)
+		}
+		if inv.args, err = convertArgs(inv.method.Inputs, conf.Args); err != nil {
+			return nil, fmt.Errorf("invariant %s: %w", conf.Name, err)
+		}
+		if inv.expression, err = expr.Parse(conf.Expression); err != nil {
+			return nil, fmt.Errorf("invariant %s: parse expression %q failed: %w", conf.Name, conf.Expression, err)
+		}
+		l.invariants = append(l.invariants, inv)
+	}
+	return l, nil
+}
+
+// Check evaluates the due invariants of the layer. The invariants without interval are evaluated on the
+// processedBlockNumber once it moves forward, the others on the latest block once their interval elapses.
+func (l *LogicInvariant) Check(ctx context.Context, layer types.LayerType, processedBlockNumber uint64) {
+	client := l.l1Client
+	if layer == types.Layer2 {
+		client = l.l2Client
+	}
+
+	var latestBlockNumber uint64
+	for _, inv := range l.invariants {
+		if inv.layer != layer {
+			continue
+		}
+
+		blockNumber := processedBlockNumber
+		if inv.conf.Interval == 0 {
+			if processedBlockNumber == 0 || processedBlockNumber <= inv.lastBlockNumber {
+				continue
+			}
+		} else {
+			if time.Since(inv.lastCheckTime) < time.Duration(inv.conf.Interval)*time.Second {
+				continue
+			}
+			if latestBlockNumber == 0 {
+				var err error
+				if latestBlockNumber, err = client.BlockNumber(ctx); err != nil {
+					log.Error("get latest block number failed", "layer", layer, "err", err)
+					return
+				}
+			}
+			blockNumber = latestBlockNumber
+		}
+
+		if err := l.evaluate(ctx, client, inv, blockNumber); err != nil {
+			l.invariantCheckFailureTotal.WithLabelValues(layer.String(), inv.conf.Name).Inc()
+			log.Error("evaluate invariant failed", "name", inv.conf.Name, "layer", layer, "block number", blockNumber, "err", err)
+			continue
+		}
+		inv.lastBlockNumber = blockNumber
+		inv.lastCheckTime = time.Now()
+	}
+}
+
+func (l *LogicInvariant) evaluate(ctx context.Context, client *ethclient.Client, inv *invariant, blockNumber uint64) error {
+	input, err := inv.method.Inputs.Pack(inv.args...)
+	if err != nil {
+		return fmt.Errorf("pack args failed: %w", err)
+	}
+	data, err := client.CallContract(ctx, ethereum.CallMsg{
+		To:   &inv.conf.Address,
+		Data: append(append([]byte{}, inv.method.ID...), input...),
+	}, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return fmt.Errorf("call %s failed: %w", inv.method.Sig, err)
+	}
+	outputs, err := inv.method.Outputs.Unpack(data)
+	if err != nil {
+		return fmt.Errorf("unpack %s outputs failed: %w", inv.method.Sig, err)
+	}
+
+	vars := map[string]interface{}{"block_number": blockNumber}
+	for i, output := range outputs {
+		value := outputValue(output)
+		vars[fmt.Sprintf("out%d", i)] = value
+		if name := inv.method.Outputs[i].Name; name != "" {
+			vars[name] = value
+		}
+	}
+	result := vars["out0"]
+	vars["result"] = result
+	if inv.previous != nil {
+		vars["previous"] = inv.previous
+	}
+
+	previous := inv.previous
+	inv.previous = result
+	l.setValue(inv, result)
+
+	holds, err := inv.expression.EvalBool(expr.Env{Vars: vars})
+	if errors.Is(err, expr.ErrUndefined) && previous == nil {
+		// the expression compares with the previous result, nothing to check on the first evaluation.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("eval expression %q failed: %w", inv.expression.String(), err)
+	}
+
+	if holds {
+		l.invariantViolated.WithLabelValues(inv.layer.String(), inv.conf.Name).Set(0)
+		if inv.violated {
+			log.Info("invariant holds again", "name", inv.conf.Name, "layer", inv.layer, "block number", blockNumber, "result", result)
+			inv.violated = false
+		}
+		return nil
+	}
+
+	l.invariantViolated.WithLabelValues(inv.layer.String(), inv.conf.Name).Set(1)
+	log.Warn("invariant violated", "name", inv.conf.Name, "layer", inv.layer, "block number", blockNumber, "result", result, "previous", previous)
+	if inv.violated {
+		return nil
+	}
+	inv.violated = true
+
+	info := slack.InvariantInfo{
+		Name:        inv.conf.Name,
+		Layer:       inv.layer,
+		Contract:    inv.conf.Address,
+		Method:      inv.conf.Method,
+		Expression:  inv.expression.String(),
+		BlockNumber: blockNumber,
+		Result:      fmt.Sprintf("%v", result),
+	}
+	if previous != nil {
+		info.Previous = fmt.Sprintf("%v", previous)
+	}
+	slack.Notify(slack.MrkDwnInvariantMessage(info))
+	return nil
+}
+
+func (l *LogicInvariant) setValue(inv *invariant, result interface{}) {
+	var value float64
+	switch v := result.(type) {
+	case decimal.Decimal:
+		value = v.InexactFloat64()
+	case bool:
+		if v {
+			value = 1
+		}
+	default:
+		return
+	}
+	l.invariantValue.WithLabelValues(inv.layer.String(), inv.conf.Name).Set(value)
+}
@@ -0,0 +1,171 @@
+package invariant
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+)
+
+// parseMethod parses a method signature with its outputs such as balanceOf(address)(uint256 balance), tuples
+// aren't supported.
+func parseMethod(signature string) (abi.Method, error) {
+	signature = strings.TrimSpace(signature)
+	open := strings.Index(signature, "(")
+	if open <= 0 {
+		return abi.Method{}, fmt.Errorf("invalid method signature %q", signature)
+	}
+	name := signature[:open]
+
+	inputList, rest, err := splitParen(signature[open:])
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("invalid method signature %q: %w", signature, err)
+	}
+	var outputList string
+	if rest = strings.TrimSpace(rest); rest != "" {
+		outputList, rest, err = splitParen(rest)
+		if err != nil {
+			return abi.Method{}, fmt.Errorf("invalid method signature %q: %w", signature, err)
+		}
+		if strings.TrimSpace(rest) != "" {
+			return abi.Method{}, fmt.Errorf("invalid method signature %q: unexpected %q", signature, rest)
+		}
+	}
+
+	inputs, err := parseArguments(inputList)
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("invalid method inputs %q: %w", signature, err)
+	}
+	outputs, err := parseArguments(outputList)
+	if err != nil {
+		return abi.Method{}, fmt.Errorf("invalid method outputs %q: %w", signature, err)
+	}
+	if len(outputs) == 0 {
+		return abi.Method{}, fmt.Errorf("method %q has no output", signature)
+	}
+	return abi.NewMethod(name, name, abi.Function, "view", false, false, inputs, outputs), nil
+}
+
+// splitParen returns the content of the leading parentheses and the rest of s.
+func splitParen(s string) (string, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return "", "", fmt.Errorf("expected ( in %q", s)
+	}
+	end := strings.Index(s, ")")
+	if end < 0 {
+		return "", "", fmt.Errorf("unclosed ( in %q", s)
+	}
+	if strings.Contains(s[1:end], "(") {
+		return "", "", fmt.Errorf("tuple isn't supported in %q", s)
+	}
+	return s[1:end], s[end+1:], nil
+}
+
+func parseArguments(list string) (abi.Arguments, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+	var arguments abi.Arguments
+	for _, item := range strings.Split(list, ",") {
+		fields := strings.Fields(item)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, fmt.Errorf("invalid argument %q", item)
+		}
+		typ, err := abi.NewType(fields[0], "", nil)
+		if err != nil {
+			return nil, err
+		}
+		argument := abi.Argument{Type: typ}
+		if len(fields) == 2 {
+			argument.Name = fields[1]
+		}
+		arguments = append(arguments, argument)
+	}
+	return arguments, nil
+}
+
+// convertArgs converts the configured string args into the go values packed for the method inputs.
+func convertArgs(inputs abi.Arguments, args []string) ([]interface{}, error) {
+	if len(inputs) != len(args) {
+		return nil, fmt.Errorf("method takes %d args, got %d", len(inputs), len(args))
+	}
+	values := make([]interface{}, 0, len(args))
+	for i, input := range inputs {
+		value, err := convertArg(input.Type, args[i])
+		if err != nil {
+			return nil, fmt.Errorf("arg %d %q: %w", i, args[i], err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func convertArg(typ abi.Type, arg string) (interface{}, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(arg) {
+			return nil, fmt.Errorf("invalid address")
+		}
+		return common.HexToAddress(arg), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+	case abi.StringTy:
+		return arg, nil
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(b))
+		}
+		value := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(b))
+		return value.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(arg, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer")
+		}
+		if typ.Size > 64 {
+			return n, nil
+		}
+		if (typ.T == abi.UintTy && (n.Sign() < 0 || n.BitLen() > typ.Size)) || (typ.T == abi.IntTy && n.BitLen() >= typ.Size) {
+			return nil, fmt.Errorf("integer overflows %s", typ.String())
+		}
+		if typ.T == abi.UintTy {
+			return reflect.ValueOf(n.Uint64()).Convert(typ.GetType()).Interface(), nil
+		}
+		return reflect.ValueOf(n.Int64()).Convert(typ.GetType()).Interface(), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ.String())
+}
+
+// outputValue converts an unpacked output into an expression value.
+func outputValue(v interface{}) interface{} {
+	switch x := v.(type) {
+	case *big.Int:
+		return decimal.NewFromBigInt(x, 0)
+	case uint8, uint16, uint32, uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(reflect.ValueOf(x).Uint()), 0)
+	case int8, int16, int32, int64:
+		return decimal.NewFromInt(reflect.ValueOf(x).Int())
+	case bool, string:
+		return x
+	case common.Address:
+		return x.Hex()
+	case [32]byte:
+		return common.Hash(x).Hex()
+	case []byte:
+		return hexutil.Encode(x)
+	}
+	return fmt.Sprintf("%v", v)
+}
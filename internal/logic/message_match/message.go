@@ -6,31 +6,53 @@ import (
 	"fmt"
 
 	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 
 	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
 	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
 	"github.com/scroll-tech/chain-monitor/internal/orm"
 	"github.com/scroll-tech/chain-monitor/internal/types"
 	"github.com/scroll-tech/chain-monitor/internal/utils"
 )
 
+const defaultFailedRelayAlertTimes = 3
+
+// defaultDropAlertETHAmount is 1 ether.
+var defaultDropAlertETHAmount = decimal.New(1, 18)
+
 // LogicMessageMatch defines the logic related to message matching.
 type LogicMessageMatch struct {
 	db                       *gorm.DB
 	conf                     *config.Config
 	gatewayMessageMatchOrm   *orm.GatewayMessageMatch
 	messengerMessageMatchOrm *orm.MessengerMessageMatch
+
+	failedRelayAlertTimes int
+	dropAlertETHAmount    decimal.Decimal
 }
 
 // NewMessageMatchLogic initializes a new instance of Logic with an instance of orm.GatewayMessageMatch/orm.MessengerMessageMatch
 func NewMessageMatchLogic(cfg *config.Config, db *gorm.DB) *LogicMessageMatch {
-	return &LogicMessageMatch{
+	l := &LogicMessageMatch{
 		db:                       db,
 		conf:                     cfg,
 		gatewayMessageMatchOrm:   orm.NewGatewayMessageMatch(db),
 		messengerMessageMatchOrm: orm.NewMessengerMessageMatch(db),
+		failedRelayAlertTimes:    defaultFailedRelayAlertTimes,
+		dropAlertETHAmount:       defaultDropAlertETHAmount,
 	}
+
+	if cfg.MessengerAlertConfig != nil {
+		if cfg.MessengerAlertConfig.FailedRelayAlertTimes > 0 {
+			l.failedRelayAlertTimes = cfg.MessengerAlertConfig.FailedRelayAlertTimes
+		}
+		if cfg.MessengerAlertConfig.DropAlertETHAmount.IsPositive() {
+			l.dropAlertETHAmount = cfg.MessengerAlertConfig.DropAlertETHAmount
+		}
+	}
+	return l
 }
 
 // GetBlocksStatus get the status from start block number to end block number
@@ -190,3 +212,103 @@ func (t *LogicMessageMatch) InsertOrUpdateMessageMatches(ctx context.Context, la
 	}
 	return nil
 }
+
+// InsertOrUpdateMessengerFailures records the failed relays, replays and drops of messenger events against their message
+// match rows. It returns the failures to alert once the range is stored, the messages failed to relay repeatedly and the
+// high-value messages dropped, a rescanned failure event isn't alerted again.
+func (t *LogicMessageMatch) InsertOrUpdateMessengerFailures(ctx context.Context, messengerEvents []events.EventUnmarshaler, dbTX *gorm.DB) ([]slack.MessengerFailureInfo, error) {
+	var failures []slack.MessengerFailureInfo
+	for _, eventData := range messengerEvents {
+		messengerEvent, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok {
+			return nil, fmt.Errorf("eventData is not of type *events.MessengerEventUnmarshaler")
+		}
+
+		if messengerEvent.Type != types.L1FailedRelayedMessage &&
+			messengerEvent.Type != types.L2FailedRelayedMessage &&
+			messengerEvent.Type != types.L1ReplayMessage &&
+			messengerEvent.Type != types.L1DropMessage {
+			continue
+		}
+
+		message := orm.MessengerMessageMatch{MessageHash: messengerEvent.MessageHash.Hex()}
+		if messengerEvent.Type == types.L1DropMessage {
+			message.L1DropBlockNumber = messengerEvent.Number
+			message.L1DropTxHash = messengerEvent.TxHash.Hex()
+		}
+		failureEvent := orm.MessengerFailureEvent{
+			BlockNumber: messengerEvent.Number,
+			TxHash:      messengerEvent.TxHash.Hex(),
+			LogIndex:    messengerEvent.Index,
+		}
+
+		messageMatch, recorded, err := t.messengerMessageMatchOrm.InsertOrUpdateMessageFailure(ctx, messengerEvent.Type, message, failureEvent, dbTX)
+		if err != nil {
+			return nil, fmt.Errorf("messenger failure orm insert failed, err: %w, event type:%s", err, messengerEvent.Type.String())
+		}
+		if !recorded {
+			continue
+		}
+
+		info := slack.MessengerFailureInfo{
+			Layer:        messengerEvent.Layer,
+			EventType:    messengerEvent.Type,
+			BlockNumber:  messengerEvent.Number,
+			TxHash:       messengerEvent.TxHash,
+			MessageHash:  messengerEvent.MessageHash,
+			MessageNonce: messengerEvent.MessageNonce,
+			Value:        messengerEvent.Value,
+		}
+
+		switch messengerEvent.Type {
+		case types.L1FailedRelayedMessage:
+			info.FailedTimes = messageMatch.L1FailedRelayCount
+		case types.L2FailedRelayedMessage:
+			info.FailedTimes = messageMatch.L2FailedRelayCount
+		case types.L1DropMessage:
+			if decimal.NewFromBigInt(messengerEvent.Value, 0).GreaterThanOrEqual(t.dropAlertETHAmount) {
+				failures = append(failures, info)
+			}
+			continue
+		default:
+			continue
+		}
+
+		if info.FailedTimes >= t.failedRelayAlertTimes {
+			failures = append(failures, info)
+		}
+	}
+	return failures, nil
+}
+
+// NotifyMessengerFailures alerts the messenger failures of a stored range, and notifies the relay of an alerted message
+// as the resolution of the alert.
+func (t *LogicMessageMatch) NotifyMessengerFailures(failures []slack.MessengerFailureInfo, messengerEvents []events.EventUnmarshaler) {
+	for _, info := range failures {
+		if info.EventType == types.L1DropMessage {
+			log.Error("high value message dropped", "message hash", info.MessageHash, "value", info.Value, "tx hash", info.TxHash)
+			slack.Notify(slack.MrkDwnMessengerDropMessage(info))
+			continue
+		}
+		log.Error("message relay failed repeatedly", "layer", info.Layer, "message hash", info.MessageHash, "failed times", info.FailedTimes)
+		slack.Notify(slack.MrkDwnMessengerFailedRelayMessage(info))
+	}
+
+	for _, eventData := range messengerEvents {
+		messengerEvent, ok := eventData.(*events.MessengerEventUnmarshaler)
+		if !ok || (messengerEvent.Type != types.L1RelayedMessage && messengerEvent.Type != types.L2RelayedMessage) {
+			continue
+		}
+		// the message alerted for failing to relay or being dropped is finally relayed, the follow-up resolves the
+		// alert thread. The other alerts of the message hash aren't resolved by the relay.
+		if slack.RelayFailureAlerted(messengerEvent.MessageHash.Hex()) {
+			slack.Notify(slack.MrkDwnMessengerRelayResolvedMessage(slack.MessengerFailureInfo{
+				Layer:       messengerEvent.Layer,
+				EventType:   messengerEvent.Type,
+				BlockNumber: messengerEvent.Number,
+				TxHash:      messengerEvent.TxHash,
+				MessageHash: messengerEvent.MessageHash,
+			}))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package report
+
+import (
+	"bytes"
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+)
+
+//go:embed templates/*.tmpl
+var templates embed.FS
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.ParseFS(templates, "templates/digest.txt.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/digest.html.tmpl"))
+)
+
+// Subject returns the mail subject of the digest.
+func (r *Report) Subject() string {
+	return fmt.Sprintf("Scroll bridge %s health digest %s", r.Period, r.EndTime.Format("2006-01-02"))
+}
+
+// Render renders the digest as plain text and html.
+func (r *Report) Render() (text string, html string, err error) {
+	var textBuf, htmlBuf bytes.Buffer
+	if err = textTemplate.Execute(&textBuf, r); err != nil {
+		return "", "", fmt.Errorf("render digest text failed err:%w", err)
+	}
+	if err = htmlTemplate.Execute(&htmlBuf, r); err != nil {
+		return "", "", fmt.Errorf("render digest html failed err:%w", err)
+	}
+	return textBuf.String(), htmlBuf.String(), nil
+}
+
+// Send renders the digest and mails it as a multipart/alternative message through the smtp server.
+func Send(cfg *config.SMTPConfig, report *Report) error {
+	text, html, err := report.Render()
+	if err != nil {
+		return err
+	}
+	mail, err := buildMail(cfg, report.Subject(), text, html, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port))
+	if sendErr := smtp.SendMail(addr, auth, cfg.From, cfg.To, mail); sendErr != nil {
+		return fmt.Errorf("send digest mail failed err:%w", sendErr)
+	}
+	return nil
+}
+
+func buildMail(cfg *config.SMTPConfig, subject, text, html string, date time.Time) ([]byte, error) {
+	var nonce [12]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+	boundary := "chain-monitor-" + hex.EncodeToString(nonce[:])
+
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("From: %s\r\n", cfg.From))
+	buf.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(cfg.To, ", ")))
+	buf.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
+	buf.WriteString(fmt.Sprintf("Date: %s\r\n", date.Format(time.RFC1123Z)))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary))
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain", text},
+		{"text/html", html},
+	} {
+		buf.WriteString(fmt.Sprintf("--%s\r\n", boundary))
+		buf.WriteString(fmt.Sprintf("Content-Type: %s; charset=UTF-8\r\n", part.contentType))
+		buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+		w := quotedprintable.NewWriter(&buf)
+		if _, err := w.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString(fmt.Sprintf("--%s--\r\n", boundary))
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,263 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/token"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+const (
+	// stuckMessageLimit the maximum number of the oldest stuck messages listed in a digest.
+	stuckMessageLimit = 20
+	// defaultStuckMessageAfter an l1 message not relayed on l2 for an hour is stuck if not configured.
+	defaultStuckMessageAfter = time.Hour
+)
+
+// Period the period of a digest.
+type Period string
+
+const (
+	// Daily the digest of the last day.
+	Daily Period = "daily"
+	// Weekly the digest of the last week.
+	Weekly Period = "weekly"
+)
+
+// Duration returns the duration of the period.
+func (p Period) Duration() time.Duration {
+	if p == Weekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// TokenCount the number of the gateway messages of a token.
+type TokenCount struct {
+	TokenType string
+	Token     string
+	Count     int64
+}
+
+// MismatchCount the number of the cross chain mismatches of a type.
+type MismatchCount struct {
+	// Source the match table, gateway or messenger.
+	Source string
+	Type   string
+	Count  int64
+}
+
+// LayerReport the messages processed and the mismatches found on a layer, and the uptime of its watcher.
+type LayerReport struct {
+	Layer             string
+	GatewayMessages   []TokenCount
+	MessengerMessages int64
+	Mismatches        []MismatchCount
+	// Uptime the uptime percentage of the watcher, empty if the watcher wasn't sampled within the period.
+	Uptime      string
+	BlockNumber uint64
+}
+
+// StuckMessage an l1 message not relayed on l2.
+type StuckMessage struct {
+	MessageHash   string
+	L1BlockNumber uint64
+	L1TxHash      string
+	Age           string
+}
+
+// ReserveResult the reserve reconciliations of a token within the period and the latest one.
+type ReserveResult struct {
+	Name            string
+	L1Token         string
+	Snapshots       int64
+	Imbalanced      int64
+	LatestImbalance string
+	LatestStatus    string
+}
+
+// Report the bridge health digest of a period.
+type Report struct {
+	Period        Period
+	StartTime     time.Time
+	EndTime       time.Time
+	Layers        []LayerReport
+	StuckAfter    time.Duration
+	StuckCount    int64
+	StuckMessages []StuckMessage
+	Reserves      []ReserveResult
+	// UptimeSince the time the watcher uptime is sampled since, if it's within the period.
+	UptimeSince string
+}
+
+// LogicReport generates the bridge health digests from the match tables and the watcher metrics.
+type LogicReport struct {
+	gatewayMessageMatchOrm   *orm.GatewayMessageMatch
+	messengerMessageMatchOrm *orm.MessengerMessageMatch
+	reserveSnapshotOrm       *orm.ReserveSnapshot
+	uptime                   *UptimeTracker
+	smtp                     *config.SMTPConfig
+	stuckAfter               time.Duration
+}
+
+// NewLogicReport creates a new LogicReport instance.
+func NewLogicReport(cfg *config.DigestConfig, db *gorm.DB, uptime *UptimeTracker) (*LogicReport, error) {
+	if cfg.SMTP == nil || cfg.SMTP.Host == "" || cfg.SMTP.Port == 0 || cfg.SMTP.From == "" || len(cfg.SMTP.To) == 0 {
+		return nil, fmt.Errorf("digest smtp config has no host, port, sender or recipients")
+	}
+	if cfg.SendHour < 0 || cfg.SendHour > 23 {
+		return nil, fmt.Errorf("invalid digest send hour: %d", cfg.SendHour)
+	}
+
+	stuckAfter := time.Duration(cfg.StuckMessageAfter) * time.Second
+	if stuckAfter == 0 {
+		stuckAfter = defaultStuckMessageAfter
+	}
+	return &LogicReport{
+		gatewayMessageMatchOrm:   orm.NewGatewayMessageMatch(db),
+		messengerMessageMatchOrm: orm.NewMessengerMessageMatch(db),
+		reserveSnapshotOrm:       orm.NewReserveSnapshot(db),
+		uptime:                   uptime,
+		smtp:                     cfg.SMTP,
+		stuckAfter:               stuckAfter,
+	}, nil
+}
+
+// SendDigest generates the digest of the period ending at endTime and mails it.
+func (l *LogicReport) SendDigest(ctx context.Context, period Period, endTime time.Time) error {
+	report, err := l.Generate(ctx, period, endTime)
+	if err != nil {
+		return err
+	}
+	return Send(l.smtp, report)
+}
+
+// Generate generates the digest of the period ending at endTime.
+func (l *LogicReport) Generate(ctx context.Context, period Period, endTime time.Time) (*Report, error) {
+	report := &Report{
+		Period:     period,
+		StartTime:  endTime.Add(-period.Duration()),
+		EndTime:    endTime,
+		StuckAfter: l.stuckAfter,
+	}
+	sampledSince, err := l.uptime.SampledSince(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sampledSince.After(report.StartTime) {
+		report.UptimeSince = sampledSince.Format(time.RFC3339)
+	}
+
+	for _, layer := range []types.LayerType{types.Layer1, types.Layer2} {
+		layerReport, err := l.layerReport(ctx, layer, report.StartTime, report.EndTime)
+		if err != nil {
+			return nil, err
+		}
+		report.Layers = append(report.Layers, *layerReport)
+	}
+
+	stuck, stuckCount, err := l.messengerMessageMatchOrm.GetStuckL1SentMessages(ctx, endTime.Add(-l.stuckAfter), stuckMessageLimit)
+	if err != nil {
+		return nil, err
+	}
+	report.StuckCount = stuckCount
+	for _, message := range stuck {
+		report.StuckMessages = append(report.StuckMessages, StuckMessage{
+			MessageHash:   message.MessageHash,
+			L1BlockNumber: message.L1BlockNumber,
+			L1TxHash:      message.L1TxHash,
+			Age:           endTime.Sub(message.L1BlockStatusUpdatedAt).Truncate(time.Minute).String(),
+		})
+	}
+
+	summaries, err := l.reserveSnapshotOrm.GetReserveSummaries(ctx, report.StartTime, report.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range summaries {
+		result := ReserveResult{
+			Name:       summary.Name,
+			L1Token:    summary.L1Token,
+			Snapshots:  summary.Snapshots,
+			Imbalanced: summary.Imbalanced,
+		}
+		latest, latestErr := l.reserveSnapshotOrm.GetLatestReserveSnapshot(ctx, summary.L1Token)
+		if latestErr != nil {
+			return nil, latestErr
+		}
+		if latest != nil {
+			result.LatestImbalance = token.FormatDecimal(types.Layer1, common.HexToAddress(latest.L1Token), latest.Imbalance)
+			result.LatestStatus = reserveStatus(latest.ReserveStatus)
+		}
+		report.Reserves = append(report.Reserves, result)
+	}
+	return report, nil
+}
+
+func (l *LogicReport) layerReport(ctx context.Context, layer types.LayerType, startTime, endTime time.Time) (*LayerReport, error) {
+	layerReport := &LayerReport{Layer: layer.String()}
+
+	tokenCounts, err := l.gatewayMessageMatchOrm.GetProcessedMessageCounts(ctx, layer, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, tokenCount := range tokenCounts {
+		layerReport.GatewayMessages = append(layerReport.GatewayMessages, TokenCount{
+			TokenType: types.TokenType(tokenCount.TokenType).String(),
+			Token:     tokenName(tokenCount.Token),
+			Count:     tokenCount.Count,
+		})
+	}
+
+	layerReport.MessengerMessages, err = l.messengerMessageMatchOrm.GetProcessedMessageCount(ctx, layer, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	gatewayMismatches, err := l.gatewayMessageMatchOrm.GetMismatchCounts(ctx, layer, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	messengerMismatches, err := l.messengerMessageMatchOrm.GetMismatchCounts(ctx, layer, startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	for _, mismatch := range gatewayMismatches {
+		layerReport.Mismatches = append(layerReport.Mismatches, MismatchCount{Source: "gateway", Type: types.MismatchType(mismatch.MismatchType).String(), Count: mismatch.Count})
+	}
+	for _, mismatch := range messengerMismatches {
+		layerReport.Mismatches = append(layerReport.Mismatches, MismatchCount{Source: "messenger", Type: types.MismatchType(mismatch.MismatchType).String(), Count: mismatch.Count})
+	}
+
+	ratio, blockNumber, ok, err := l.uptime.Uptime(ctx, layer.String(), startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		layerReport.Uptime = fmt.Sprintf("%.2f%%", ratio*100)
+		layerReport.BlockNumber = blockNumber
+	}
+	return layerReport, nil
+}
+
+// tokenName returns the symbol of the l1 token if its metadata is cached, or the token address.
+func tokenName(address string) string {
+	if metadata, exists := token.Lookup(types.Layer1, common.HexToAddress(address)); exists && metadata.Symbol != "" {
+		return metadata.Symbol
+	}
+	return address
+}
+
+func reserveStatus(status int) string {
+	if types.ReserveStatus(status) == types.ReserveStatusTypeValid {
+		return "balanced"
+	}
+	return "imbalanced"
+}
@@ -0,0 +1,206 @@
+package report
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+)
+
+// smtpServer a local smtp stand-in accepting a single mail.
+type smtpServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     chan string
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpServer{listener: listener, data: make(chan string, 1)}
+	go s.serve()
+	return s
+}
+
+func (s *smtpServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			s.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			s.to = append(s.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			s.data <- data.String()
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func newTestReport() *Report {
+	endTime := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	return &Report{
+		Period:    Daily,
+		StartTime: endTime.Add(-Daily.Duration()),
+		EndTime:   endTime,
+		Layers: []LayerReport{
+			{
+				Layer:             "Layer1",
+				GatewayMessages:   []TokenCount{{TokenType: "ETH", Token: "ETH", Count: 12}, {TokenType: "ERC20", Token: "USDC", Count: 3}},
+				MessengerMessages: 15,
+				Mismatches:        []MismatchCount{{Source: "gateway", Type: "MismatchTypeAmount", Count: 1}},
+				Uptime:            "99.93%",
+				BlockNumber:       100,
+			},
+			{Layer: "Layer2", MessengerMessages: 4},
+		},
+		StuckAfter:    time.Hour,
+		StuckCount:    1,
+		StuckMessages: []StuckMessage{{MessageHash: "0x01", L1BlockNumber: 90, L1TxHash: "0x02", Age: "2h0m0s"}},
+		Reserves:      []ReserveResult{{Name: "usdc", L1Token: "0x03", Snapshots: 288, Imbalanced: 2, LatestImbalance: "0 USDC", LatestStatus: "balanced"}},
+	}
+}
+
+func TestReportRender(t *testing.T) {
+	text, html, err := newTestReport().Render()
+	require.NoError(t, err)
+
+	assert.Contains(t, text, "Watcher uptime: 99.93% (block 100)")
+	assert.Contains(t, text, "  - ERC20 USDC: 3\n")
+	assert.Contains(t, text, "  - gateway MismatchTypeAmount: 1\n")
+	assert.Contains(t, text, "Watcher uptime: N/A\n")
+	assert.Contains(t, text, "L1 messages not relayed on L2 after 1h0m0s: 1\n  - 0x01 (l1 block 90, tx 0x02, age 2h0m0s)")
+	assert.Contains(t, text, "  - usdc 0x03: 2/288 snapshots imbalanced, latest balanced (0 USDC)")
+	assert.Contains(t, html, "<tr><td>ERC20</td><td>USDC</td><td>3</td></tr>")
+	assert.Contains(t, html, "<tr><td colspan=\"3\">no gateway messages</td></tr>")
+}
+
+func TestSendDigest(t *testing.T) {
+	server := newSMTPServer(t)
+	defer server.listener.Close()
+
+	port := server.listener.Addr().(*net.TCPAddr).Port
+	cfg := &config.SMTPConfig{Host: "127.0.0.1", Port: port, From: "monitor@example.org", To: []string{"ops@example.org", "mgmt@example.org"}}
+	require.NoError(t, Send(cfg, newTestReport()))
+
+	assert.Equal(t, "monitor@example.org", server.from)
+	assert.Equal(t, []string{"ops@example.org", "mgmt@example.org"}, server.to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(<-server.data))
+	require.NoError(t, err)
+	assert.Equal(t, "Scroll bridge daily health digest 2026-10-19", msg.Header.Get("Subject"))
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var contentTypes []string
+	for {
+		part, partErr := reader.NextPart()
+		if partErr == io.EOF {
+			break
+		}
+		require.NoError(t, partErr)
+		body, readErr := io.ReadAll(part)
+		require.NoError(t, readErr)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		assert.Contains(t, string(body), "Scroll bridge daily health digest")
+	}
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, contentTypes)
+}
+
+func TestWatcherUptime(t *testing.T) {
+	startTime := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	watcher := &watcherUptime{lastAdvanced: startTime}
+	var up []bool
+	for i := 0; i < 10; i++ {
+		// the watcher stalls after the 2nd minute.
+		blockNumber := uint64(i)
+		if i > 2 {
+			blockNumber = 2
+		}
+		up = append(up, watcher.advance(blockNumber, startTime.Add(time.Duration(i)*time.Minute)))
+	}
+	assert.Equal(t, []bool{true, true, true, true, true, true, true, false, false, false}, up)
+	assert.Equal(t, uint64(2), watcher.blockNumber)
+
+	// the state restored from the latest sample after a restart keeps the stall.
+	restored := &watcherUptime{blockNumber: watcher.blockNumber, lastAdvanced: watcher.lastAdvanced}
+	assert.False(t, restored.advance(2, startTime.Add(time.Hour)))
+	assert.True(t, restored.advance(3, startTime.Add(time.Hour)))
+}
+
+func TestUptimeRatio(t *testing.T) {
+	startTime := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		up      int64
+		endTime time.Time
+		ratio   float64
+		ok      bool
+	}{
+		{name: "allUp", up: 60, endTime: startTime.Add(time.Hour), ratio: 1, ok: true},
+		{name: "extraSample", up: 61, endTime: startTime.Add(time.Hour), ratio: 1, ok: true},
+		// the monitor was down for the second half hour, its missing samples count as down.
+		{name: "monitorDown", up: 30, endTime: startTime.Add(time.Hour), ratio: 0.5, ok: true},
+		{name: "neverUp", up: 0, endTime: startTime.Add(time.Hour), ratio: 0, ok: true},
+		{name: "noSampleExpected", up: 0, endTime: startTime.Add(30 * time.Second), ok: false},
+		{name: "emptyPeriod", up: 0, endTime: startTime, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratio, ok := uptimeRatio(tt.up, startTime, tt.endTime, time.Minute)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.ratio, ratio)
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+)
+
+const (
+	// blockNumberGauge the gauge of the block number processed by the contract controller of each layer.
+	blockNumberGauge = "contract_controller_block_number"
+	// watcherStallTimeout a watcher whose processed block number doesn't advance for this long is down.
+	watcherStallTimeout = 5 * time.Minute
+	// uptimeRetention the samples are kept for the longest digest period.
+	uptimeRetention = 8 * 24 * time.Hour
+)
+
+type watcherUptime struct {
+	blockNumber  uint64
+	lastAdvanced time.Time
+}
+
+// advance records the block number sampled at now, and returns whether the watcher is up.
+func (w *watcherUptime) advance(blockNumber uint64, now time.Time) bool {
+	if blockNumber > w.blockNumber {
+		w.blockNumber = blockNumber
+		w.lastAdvanced = now
+	}
+	return now.Sub(w.lastAdvanced) < watcherStallTimeout
+}
+
+// UptimeTracker samples the processed block numbers of the watchers from the metrics and stores the samples, a
+// watcher is up at a sample if its block number advanced within the stall timeout. The samples missing while the
+// monitor was down count as down.
+type UptimeTracker struct {
+	gatherer       prometheus.Gatherer
+	interval       time.Duration
+	uptimeSampleDB *orm.WatcherUptimeSample
+
+	mu       sync.Mutex
+	watchers map[string]*watcherUptime
+}
+
+// NewUptimeTracker creates the watcher uptime tracker sampling the metrics of the gatherer at the interval.
+func NewUptimeTracker(gatherer prometheus.Gatherer, db *gorm.DB, interval time.Duration) *UptimeTracker {
+	return &UptimeTracker{
+		gatherer:       gatherer,
+		interval:       interval,
+		uptimeSampleDB: orm.NewWatcherUptimeSample(db),
+		watchers:       make(map[string]*watcherUptime),
+	}
+}
+
+// Sample samples the processed block number of every watcher and stores the samples.
+func (u *UptimeTracker) Sample(ctx context.Context, now time.Time) {
+	families, err := u.gatherer.Gather()
+	if err != nil {
+		log.Warn("gather watcher block number metrics failed", "error", err)
+		return
+	}
+
+	blockNumbers := make(map[string]uint64)
+	for _, family := range families {
+		if family.GetName() != blockNumberGauge {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "layer" {
+					blockNumbers[label.GetValue()] = uint64(metric.GetGauge().GetValue())
+				}
+			}
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	var samples []orm.WatcherUptimeSample
+	for layer, blockNumber := range blockNumbers {
+		watcher, err := u.watcher(ctx, layer, now)
+		if err != nil {
+			log.Warn("get the latest watcher uptime sample failed", "layer", layer, "error", err)
+			continue
+		}
+		up := watcher.advance(blockNumber, now)
+		samples = append(samples, orm.WatcherUptimeSample{
+			Layer:          layer,
+			BlockNumber:    watcher.blockNumber,
+			LastAdvancedAt: watcher.lastAdvanced,
+			Up:             up,
+			SampledAt:      now,
+		})
+	}
+	if err := u.uptimeSampleDB.InsertWatcherUptimeSamples(ctx, samples); err != nil {
+		log.Warn("insert watcher uptime samples failed", "error", err)
+	}
+	if err := u.uptimeSampleDB.DeleteWatcherUptimeSamples(ctx, now.Add(-uptimeRetention)); err != nil {
+		log.Warn("delete expired watcher uptime samples failed", "error", err)
+	}
+}
+
+// watcher returns the sampling state of the layer, restored from its latest stored sample after a restart so a
+// watcher stalled across restarts stays down. The caller holds the lock.
+func (u *UptimeTracker) watcher(ctx context.Context, layer string, now time.Time) (*watcherUptime, error) {
+	if watcher, exists := u.watchers[layer]; exists {
+		return watcher, nil
+	}
+	latest, err := u.uptimeSampleDB.GetLatestWatcherUptimeSample(ctx, layer)
+	if err != nil {
+		return nil, err
+	}
+	watcher := &watcherUptime{lastAdvanced: now}
+	if latest != nil {
+		watcher.blockNumber = latest.BlockNumber
+		watcher.lastAdvanced = latest.LastAdvancedAt
+	}
+	u.watchers[layer] = watcher
+	return watcher, nil
+}
+
+// Uptime returns the ratio of the sampling intervals of the watcher between startTime and endTime it was up at, the
+// intervals before the first stored sample aren't counted.
+func (u *UptimeTracker) Uptime(ctx context.Context, layer string, startTime, endTime time.Time) (ratio float64, blockNumber uint64, ok bool, err error) {
+	firstSampledAt, err := u.uptimeSampleDB.GetFirstSampledAt(ctx, layer)
+	if err != nil || firstSampledAt.IsZero() {
+		return 0, 0, false, err
+	}
+	if firstSampledAt.After(startTime) {
+		startTime = firstSampledAt
+	}
+	uptime, err := u.uptimeSampleDB.GetWatcherUptime(ctx, layer, startTime, endTime)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	ratio, ok = uptimeRatio(uptime.Up, startTime, endTime, u.interval)
+	return ratio, uptime.BlockNumber, ok, nil
+}
+
+// uptimeRatio returns the ratio of the up samples to the samples expected between startTime and endTime, the
+// samples missing because the monitor was down count as down. It returns false if no sample is expected.
+func uptimeRatio(up int64, startTime, endTime time.Time, interval time.Duration) (float64, bool) {
+	if !startTime.Before(endTime) {
+		return 0, false
+	}
+	expected := int64(endTime.Sub(startTime) / interval)
+	// the ticker drifts, a period may hold a sample more than expected.
+	if expected < up {
+		expected = up
+	}
+	if expected == 0 {
+		return 0, false
+	}
+	return float64(up) / float64(expected), true
+}
+
+// SampledSince returns the time of the first stored sample, the zero time if the watchers were never sampled.
+func (u *UptimeTracker) SampledSince(ctx context.Context) (time.Time, error) {
+	return u.uptimeSampleDB.GetFirstSampledAt(ctx, "")
+}
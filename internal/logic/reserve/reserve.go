@@ -0,0 +1,381 @@
+package reserve
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/scroll-tech/go-ethereum/rpc"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/il1erc20gateway"
+	"github.com/scroll-tech/chain-monitor/internal/logic/contracts/abi/iscrollerc20"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	tokenmetadata "github.com/scroll-tech/chain-monitor/internal/logic/token"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+// erc20GatewayNames the l1 gateways escrowing the erc20 tokens, the standard gateway computes an l2 address for any
+// token so it is tried last. The weth gateway unwraps the deposits into the messenger and escrows nothing.
+var erc20GatewayNames = []string{"custom_erc20_gateway", "dai_gateway", "usdc_gateway", "lido_gateway", "puffer_gateway", "standard_erc20_gateway"}
+
+// tokenGateway the l1 gateway escrowing a token and the l2 token the gateway maps it to.
+type tokenGateway struct {
+	l1Gateway common.Address
+	l2Token   common.Address
+}
+
+// LogicReserve reconciles the l1 escrow balance of the bridged tokens with their l2 total supply.
+//
+// The configured tokens are always reconciled, the other tokens are discovered from the l1 custom gateway token
+// mappings and the erc20 deposits and withdrawals of the gateways. Both layers are reconciled at the finalized heights
+// pinned to the processed ones, and the deposits and withdrawals processed on only one layer at those heights are
+// subtracted from the imbalance, so the tolerance of a token only covers the rounding of the token.
+type LogicReserve struct {
+	reserveSnapshotOrm       *orm.ReserveSnapshot
+	gatewayMessageMatchOrm   *orm.GatewayMessageMatch
+	messengerMessageMatchOrm *orm.MessengerMessageMatch
+	governanceEventOrm       *orm.GovernanceEvent
+	l1Client                 *ethclient.Client
+	l2Client                 *ethclient.Client
+
+	enabled          bool
+	defaultTolerance decimal.Decimal
+	tokens           []config.ReserveToken
+	l1Gateways       map[string]common.Address
+	// the l1 gateways escrowing the tokens, resolved through the gateway mappings.
+	tokenGateways map[common.Address]tokenGateway
+	// the tokens already alerted, to alert once until the imbalance is back within the tolerance.
+	alerted map[common.Address]bool
+}
+
+// NewLogicReserve creates a new LogicReserve instance.
+func NewLogicReserve(cfg *config.Config, db *gorm.DB, l1Client, l2Client *ethclient.Client) *LogicReserve {
+	l1Gateways := make(map[string]common.Address)
+	for _, gateway := range cfg.L1Config.L1Contracts.Gateway.Addresses() {
+		l1Gateways[gateway.Name] = gateway.Address
+	}
+
+	r := &LogicReserve{
+		reserveSnapshotOrm:       orm.NewReserveSnapshot(db),
+		gatewayMessageMatchOrm:   orm.NewGatewayMessageMatch(db),
+		messengerMessageMatchOrm: orm.NewMessengerMessageMatch(db),
+		governanceEventOrm:       orm.NewGovernanceEvent(db),
+		l1Client:                 l1Client,
+		l2Client:                 l2Client,
+		l1Gateways:               l1Gateways,
+		tokenGateways:            make(map[common.Address]tokenGateway),
+		alerted:                  make(map[common.Address]bool),
+	}
+	if cfg.ReserveConfig != nil {
+		r.enabled = true
+		r.defaultTolerance = cfg.ReserveConfig.DefaultTolerance
+		r.tokens = cfg.ReserveConfig.Tokens
+	}
+	return r
+}
+
+// CheckReserves compares the l1 escrow balance and the l2 total supply of every bridged erc20 token, stores the
+// results and alerts when the imbalance exceeds the tolerance of the token.
+func (r *LogicReserve) CheckReserves(ctx context.Context) {
+	if !r.enabled {
+		return
+	}
+
+	l1BlockNumber, err := r.reconcileBlockNumber(ctx, types.Layer1, r.l1Client)
+	if err != nil {
+		log.Error("get l1 reconcile block number failed", "error", err)
+		return
+	}
+
+	l2BlockNumber, err := r.reconcileBlockNumber(ctx, types.Layer2, r.l2Client)
+	if err != nil {
+		log.Error("get l2 reconcile block number failed", "error", err)
+		return
+	}
+
+	if l1BlockNumber == 0 || l2BlockNumber == 0 {
+		return
+	}
+
+	tokens, err := r.reserveTokens(ctx)
+	if err != nil {
+		log.Error("get reserve tokens failed", "error", err)
+		return
+	}
+
+	var snapshots []orm.ReserveSnapshot
+	for _, token := range tokens {
+		snapshot, snapshotErr := r.reconcile(ctx, token, l1BlockNumber, l2BlockNumber)
+		if snapshotErr != nil {
+			log.Error("reconcile reserve failed", "token", token.Name, "l1 token", token.L1Token, "l2 token", token.L2Token, "error", snapshotErr)
+			continue
+		}
+		if snapshot != nil {
+			snapshots = append(snapshots, *snapshot)
+		}
+	}
+
+	if _, err = r.reserveSnapshotOrm.InsertReserveSnapshots(ctx, snapshots); err != nil {
+		log.Error("insert reserve snapshots failed", "error", err)
+		return
+	}
+
+	for _, snapshot := range snapshots {
+		l1Token := common.HexToAddress(snapshot.L1Token)
+		if snapshot.ReserveStatus == int(types.ReserveStatusTypeValid) {
+			delete(r.alerted, l1Token)
+			continue
+		}
+
+		log.Warn("reserve imbalance exceeds tolerance", "token", snapshot.Name, "l1 token", snapshot.L1Token, "l2 token", snapshot.L2Token,
+			"escrow", snapshot.L1EscrowBalance, "supply", snapshot.L2TotalSupply, "in flight", snapshot.InFlight, "imbalance", snapshot.Imbalance,
+			"tolerance", snapshot.Tolerance)
+		if r.alerted[l1Token] {
+			continue
+		}
+		r.alerted[l1Token] = true
+
+		// the metadata is only used to format the amounts, the raw amounts are alerted without it.
+		if _, resolveErr := tokenmetadata.Resolve(ctx, types.Layer1, l1Token); resolveErr != nil {
+			log.Debug("resolve token metadata failed", "l1 token", snapshot.L1Token, "error", resolveErr)
+		}
+		slack.Notify(slack.MrkDwnReserveImbalanceMessage(slack.ReserveImbalanceInfo{
+			Name:            snapshot.Name,
+			L1Gateway:       common.HexToAddress(snapshot.L1Gateway),
+			L1Token:         l1Token,
+			L2Token:         common.HexToAddress(snapshot.L2Token),
+			L1BlockNumber:   snapshot.L1BlockNumber,
+			L2BlockNumber:   snapshot.L2BlockNumber,
+			L1EscrowBalance: snapshot.L1EscrowBalance.BigInt(),
+			L2TotalSupply:   snapshot.L2TotalSupply.BigInt(),
+			InFlight:        snapshot.InFlight.BigInt(),
+			Imbalance:       snapshot.Imbalance.BigInt(),
+			Tolerance:       snapshot.Tolerance.BigInt(),
+		}))
+	}
+}
+
+// reconcileBlockNumber the finalized block number of the layer, pinned to the latest block processed by the
+// watcher so the stored deposits and withdrawals cover the balances read on chain.
+func (r *LogicReserve) reconcileBlockNumber(ctx context.Context, layer types.LayerType, client *ethclient.Client) (uint64, error) {
+	finalizedBlockNumber, err := utils.GetLatestConfirmedBlockNumber(ctx, client, rpc.FinalizedBlockNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	processed, err := r.messengerMessageMatchOrm.GetLatestBlockValidMessageMatch(ctx, layer)
+	if err != nil {
+		return 0, err
+	}
+	if processed == nil {
+		return 0, nil
+	}
+
+	processedBlockNumber := processed.L1BlockNumber
+	if layer == types.Layer2 {
+		processedBlockNumber = processed.L2BlockNumber
+	}
+	if processedBlockNumber < finalizedBlockNumber {
+		return processedBlockNumber, nil
+	}
+	return finalizedBlockNumber, nil
+}
+
+// reserveTokens the configured tokens, followed by the tokens mapped by the l1 custom gateways and the tokens
+// deposited or withdrawn through the gateways, the discovered tokens are reconciled with the default tolerance.
+func (r *LogicReserve) reserveTokens(ctx context.Context) ([]config.ReserveToken, error) {
+	tokens := append([]config.ReserveToken(nil), r.tokens...)
+	known := make(map[common.Address]bool)
+	for _, token := range r.tokens {
+		known[token.L1Token] = true
+	}
+
+	discover := func(l1Token, l2Token common.Address) {
+		if known[l1Token] {
+			return
+		}
+		known[l1Token] = true
+		tokens = append(tokens, config.ReserveToken{
+			Name:      l1Token.Hex(),
+			L1Token:   l1Token,
+			L2Token:   l2Token,
+			Tolerance: r.defaultTolerance,
+		})
+	}
+
+	mappings, err := r.tokenMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		discover(mapping.l1Token, mapping.l2Token)
+	}
+
+	pairs, err := r.gatewayMessageMatchOrm.GetERC20TokenPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range pairs {
+		discover(common.HexToAddress(pair.L1Token), common.HexToAddress(pair.L2Token))
+	}
+	return tokens, nil
+}
+
+type tokenMapping struct {
+	l1Token common.Address
+	l2Token common.Address
+}
+
+// tokenMappings the latest l2 token of every l1 token mapped by the UpdateTokenMapping events of the l1 custom
+// gateways, the tokens unmapped since are left out.
+func (r *LogicReserve) tokenMappings(ctx context.Context) ([]tokenMapping, error) {
+	governanceEvents, err := r.governanceEventOrm.GetGovernanceEventsByType(ctx, types.Layer1, types.GovernanceEventTypeUpdateTokenMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var l1Tokens []common.Address
+	l2Tokens := make(map[common.Address]common.Address)
+	for _, governanceEvent := range governanceEvents {
+		var args map[string]string
+		if err = json.Unmarshal([]byte(governanceEvent.Args), &args); err != nil {
+			return nil, fmt.Errorf("unmarshal token mapping args failed, err:%w, tx hash:%s", err, governanceEvent.TxHash)
+		}
+
+		l1Token := common.HexToAddress(args["l1Token"])
+		if _, ok := l2Tokens[l1Token]; !ok {
+			l1Tokens = append(l1Tokens, l1Token)
+		}
+		l2Tokens[l1Token] = common.HexToAddress(args["newL2Token"])
+	}
+
+	var mappings []tokenMapping
+	for _, l1Token := range l1Tokens {
+		if l2Tokens[l1Token] == (common.Address{}) {
+			continue
+		}
+		mappings = append(mappings, tokenMapping{l1Token: l1Token, l2Token: l2Tokens[l1Token]})
+	}
+	return mappings, nil
+}
+
+func (r *LogicReserve) reconcile(ctx context.Context, token config.ReserveToken, l1BlockNumber, l2BlockNumber uint64) (*orm.ReserveSnapshot, error) {
+	l1Opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(l1BlockNumber)}
+	gateway, err := r.tokenGateway(l1Opts, token)
+	if err != nil {
+		return nil, err
+	}
+	if gateway == nil {
+		log.Warn("erc20 token not mapped by the l1 gateways, skip reconciling", "l1 token", token.L1Token, "l2 token", token.L2Token)
+		return nil, nil
+	}
+
+	l1TokenCaller, err := iscrollerc20.NewIscrollerc20Caller(token.L1Token, r.l1Client)
+	if err != nil {
+		return nil, err
+	}
+	escrowBalance, err := l1TokenCaller.BalanceOf(l1Opts, gateway.l1Gateway)
+	if err != nil {
+		return nil, fmt.Errorf("get l1 escrow balance failed, err:%w", err)
+	}
+
+	l2TokenCaller, err := iscrollerc20.NewIscrollerc20Caller(gateway.l2Token, r.l2Client)
+	if err != nil {
+		return nil, err
+	}
+	totalSupply, err := l2TokenCaller.TotalSupply(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(l2BlockNumber)})
+	if err != nil {
+		return nil, fmt.Errorf("get l2 total supply failed, err:%w", err)
+	}
+
+	inFlight, err := r.gatewayMessageMatchOrm.GetERC20InFlightAmount(ctx, token.L1Token.Hex(), l1BlockNumber, l2BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	imbalance := decimal.NewFromBigInt(escrowBalance, 0).Sub(decimal.NewFromBigInt(totalSupply, 0)).Sub(inFlight)
+	reserveStatus := types.ReserveStatusTypeValid
+	if imbalance.Abs().GreaterThan(token.Tolerance) {
+		reserveStatus = types.ReserveStatusTypeInvalid
+	}
+
+	return &orm.ReserveSnapshot{
+		Name:            token.Name,
+		L1Gateway:       gateway.l1Gateway.Hex(),
+		L1Token:         token.L1Token.Hex(),
+		L2Token:         gateway.l2Token.Hex(),
+		L1BlockNumber:   l1BlockNumber,
+		L2BlockNumber:   l2BlockNumber,
+		L1EscrowBalance: decimal.NewFromBigInt(escrowBalance, 0),
+		L2TotalSupply:   decimal.NewFromBigInt(totalSupply, 0),
+		InFlight:        inFlight,
+		Imbalance:       imbalance,
+		Tolerance:       token.Tolerance,
+		ReserveStatus:   int(reserveStatus),
+	}, nil
+}
+
+// tokenGateway the l1 gateway escrowing the token and the l2 token it maps to, nil if no gateway maps the token. The
+// configured gateway of a token is trusted, otherwise the gateways are asked in turn for their mapping of the token.
+func (r *LogicReserve) tokenGateway(opts *bind.CallOpts, token config.ReserveToken) (*tokenGateway, error) {
+	if token.Gateway != "" {
+		l1Gateway, ok := r.l1Gateways[token.Gateway]
+		if !ok {
+			return nil, fmt.Errorf("l1 gateway %s unconfigured", token.Gateway)
+		}
+		if token.L2Token != (common.Address{}) {
+			return &tokenGateway{l1Gateway: l1Gateway, l2Token: token.L2Token}, nil
+		}
+		l2Token, err := r.mappedL2Token(opts, l1Gateway, token.L1Token)
+		if err != nil {
+			return nil, fmt.Errorf("get l2 token from l1 gateway failed, err:%w", err)
+		}
+		if l2Token == (common.Address{}) {
+			return nil, fmt.Errorf("l1 token %s is not mapped by l1 gateway %s", token.L1Token.Hex(), token.Gateway)
+		}
+		return &tokenGateway{l1Gateway: l1Gateway, l2Token: l2Token}, nil
+	}
+
+	if cached, ok := r.tokenGateways[token.L1Token]; ok && (token.L2Token == (common.Address{}) || cached.l2Token == token.L2Token) {
+		return &cached, nil
+	}
+
+	for _, name := range erc20GatewayNames {
+		l1Gateway, ok := r.l1Gateways[name]
+		if !ok {
+			continue
+		}
+
+		l2Token, err := r.mappedL2Token(opts, l1Gateway, token.L1Token)
+		if err != nil {
+			log.Debug("get l2 token from l1 gateway failed", "gateway", name, "l1 token", token.L1Token, "error", err)
+			continue
+		}
+		if l2Token == (common.Address{}) || (token.L2Token != (common.Address{}) && l2Token != token.L2Token) {
+			continue
+		}
+
+		gateway := tokenGateway{l1Gateway: l1Gateway, l2Token: l2Token}
+		r.tokenGateways[token.L1Token] = gateway
+		return &gateway, nil
+	}
+	return nil, nil
+}
+
+func (r *LogicReserve) mappedL2Token(opts *bind.CallOpts, l1Gateway, l1Token common.Address) (common.Address, error) {
+	gatewayCaller, err := il1erc20gateway.NewIl1erc20gatewayCaller(l1Gateway, r.l1Client)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return gatewayCaller.GetL2ERC20Address(opts, l1Token)
+}
@@ -0,0 +1,312 @@
+package rule
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils/expr"
+)
+
+type windowKey struct {
+	// layer is only set in the block windows, the block numbers of the layers can't be compared.
+	layer types.LayerType
+	group string
+}
+
+type windowEntry struct {
+	blockNumber uint64
+	processedAt time.Time
+	value       decimal.Decimal
+}
+
+type windowState struct {
+	definition string
+	entries    map[windowKey][]windowEntry
+	// alerted the keys whose condition held on their last event, they are alerted again once it stops holding.
+	alerted map[windowKey]bool
+	// latestBlockNumbers the latest block of each layer seen by the window.
+	latestBlockNumbers map[types.LayerType]uint64
+}
+
+// LogicRule evaluates the detection rules loaded from the rule files on the normalized bridge events. The window
+// aggregates are kept in memory, they start empty after a restart.
+type LogicRule struct {
+	files []string
+
+	mu       sync.Mutex
+	modTimes map[string]time.Time
+	rules    []*compiledRule
+	windows  map[string]*windowState
+
+	ruleEvaluateFailureTotal *prometheus.CounterVec
+	ruleReloadFailureTotal   prometheus.Counter
+}
+
+// NewLogicRule loads the configured rule files and creates a new LogicRule instance.
+func NewLogicRule(cfg *config.Config) (*LogicRule, error) {
+	l := &LogicRule{
+		modTimes: make(map[string]time.Time),
+		windows:  make(map[string]*windowState),
+		ruleEvaluateFailureTotal: promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+			Name: "rule_evaluate_failure_total",
+			Help: "The total number of rule evaluation failure.",
+		}, []string{"rule"}),
+		ruleReloadFailureTotal: promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+			Name: "rule_reload_failure_total",
+			Help: "The total number of rule files reload failure, the previous rules are kept on failure.",
+		}),
+	}
+	if cfg.RuleConfig != nil {
+		l.files = cfg.RuleConfig.Files
+	}
+	if err := l.reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// reload loads the rule files again if any of them has been modified since the last load.
+func (l *LogicRule) reload() error {
+	modTimes := make(map[string]time.Time, len(l.files))
+	changed := false
+	for _, file := range l.files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("stat rule file %s failed: %w", file, err)
+		}
+		modTimes[file] = info.ModTime()
+		if !info.ModTime().Equal(l.modTimes[file]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	// a broken file is reported once, and loaded again after the next modification.
+	l.modTimes = modTimes
+
+	rules, err := loadRules(l.files)
+	if err != nil {
+		return err
+	}
+
+	windows := make(map[string]*windowState)
+	for _, r := range rules {
+		if r.Window == nil {
+			continue
+		}
+		if state, exists := l.windows[r.Name]; exists && state.definition == r.definition {
+			windows[r.Name] = state
+			continue
+		}
+		windows[r.Name] = &windowState{
+			definition:         r.definition,
+			entries:            make(map[windowKey][]windowEntry),
+			alerted:            make(map[windowKey]bool),
+			latestBlockNumbers: make(map[types.LayerType]uint64),
+		}
+	}
+
+	l.rules = rules
+	l.windows = windows
+	log.Info("rules loaded", "files", l.files, "rules", len(rules))
+	return nil
+}
+
+// Evaluate evaluates the rules on the bridge events of a processed range in order, and returns the rule alerts.
+func (l *LogicRule) Evaluate(bridgeEvents []BridgeEvent) []slack.RuleInfo {
+	return l.evaluate(bridgeEvents, time.Now())
+}
+
+// evaluate evaluates the rules on the bridge events as processed at now.
+func (l *LogicRule) evaluate(bridgeEvents []BridgeEvent, now time.Time) []slack.RuleInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.reload(); err != nil {
+		l.ruleReloadFailureTotal.Inc()
+		log.Error("reload rule files failed, keep the previous rules", "files", l.files, "err", err)
+	}
+	if len(l.rules) == 0 {
+		return nil
+	}
+
+	var alerts []slack.RuleInfo
+	for _, event := range bridgeEvents {
+		vars := event.Vars()
+		for _, r := range l.rules {
+			alert, err := l.evaluateRule(r, event, vars, now)
+			if err != nil {
+				l.ruleEvaluateFailureTotal.WithLabelValues(r.Name).Inc()
+				log.Error("evaluate rule failed", "rule", r.Name, "layer", event.Layer, "tx hash", event.TxHash, "err", err)
+				continue
+			}
+			if alert != nil {
+				alerts = append(alerts, *alert)
+			}
+		}
+	}
+
+	for _, r := range l.rules {
+		if r.Window != nil {
+			l.windows[r.Name].prune(r.Window, now)
+		}
+	}
+	return alerts
+}
+
+func (l *LogicRule) evaluateRule(r *compiledRule, event BridgeEvent, eventVars map[string]interface{}, now time.Time) (*slack.RuleInfo, error) {
+	env := expr.Env{Vars: eventVars}
+	matched, err := r.match.EvalBool(env)
+	if err != nil || !matched {
+		return nil, err
+	}
+
+	if r.Window == nil {
+		holds, condErr := r.condition.EvalBool(env)
+		if condErr != nil || !holds {
+			return nil, condErr
+		}
+		return l.alert(r, event, "", 0, decimal.Zero), nil
+	}
+
+	key, err := windowKeyOf(r.Window, event, eventVars)
+	if err != nil {
+		return nil, err
+	}
+	value, err := r.sum.Eval(env)
+	if err != nil {
+		return nil, err
+	}
+	amount, ok := value.(decimal.Decimal)
+	if !ok {
+		return nil, fmt.Errorf("window sum %q isn't a number", r.sum.String())
+	}
+
+	state := l.windows[r.Name]
+	if event.BlockNumber > state.latestBlockNumbers[event.Layer] {
+		state.latestBlockNumbers[event.Layer] = event.BlockNumber
+	}
+	state.entries[key] = append(state.entries[key], windowEntry{blockNumber: event.BlockNumber, processedAt: now, value: amount})
+	state.pruneKey(r.Window, key, now)
+
+	count, sum := len(state.entries[key]), decimal.Zero
+	for _, entry := range state.entries[key] {
+		sum = sum.Add(entry.value)
+	}
+
+	vars := make(map[string]interface{}, len(eventVars)+2)
+	for name, v := range eventVars {
+		vars[name] = v
+	}
+	vars["count"] = count
+	vars["sum"] = sum
+	holds, err := r.condition.EvalBool(expr.Env{Vars: vars})
+	if err != nil {
+		return nil, err
+	}
+	if !holds {
+		delete(state.alerted, key)
+		return nil, nil
+	}
+	if state.alerted[key] {
+		return nil, nil
+	}
+	state.alerted[key] = true
+	return l.alert(r, event, key.group, count, sum), nil
+}
+
+func windowKeyOf(w *Window, event BridgeEvent, vars map[string]interface{}) (windowKey, error) {
+	var key windowKey
+	if w.Blocks > 0 {
+		key.layer = event.Layer
+	}
+	groups := make([]string, 0, len(w.GroupBy))
+	for _, field := range w.GroupBy {
+		v, exists := vars[field]
+		if !exists {
+			return key, fmt.Errorf("unknown group by field %s", field)
+		}
+		groups = append(groups, fmt.Sprintf("%s=%v", field, v))
+	}
+	key.group = strings.Join(groups, ",")
+	return key, nil
+}
+
+// pruneKey drops the entries of the key outside the window.
+func (s *windowState) pruneKey(w *Window, key windowKey, now time.Time) {
+	entries := s.entries[key]
+	kept := entries[:0]
+	for _, entry := range entries {
+		if w.Blocks > 0 && entry.blockNumber+w.Blocks <= s.latestBlockNumbers[key.layer] {
+			continue
+		}
+		if w.Minutes > 0 && now.Sub(entry.processedAt) >= time.Duration(w.Minutes)*time.Minute {
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	if len(kept) == 0 {
+		delete(s.entries, key)
+		delete(s.alerted, key)
+		return
+	}
+	s.entries[key] = kept
+}
+
+// prune drops the entries of every key outside the window, so idle keys don't pile up.
+func (s *windowState) prune(w *Window, now time.Time) {
+	keys := make([]windowKey, 0, len(s.entries))
+	for key := range s.entries {
+		keys = append(keys, key)
+	}
+	for _, key := range keys {
+		s.pruneKey(w, key, now)
+	}
+}
+
+func (l *LogicRule) alert(r *compiledRule, event BridgeEvent, group string, count int, sum decimal.Decimal) *slack.RuleInfo {
+	info := slack.RuleInfo{
+		Name:        r.Name,
+		Severity:    r.Severity,
+		Description: r.Description,
+		Layer:       event.Layer,
+		Kind:        event.Kind,
+		TokenType:   strings.TrimPrefix(event.TokenType.String(), "TokenType"),
+		L1Token:     event.L1Token,
+		Amount:      event.Amount.String(),
+		From:        event.From,
+		To:          event.To,
+		BlockNumber: event.BlockNumber,
+		TxHash:      event.TxHash,
+	}
+	if r.Window != nil {
+		info.Window = describeWindow(r.Window, group)
+		info.Count = count
+		info.Sum = sum.String()
+	}
+	log.Warn("rule alerted", "rule", r.Name, "layer", event.Layer, "kind", event.Kind, "tx hash", event.TxHash, "window", info.Window, "count", count, "sum", info.Sum)
+	return &info
+}
+
+func describeWindow(w *Window, group string) string {
+	span := fmt.Sprintf("%d blocks", w.Blocks)
+	if w.Minutes > 0 {
+		span = fmt.Sprintf("%d minutes", w.Minutes)
+	}
+	if group == "" {
+		return span
+	}
+	return fmt.Sprintf("%s of %s", span, group)
+}
@@ -0,0 +1,145 @@
+package rule
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// newTestLogicRule loads the rule file like NewLogicRule, with the metrics left unregistered.
+func newTestLogicRule(t *testing.T, file string) *LogicRule {
+	l := &LogicRule{
+		files:    []string{file},
+		modTimes: make(map[string]time.Time),
+		windows:  make(map[string]*windowState),
+		ruleEvaluateFailureTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "rule_evaluate_failure_total",
+		}, []string{"rule"}),
+		ruleReloadFailureTotal: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "rule_reload_failure_total",
+		}),
+	}
+	require.NoError(t, l.reload())
+	return l
+}
+
+// writeRules writes the rule file with a modification time of modTime.
+func writeRules(t *testing.T, file, rules string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(file, []byte(rules), 0600))
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+}
+
+func withdrawal(from common.Address, amount int64, blockNumber uint64) BridgeEvent {
+	return BridgeEvent{
+		Kind:        KindWithdrawal,
+		Layer:       types.Layer2,
+		EventType:   types.L2WithdrawERC20,
+		TokenType:   types.TokenTypeERC20,
+		L1Token:     common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		Amount:      decimal.NewFromInt(amount),
+		From:        from,
+		BlockNumber: blockNumber,
+	}
+}
+
+func TestEvaluateWindow(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, file, `{"rules": [
+		{"name": "withdrawal_burst", "match": "kind == 'withdrawal'", "window": {"group_by": ["from"], "blocks": 10}, "condition": "count >= 3"},
+		{"name": "withdrawal_volume", "match": "kind == 'withdrawal'", "window": {"minutes": 60}, "condition": "sum > 1000"}
+	]}`, time.Now())
+	l := newTestLogicRule(t, file)
+
+	alice, bob := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	now := time.Now()
+
+	// the windows are grouped by the sender, bob's withdrawal isn't counted in alice's window.
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 100), withdrawal(bob, 100, 101), withdrawal(alice, 100, 102)}, now))
+	alerts := l.evaluate([]BridgeEvent{withdrawal(alice, 100, 103)}, now)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "withdrawal_burst", alerts[0].Name)
+	assert.Equal(t, 3, alerts[0].Count)
+	assert.Equal(t, "10 blocks of from="+alice.Hex(), alerts[0].Window)
+
+	// the key is alerted once while the condition holds.
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 104)}, now))
+
+	// the sum of the minutes window crosses the limit.
+	alerts = l.evaluate([]BridgeEvent{withdrawal(bob, 600, 105)}, now)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "withdrawal_volume", alerts[0].Name)
+	assert.Equal(t, 6, alerts[0].Count)
+	assert.Equal(t, "1100", alerts[0].Sum)
+	assert.Equal(t, "60 minutes", alerts[0].Window)
+}
+
+func TestEvaluateWindowPruning(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rules.json")
+	writeRules(t, file, `{"rules": [
+		{"name": "withdrawal_burst", "match": "kind == 'withdrawal'", "window": {"group_by": ["from"], "blocks": 10}, "condition": "count >= 2"},
+		{"name": "withdrawal_volume", "match": "kind == 'withdrawal'", "window": {"minutes": 60}, "condition": "sum >= 1000"}
+	]}`, time.Now())
+	l := newTestLogicRule(t, file)
+
+	alice, bob := common.HexToAddress("0x01"), common.HexToAddress("0x02")
+	now := time.Now()
+
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 100)}, now))
+
+	// block 100 is out of the 10 blocks window at block 110, and out of the 60 minutes window after an hour.
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 110)}, now.Add(time.Hour)))
+	burst := l.windows["withdrawal_burst"]
+	assert.Len(t, burst.entries, 1)
+	assert.Len(t, l.windows["withdrawal_volume"].entries[windowKey{}], 1)
+
+	// the idle keys are pruned by the later ranges too.
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(bob, 50, 120)}, now.Add(time.Hour)))
+	assert.Equal(t, map[windowKey][]windowEntry{
+		{layer: types.Layer2, group: "from=" + bob.Hex()}: {{blockNumber: 120, processedAt: now.Add(time.Hour), value: decimal.NewFromInt(50)}},
+	}, burst.entries)
+
+	// an alerted key is alerted again once the condition stopped holding.
+	require.Len(t, l.evaluate([]BridgeEvent{withdrawal(bob, 50, 121)}, now.Add(time.Hour)), 1)
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(bob, 50, 140)}, now.Add(time.Hour)))
+	require.Len(t, l.evaluate([]BridgeEvent{withdrawal(bob, 50, 141)}, now.Add(time.Hour)), 1)
+}
+
+func TestReloadOnModify(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rules.json")
+	loadedAt := time.Now().Add(-time.Hour)
+	burst := `{"name": "withdrawal_burst", "match": "kind == 'withdrawal'", "window": {"blocks": 10}, "condition": "count >= 3"}`
+	writeRules(t, file, `{"rules": [`+burst+`]}`, loadedAt)
+	l := newTestLogicRule(t, file)
+
+	alice := common.HexToAddress("0x01")
+	now := time.Now()
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 100), withdrawal(alice, 100, 101)}, now))
+
+	// the window of an unchanged rule is kept when another rule is added.
+	large := `{"name": "large_withdrawal", "match": "kind == 'withdrawal'", "condition": "amount >= 1000"}`
+	writeRules(t, file, `{"rules": [`+burst+`, `+large+`]}`, loadedAt.Add(time.Minute))
+	alerts := l.evaluate([]BridgeEvent{withdrawal(alice, 1000, 102)}, now)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "withdrawal_burst", alerts[0].Name)
+	assert.Equal(t, "large_withdrawal", alerts[1].Name)
+
+	// a broken file keeps the previous rules.
+	writeRules(t, file, `{"rules": [`, loadedAt.Add(2*time.Minute))
+	require.Len(t, l.evaluate([]BridgeEvent{withdrawal(alice, 1000, 103)}, now), 1)
+	assert.Len(t, l.rules, 2)
+
+	// the window of a modified rule starts empty.
+	writeRules(t, file, `{"rules": [{"name": "withdrawal_burst", "match": "kind == 'withdrawal'", "window": {"blocks": 20}, "condition": "count >= 3"}]}`, loadedAt.Add(3*time.Minute))
+	assert.Empty(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 104), withdrawal(alice, 100, 105)}, now))
+	assert.Len(t, l.rules, 1)
+	require.Len(t, l.evaluate([]BridgeEvent{withdrawal(alice, 100, 106)}, now), 1)
+}
@@ -0,0 +1,204 @@
+package rule
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// The kinds of the normalized bridge events.
+const (
+	KindDeposit     = "deposit"
+	KindWithdrawal  = "withdrawal"
+	KindFinalize    = "finalize"
+	KindRefund      = "refund"
+	KindRelay       = "relay"
+	KindFailedRelay = "failed_relay"
+	KindReplay      = "replay"
+	KindDrop        = "drop"
+)
+
+// BridgeEvent is a gateway or messenger event normalized for the rules. The eth moved by the messenger is an
+// ETH token event with the zero token address, the gateway events are reported with their token addresses.
+type BridgeEvent struct {
+	Kind        string
+	Layer       types.LayerType
+	EventType   types.EventType
+	TokenType   types.TokenType
+	L1Token     common.Address
+	L2Token     common.Address
+	Amount      decimal.Decimal
+	From        common.Address
+	To          common.Address
+	BlockNumber uint64
+	TxHash      common.Hash
+	Index       uint
+	MessageHash common.Hash
+}
+
+// Vars returns the event fields bound in the rule expressions.
+func (e BridgeEvent) Vars() map[string]interface{} {
+	layer := "l1"
+	if e.Layer == types.Layer2 {
+		layer = "l2"
+	}
+	return map[string]interface{}{
+		"kind":         e.Kind,
+		"layer":        layer,
+		"event_type":   e.EventType.String(),
+		"token_type":   strings.TrimPrefix(e.TokenType.String(), "TokenType"),
+		"token":        e.L1Token.Hex(),
+		"l1_token":     e.L1Token.Hex(),
+		"l2_token":     e.L2Token.Hex(),
+		"amount":       e.Amount,
+		"from":         e.From.Hex(),
+		"to":           e.To.Hex(),
+		"block_number": e.BlockNumber,
+		"tx_hash":      e.TxHash.Hex(),
+		"message_hash": e.MessageHash.Hex(),
+	}
+}
+
+// NormalizeEvents normalizes the gateway and messenger events of a processed range, ordered by block and log index.
+// The messenger sent messages without value are left out since they carry the gateway events.
+func NormalizeEvents(gatewayEvents, messengerEvents []events.EventUnmarshaler) []BridgeEvent {
+	var bridgeEvents []BridgeEvent
+	for _, event := range gatewayEvents {
+		var bridgeEvent BridgeEvent
+		switch e := event.(type) {
+		case *events.ERC20GatewayEventUnmarshaler:
+			bridgeEvent = BridgeEvent{
+				Layer:       e.Layer,
+				EventType:   e.Type,
+				TokenType:   types.TokenTypeERC20,
+				L1Token:     e.L1Token,
+				L2Token:     e.L2Token,
+				Amount:      amount(e.Amount),
+				From:        e.From,
+				To:          e.To,
+				BlockNumber: e.Number,
+				TxHash:      e.TxHash,
+				Index:       e.Index,
+				MessageHash: e.MessageHash,
+			}
+		case *events.ERC721GatewayEventUnmarshaler:
+			bridgeEvent = BridgeEvent{
+				Layer:       e.Layer,
+				EventType:   e.Type,
+				TokenType:   types.TokenTypeERC721,
+				L1Token:     e.L1Token,
+				L2Token:     e.L2Token,
+				Amount:      decimal.NewFromInt(int64(len(e.TokenIds))),
+				From:        e.From,
+				To:          e.To,
+				BlockNumber: e.Number,
+				TxHash:      e.TxHash,
+				Index:       e.Index,
+				MessageHash: e.MessageHash,
+			}
+		case *events.ERC1155GatewayEventUnmarshaler:
+			total := decimal.Zero
+			for _, a := range e.Amounts {
+				total = total.Add(amount(a))
+			}
+			bridgeEvent = BridgeEvent{
+				Layer:       e.Layer,
+				EventType:   e.Type,
+				TokenType:   types.TokenTypeERC1155,
+				L1Token:     e.L1Token,
+				L2Token:     e.L2Token,
+				Amount:      total,
+				From:        e.From,
+				To:          e.To,
+				BlockNumber: e.Number,
+				TxHash:      e.TxHash,
+				Index:       e.Index,
+				MessageHash: e.MessageHash,
+			}
+		default:
+			continue
+		}
+		if bridgeEvent.Kind = gatewayEventKind(bridgeEvent.EventType); bridgeEvent.Kind == "" {
+			continue
+		}
+		bridgeEvents = append(bridgeEvents, bridgeEvent)
+	}
+
+	for _, event := range messengerEvents {
+		e, ok := event.(*events.MessengerEventUnmarshaler)
+		if !ok {
+			continue
+		}
+		bridgeEvent := BridgeEvent{
+			Layer:       e.Layer,
+			EventType:   e.Type,
+			TokenType:   types.TokenTypeETH,
+			Amount:      amount(e.Value),
+			BlockNumber: e.Number,
+			TxHash:      e.TxHash,
+			Index:       e.Index,
+			MessageHash: e.MessageHash,
+		}
+		switch e.Type {
+		case types.L1SentMessage:
+			bridgeEvent.Kind = KindDeposit
+		case types.L2SentMessage:
+			bridgeEvent.Kind = KindWithdrawal
+		case types.L1RelayedMessage, types.L2RelayedMessage:
+			bridgeEvent.Kind = KindRelay
+			// the relayed value is only trusted if the relay calldata reproduces the message hash.
+			if e.RelayedMessageHash != e.MessageHash {
+				bridgeEvent.Amount = decimal.Zero
+			}
+		case types.L1FailedRelayedMessage, types.L2FailedRelayedMessage:
+			bridgeEvent.Kind = KindFailedRelay
+		case types.L1ReplayMessage:
+			bridgeEvent.Kind = KindReplay
+		case types.L1DropMessage:
+			bridgeEvent.Kind = KindDrop
+		default:
+			continue
+		}
+		if (bridgeEvent.Kind == KindDeposit || bridgeEvent.Kind == KindWithdrawal) && bridgeEvent.Amount.IsZero() {
+			continue
+		}
+		bridgeEvents = append(bridgeEvents, bridgeEvent)
+	}
+
+	sort.SliceStable(bridgeEvents, func(i, j int) bool {
+		if bridgeEvents[i].BlockNumber != bridgeEvents[j].BlockNumber {
+			return bridgeEvents[i].BlockNumber < bridgeEvents[j].BlockNumber
+		}
+		return bridgeEvents[i].Index < bridgeEvents[j].Index
+	})
+	return bridgeEvents
+}
+
+// gatewayEventKind classifies the gateway event types by their names, e.g. L1FinalizeBatchWithdrawERC721 is a finalize.
+func gatewayEventKind(eventType types.EventType) string {
+	name := eventType.String()
+	switch {
+	case strings.Contains(name, "Refund"):
+		return KindRefund
+	case strings.Contains(name, "Finalize"):
+		return KindFinalize
+	case strings.Contains(name, "Deposit"):
+		return KindDeposit
+	case strings.Contains(name, "Withdraw"):
+		return KindWithdrawal
+	}
+	return ""
+}
+
+func amount(v *big.Int) decimal.Decimal {
+	if v == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(v, 0)
+}
@@ -0,0 +1,113 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/scroll-tech/chain-monitor/internal/utils/expr"
+)
+
+// Rule a detection rule over the normalized bridge events, written in the expression language of the expr package.
+type Rule struct {
+	Name        string `json:"name"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	// Match selects the events the rule applies to, every event is matched if empty.
+	Match string `json:"match"`
+	// Window aggregates the matched events, the aggregates are bound to count and sum in the condition.
+	Window *Window `json:"window"`
+	// Condition alerts on the matched event when true, every matched event is alerted if empty.
+	Condition string `json:"condition"`
+}
+
+// Window the aggregation of the matched events per key over a number of blocks or minutes.
+type Window struct {
+	// GroupBy the event fields keying the aggregation, e.g. token and from.
+	GroupBy []string `json:"group_by"`
+	// Blocks the window spans the last blocks of the event's layer, the layer is always part of the key.
+	Blocks uint64 `json:"blocks"`
+	// Minutes the window spans the last minutes since the events were processed.
+	Minutes uint64 `json:"minutes"`
+	// Sum the expression summed over the window, the amount if empty.
+	Sum string `json:"sum"`
+}
+
+// ruleFile the rule file layout.
+type ruleFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// compiledRule a rule with its expressions parsed.
+type compiledRule struct {
+	Rule
+	// definition the json of the rule, the window state is kept across reloads while it doesn't change.
+	definition string
+	match      *expr.Expression
+	sum        *expr.Expression
+	condition  *expr.Expression
+}
+
+func compileRule(r Rule) (*compiledRule, error) {
+	if r.Name == "" {
+		return nil, fmt.Errorf("rule without name")
+	}
+	definition, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compiledRule{Rule: r, definition: string(definition)}
+	if c.match, err = parseOr(r.Match, "true"); err != nil {
+		return nil, fmt.Errorf("rule %s match: %w", r.Name, err)
+	}
+	if c.condition, err = parseOr(r.Condition, "true"); err != nil {
+		return nil, fmt.Errorf("rule %s condition: %w", r.Name, err)
+	}
+	if r.Window != nil {
+		if (r.Window.Blocks == 0) == (r.Window.Minutes == 0) {
+			return nil, fmt.Errorf("rule %s window needs either blocks or minutes", r.Name)
+		}
+		if c.sum, err = parseOr(r.Window.Sum, "amount"); err != nil {
+			return nil, fmt.Errorf("rule %s window sum: %w", r.Name, err)
+		}
+	}
+	return c, nil
+}
+
+func parseOr(source, fallback string) (*expr.Expression, error) {
+	if strings.TrimSpace(source) == "" {
+		source = fallback
+	}
+	return expr.Parse(source)
+}
+
+// loadRules loads the rules of the files, the rule names must be unique across the files.
+func loadRules(files []string) ([]*compiledRule, error) {
+	var rules []*compiledRule
+	names := make(map[string]string)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+		var f ruleFile
+		if err = json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parse rule file %s failed: %w", file, err)
+		}
+		for _, r := range f.Rules {
+			c, compileErr := compileRule(r)
+			if compileErr != nil {
+				return nil, fmt.Errorf("rule file %s: %w", file, compileErr)
+			}
+			if previous, exists := names[r.Name]; exists {
+				return nil, fmt.Errorf("rule %s of %s is already defined in %s", r.Name, file, previous)
+			}
+			names[r.Name] = file
+			rules = append(rules, c)
+		}
+	}
+	return rules, nil
+}
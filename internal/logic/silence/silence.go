@@ -0,0 +1,180 @@
+package silence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
+)
+
+// queryTimeout the timeout of looking up the active silences of an alert, the alert is notified if it's exceeded.
+const queryTimeout = 3 * time.Second
+
+var (
+	// ErrInvalidSilence the silence to create is invalid.
+	ErrInvalidSilence = errors.New("invalid silence")
+	// ErrSilenceNotFound the silence doesn't exist or is expired.
+	ErrSilenceNotFound = errors.New("silence not found or expired")
+
+	silencedAlertTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_silenced_total",
+		Help: "The total number of alerts not notified because they matched an active silence.",
+	}, []string{"kind"})
+)
+
+// LogicSilence creates, lists and expires the alert silences, and silences the alerts matching an active silence.
+type LogicSilence struct {
+	alertSilenceOrm  *orm.AlertSilence
+	silencedAlertOrm *orm.SilencedAlert
+}
+
+// NewLogicSilence create a LogicSilence instance
+func NewLogicSilence(db *gorm.DB) *LogicSilence {
+	return &LogicSilence{
+		alertSilenceOrm:  orm.NewAlertSilence(db),
+		silencedAlertOrm: orm.NewSilencedAlert(db),
+	}
+}
+
+// CreateSilence validates the silence and creates it, a silence without starts at starts now. It returns the id of
+// the silence.
+func (l *LogicSilence) CreateSilence(ctx context.Context, silence orm.AlertSilence) (int64, error) {
+	if err := normalize(&silence, utils.NowUTC()); err != nil {
+		return 0, err
+	}
+	id, err := l.alertSilenceOrm.InsertAlertSilence(ctx, silence)
+	if err != nil {
+		return 0, err
+	}
+	log.Info("alert silence created", "id", id, "kind", silence.Kind, "layer", silence.Layer, "token", silence.Token,
+		"address", silence.Address, "starts at", silence.StartsAt, "ends at", silence.EndsAt, "created by", silence.CreatedBy, "reason", silence.Reason)
+	return id, nil
+}
+
+// ExpireSilence ends the silence now.
+func (l *LogicSilence) ExpireSilence(ctx context.Context, id int64) error {
+	affectRows, err := l.alertSilenceOrm.ExpireAlertSilence(ctx, id, utils.NowUTC())
+	if err != nil {
+		return err
+	}
+	if affectRows == 0 {
+		return ErrSilenceNotFound
+	}
+	log.Info("alert silence expired", "id", id)
+	return nil
+}
+
+// ListSilences returns the silences not ended yet, the active ones and the ones starting later.
+func (l *LogicSilence) ListSilences(ctx context.Context) ([]orm.AlertSilence, error) {
+	return l.alertSilenceOrm.GetUnexpiredAlertSilences(ctx, utils.NowUTC())
+}
+
+// SilencedAlerts returns the latest alerts silenced by the silence, the newest first.
+func (l *LogicSilence) SilencedAlerts(ctx context.Context, silenceID int64, limit int) ([]orm.SilencedAlert, error) {
+	return l.silencedAlertOrm.GetSilencedAlerts(ctx, silenceID, limit)
+}
+
+// Silenced returns whether the alert matches an active silence, and records it as silenced by the first of them.
+// The alert isn't silenced if the silences can't be looked up.
+func (l *LogicSilence) Silenced(msg *slack.Message) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	silences, err := l.alertSilenceOrm.GetActiveAlertSilences(ctx, utils.NowUTC())
+	if err != nil {
+		log.Error("get active alert silences failed, the alert is not silenced", "kind", msg.Kind, "error", err)
+		return false
+	}
+	for _, silence := range silences {
+		if !matches(silence, msg) {
+			continue
+		}
+		silencedAlertTotal.WithLabelValues(msg.Kind).Inc()
+		alert := orm.SilencedAlert{
+			SilenceID:   silence.ID,
+			Kind:        msg.Kind,
+			Severity:    msg.Tier(),
+			Title:       msg.Title,
+			MessageHash: msg.MessageHash,
+			Content:     msg.String(),
+		}
+		if insertErr := l.silencedAlertOrm.InsertSilencedAlert(ctx, alert); insertErr != nil {
+			log.Error("record silenced alert failed", "silence id", silence.ID, "kind", msg.Kind, "error", insertErr)
+		}
+		log.Info("alert silenced", "silence id", silence.ID, "kind", msg.Kind, "title", msg.Title)
+		return true
+	}
+	return false
+}
+
+// matches returns whether the alert matches every matcher of the silence, the empty matchers match any alert.
+func matches(silence orm.AlertSilence, msg *slack.Message) bool {
+	if silence.Kind != "" && silence.Kind != msg.Kind {
+		return false
+	}
+	if silence.Layer != "" && silence.Layer != msg.Layer {
+		return false
+	}
+	if silence.Token != "" && !contains(msg.Tokens, silence.Token) {
+		return false
+	}
+	if silence.Address != "" && !contains(msg.Addresses, silence.Address) {
+		return false
+	}
+	return true
+}
+
+func contains(labels []string, value string) bool {
+	for _, label := range labels {
+		if strings.EqualFold(label, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize validates the silence and lowercases its addresses, the silence starts now if its starts at is unset.
+func normalize(silence *orm.AlertSilence, now time.Time) error {
+	if silence.Kind == "" && silence.Layer == "" && silence.Token == "" && silence.Address == "" {
+		return fmt.Errorf("%w: at least one of kind, layer, token and address is required", ErrInvalidSilence)
+	}
+	if silence.Kind != "" && !slack.ValidKind(silence.Kind) {
+		return fmt.Errorf("%w: unknown alert kind %s", ErrInvalidSilence, silence.Kind)
+	}
+	if silence.Layer != "" && silence.Layer != "l1" && silence.Layer != "l2" {
+		return fmt.Errorf("%w: invalid layer %s, expected l1 or l2", ErrInvalidSilence, silence.Layer)
+	}
+	if common.IsHexAddress(silence.Token) {
+		silence.Token = strings.ToLower(common.HexToAddress(silence.Token).Hex())
+	}
+	if silence.Address != "" {
+		if !common.IsHexAddress(silence.Address) {
+			return fmt.Errorf("%w: invalid address %s", ErrInvalidSilence, silence.Address)
+		}
+		silence.Address = strings.ToLower(common.HexToAddress(silence.Address).Hex())
+	}
+	if silence.CreatedBy == "" || silence.Reason == "" {
+		return fmt.Errorf("%w: created by and reason are required", ErrInvalidSilence)
+	}
+
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = now
+	}
+	silence.StartsAt = silence.StartsAt.UTC()
+	silence.EndsAt = silence.EndsAt.UTC()
+	if !silence.EndsAt.After(silence.StartsAt) || !silence.EndsAt.After(now) {
+		return fmt.Errorf("%w: ends at must be after starts at and in the future", ErrInvalidSilence)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package silence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+)
+
+func TestMatches(t *testing.T) {
+	msg := &slack.Message{
+		Kind:      "proxy_drift",
+		Layer:     "l1",
+		Tokens:    []string{"0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", "USDC"},
+		Addresses: []string{"0x6774bcbd5cecef1336b5300fb5186a12ddd8b367"},
+	}
+
+	tests := []struct {
+		name    string
+		silence orm.AlertSilence
+		matched bool
+	}{
+		{"kind", orm.AlertSilence{Kind: "proxy_drift"}, true},
+		{"other kind", orm.AlertSilence{Kind: "invariant"}, false},
+		{"kind and layer", orm.AlertSilence{Kind: "proxy_drift", Layer: "l1"}, true},
+		{"kind and other layer", orm.AlertSilence{Kind: "proxy_drift", Layer: "l2"}, false},
+		{"token symbol", orm.AlertSilence{Token: "usdc"}, true},
+		{"token address", orm.AlertSilence{Token: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"}, true},
+		{"other token", orm.AlertSilence{Token: "DAI"}, false},
+		{"address", orm.AlertSilence{Address: "0x6774bcbd5cecef1336b5300fb5186a12ddd8b367"}, true},
+		{"other address", orm.AlertSilence{Layer: "l1", Address: "0xa13baf47339d63b743e7da8741db5456dac1e556"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.matched, matches(test.silence, msg))
+		})
+	}
+
+	// the alerts spanning both layers don't match a layer.
+	assert.False(t, matches(orm.AlertSilence{Layer: "l2"}, &slack.Message{Kind: "gateway_cross_chain"}))
+}
+
+func TestNormalize(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+
+	silence := orm.AlertSilence{
+		Token:     "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Address:   "0x6774Bcbd5ceCEf1336b5300fb5186a12DDD8b367",
+		EndsAt:    now.Add(2 * time.Hour),
+		CreatedBy: "alice",
+		Reason:    "usdc gateway upgrade",
+	}
+	require.NoError(t, normalize(&silence, now))
+	assert.Equal(t, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", silence.Token)
+	assert.Equal(t, "0x6774bcbd5cecef1336b5300fb5186a12ddd8b367", silence.Address)
+	assert.Equal(t, now, silence.StartsAt)
+
+	symbol := orm.AlertSilence{Token: "USDC", EndsAt: now.Add(time.Hour), CreatedBy: "alice", Reason: "upgrade"}
+	require.NoError(t, normalize(&symbol, now))
+	assert.Equal(t, "USDC", symbol.Token)
+
+	invalid := []orm.AlertSilence{
+		{EndsAt: now.Add(time.Hour), CreatedBy: "alice", Reason: "no matcher"},
+		{Kind: "unknown", EndsAt: now.Add(time.Hour), CreatedBy: "alice", Reason: "unknown kind"},
+		{Layer: "Layer1", EndsAt: now.Add(time.Hour), CreatedBy: "alice", Reason: "invalid layer"},
+		{Address: "0x01", EndsAt: now.Add(time.Hour), CreatedBy: "alice", Reason: "invalid address"},
+		{Kind: "proxy_drift", EndsAt: now.Add(time.Hour), Reason: "no creator"},
+		{Kind: "proxy_drift", EndsAt: now.Add(-time.Minute), CreatedBy: "alice", Reason: "ended"},
+		{Kind: "proxy_drift", StartsAt: now.Add(2 * time.Hour), EndsAt: now.Add(time.Hour), CreatedBy: "alice", Reason: "ends before start"},
+	}
+	for _, s := range invalid {
+		assert.ErrorIs(t, normalize(&s, now), ErrInvalidSilence, s.Reason)
+	}
+}
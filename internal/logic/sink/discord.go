@@ -0,0 +1,119 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+)
+
+const (
+	// discordInterval a webhook allows 30 messages per minute to its channel.
+	discordInterval = 2 * time.Second
+
+	discordMaxEmbeds     = 10
+	discordMaxFields     = 25
+	discordMaxTitle      = 256
+	discordMaxFieldName  = 256
+	discordMaxFieldValue = 1024
+)
+
+type discordField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields,omitempty"`
+}
+
+type discordSender struct {
+	cfg *config.DiscordConfig
+	cli *resty.Client
+}
+
+// NewDiscordSink creates the sink posting the alerts as embeds to the discord webhook.
+func NewDiscordSink(ctx context.Context, cfg *config.DiscordConfig) (*Sink, error) {
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("discord sink has no webhook url")
+	}
+	return newSink(ctx, "discord", cfg.MinSeverity, discordInterval, &discordSender{cfg: cfg, cli: newClient()})
+}
+
+func (d *discordSender) send(ctx context.Context, msg *slack.Message) (time.Duration, error) {
+	var rateLimit struct {
+		// RetryAfter the seconds to wait before the next request.
+		RetryAfter float64 `json:"retry_after"`
+	}
+	resp, err := d.cli.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(map[string]interface{}{"embeds": discordEmbeds(msg)}).
+		SetError(&rateLimit).
+		Post(d.cfg.WebhookURL)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		retryAfter := time.Duration(rateLimit.RetryAfter * float64(time.Second))
+		if retryAfter <= 0 {
+			retryAfter = discordInterval
+		}
+		return retryAfter, fmt.Errorf("discord webhook rate limited")
+	}
+	if resp.IsError() {
+		return 0, fmt.Errorf("discord webhook status: %s, body: %s", resp.Status(), resp.String())
+	}
+	return 0, nil
+}
+
+// discordEmbeds formats the message as embeds colored by the severity, the fields beyond the embed limit continue in
+// the next embeds.
+func discordEmbeds(msg *slack.Message) []discordEmbed {
+	color, err := strconv.ParseInt(strings.TrimPrefix(msg.Color(), "#"), 16, 32)
+	if err != nil {
+		color = 0
+	}
+
+	embeds := []discordEmbed{{
+		Title:       truncate(fmt.Sprintf("%s %s", emoji(msg.Emoji), msg.Title), discordMaxTitle),
+		Description: fmt.Sprintf("severity: **%s**", severity(msg)),
+		Color:       int(color),
+	}}
+	for _, field := range msg.Fields {
+		embed := &embeds[len(embeds)-1]
+		if len(embed.Fields) == discordMaxFields {
+			if len(embeds) == discordMaxEmbeds {
+				break
+			}
+			embeds = append(embeds, discordEmbed{Color: int(color)})
+			embed = &embeds[len(embeds)-1]
+		}
+		embed.Fields = append(embed.Fields, discordField{
+			Name:  truncate(field.Name, discordMaxFieldName),
+			Value: truncate(discordLinks(field.Value), discordMaxFieldValue),
+		})
+	}
+	return embeds
+}
+
+func discordLinks(text string) string {
+	value := replaceLinks(text, func(s string) string { return s }, func(url, text string) string {
+		return fmt.Sprintf("[%s](%s)", text, url)
+	})
+	// discord rejects the empty field values.
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,93 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+)
+
+// matrixInterval the homeservers rate limit the messages of a user, synapse allows about one message per second.
+const matrixInterval = time.Second
+
+type matrixSender struct {
+	cfg *config.MatrixConfig
+	cli *resty.Client
+	// txnID makes the transaction ids of the sent events unique, the homeserver deduplicates the retries of one.
+	txnID uint64
+}
+
+// NewMatrixSink creates the sink sending the alerts as html notices to the matrix room.
+func NewMatrixSink(ctx context.Context, cfg *config.MatrixConfig) (*Sink, error) {
+	if cfg.HomeserverURL == "" || cfg.AccessToken == "" || cfg.RoomID == "" {
+		return nil, fmt.Errorf("matrix sink has no homeserver url, access token or room id")
+	}
+	return newSink(ctx, "matrix", cfg.MinSeverity, matrixInterval, &matrixSender{cfg: cfg, cli: newClient()})
+}
+
+func (m *matrixSender) send(ctx context.Context, msg *slack.Message) (time.Duration, error) {
+	txnID := fmt.Sprintf("chain-monitor-%d-%d", time.Now().UnixNano(), atomic.AddUint64(&m.txnID, 1))
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimSuffix(m.cfg.HomeserverURL, "/"), url.PathEscape(m.cfg.RoomID), txnID)
+
+	var matrixErr struct {
+		ErrCode      string `json:"errcode"`
+		Error        string `json:"error"`
+		RetryAfterMs int64  `json:"retry_after_ms"`
+	}
+	body, formattedBody := matrixBodies(msg)
+	resp, err := m.cli.R().
+		SetContext(ctx).
+		SetAuthToken(m.cfg.AccessToken).
+		SetBody(map[string]string{
+			"msgtype":        "m.notice",
+			"body":           body,
+			"format":         "org.matrix.custom.html",
+			"formatted_body": formattedBody,
+		}).
+		SetError(&matrixErr).
+		Put(endpoint)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		retryAfter := time.Duration(matrixErr.RetryAfterMs) * time.Millisecond
+		if retryAfter <= 0 {
+			retryAfter = matrixInterval
+		}
+		return retryAfter, fmt.Errorf("matrix homeserver rate limited: %s", matrixErr.ErrCode)
+	}
+	if resp.IsError() {
+		return 0, fmt.Errorf("matrix homeserver status: %s, errcode: %s, error: %s", resp.Status(), matrixErr.ErrCode, matrixErr.Error)
+	}
+	return 0, nil
+}
+
+// matrixBodies formats the message as the plain text body and the html formatted body, the title of the formatted
+// body is colored by the severity.
+func matrixBodies(msg *slack.Message) (string, string) {
+	var body, formatted strings.Builder
+	body.WriteString(fmt.Sprintf("%s %s\n", emoji(msg.Emoji), msg.Title))
+	body.WriteString(fmt.Sprintf("• severity: %s\n", severity(msg)))
+	formatted.WriteString(fmt.Sprintf(`%s <strong><font color="%s">%s</font></strong><br>`, emoji(msg.Emoji), msg.Color(), html.EscapeString(msg.Title)))
+	formatted.WriteString("<ul>")
+	formatted.WriteString(fmt.Sprintf("<li>severity: <strong>%s</strong></li>", html.EscapeString(severity(msg))))
+	for _, field := range msg.Fields {
+		plain := replaceLinks(field.Value, func(s string) string { return s }, func(url, text string) string {
+			return fmt.Sprintf("%s (%s)", text, url)
+		})
+		body.WriteString(fmt.Sprintf("• %s: %s\n", field.Name, plain))
+		formatted.WriteString(fmt.Sprintf("<li>%s: %s</li>", html.EscapeString(field.Name), htmlLinks(field.Value)))
+	}
+	formatted.WriteString("</ul>")
+	return body.String(), formatted.String()
+}
@@ -0,0 +1,211 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+)
+
+const (
+	queueSize = 1000
+	// maxAttempts the maximum number of attempts to deliver a message rate limited by the platform.
+	maxAttempts = 3
+)
+
+var (
+	// slackLink matches the slack links <url|text> of the alert message fields.
+	slackLink = regexp.MustCompile(`<([^|<>]+)\|([^<>]+)>`)
+
+	// emojis the unicode of the slack emoji names of the alert messages.
+	emojis = map[string]string{
+		"bangbang":           "‼️",
+		"information_source": "ℹ️",
+		"rotating_light":     "\U0001f6a8",
+		"warning":            "⚠️",
+		"white_check_mark":   "✅",
+	}
+
+	sinkSentTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_sink_sent_total",
+		Help: "The total number of alert messages sent by the alert sink.",
+	}, []string{"sink"})
+
+	sinkFailureTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_sink_failure_total",
+		Help: "The total number of alert messages the alert sink failed to send or dropped.",
+	}, []string{"sink"})
+)
+
+// sender sends an alert message to a platform, a positive retryAfter asks to send it again after the rate limit.
+type sender interface {
+	send(ctx context.Context, msg *slack.Message) (retryAfter time.Duration, err error)
+}
+
+// Sink queues the alert messages of at least its minimum severity, and sends them one by one no faster than the
+// interval of the platform rate limit.
+type Sink struct {
+	name        string
+	minSeverity string
+	interval    time.Duration
+	sender      sender
+
+	ctx   context.Context
+	queue chan *slack.Message
+	stop  chan struct{}
+}
+
+func newSink(ctx context.Context, name, minSeverity string, interval time.Duration, s sender) (*Sink, error) {
+	if minSeverity != "" && !slack.ValidSeverity(minSeverity) {
+		return nil, fmt.Errorf("%s sink has invalid min severity %q", name, minSeverity)
+	}
+	return &Sink{
+		name:        name,
+		minSeverity: minSeverity,
+		interval:    interval,
+		sender:      s,
+		ctx:         ctx,
+		queue:       make(chan *slack.Message, queueSize),
+		stop:        make(chan struct{}),
+	}, nil
+}
+
+// Name returns the name of the sink.
+func (s *Sink) Name() string {
+	return s.name
+}
+
+// Notify queues the message if it's at least the minimum severity, the message is dropped if the queue is full.
+func (s *Sink) Notify(msg *slack.Message) {
+	if !msg.AtLeast(s.minSeverity) {
+		return
+	}
+	select {
+	case s.queue <- msg:
+	default:
+		log.Warn("alert sink queue is full, message dropped", "sink", s.name, "title", msg.Title)
+		sinkFailureTotal.WithLabelValues(s.name).Inc()
+	}
+}
+
+// Start the sink
+func (s *Sink) Start() {
+	go s.run()
+}
+
+// Stop the sink
+func (s *Sink) Stop() {
+	s.stop <- struct{}{}
+}
+
+func (s *Sink) run() {
+	for {
+		select {
+		case msg := <-s.queue:
+			s.deliver(msg)
+			if !s.wait(s.interval) {
+				return
+			}
+		case <-s.ctx.Done():
+			return
+		case <-s.stop:
+			log.Info("alert sink the run loop exit", "sink", s.name)
+			return
+		}
+	}
+}
+
+func (s *Sink) deliver(msg *slack.Message) {
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		retryAfter, err := s.sender.send(s.ctx, msg)
+		if err == nil {
+			sinkSentTotal.WithLabelValues(s.name).Inc()
+			return
+		}
+		if retryAfter <= 0 || attempt == maxAttempts {
+			log.Error("alert sink send message failed", "sink", s.name, "title", msg.Title, "attempts", attempt, "error", err)
+			sinkFailureTotal.WithLabelValues(s.name).Inc()
+			return
+		}
+		log.Warn("alert sink rate limited", "sink", s.name, "retry after", retryAfter)
+		if !s.wait(retryAfter) {
+			return
+		}
+	}
+}
+
+// wait waits for the duration, and returns false if the sink is stopped meanwhile.
+func (s *Sink) wait(d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+func newClient() *resty.Client {
+	cli := resty.New()
+	cli.SetRetryCount(3)
+	cli.SetTimeout(time.Second * 3)
+	return cli
+}
+
+func emoji(name string) string {
+	if e, exists := emojis[name]; exists {
+		return e
+	}
+	return fmt.Sprintf(":%s:", name)
+}
+
+// severity returns the severity shown by the sinks, the messages without severity are warning.
+func severity(msg *slack.Message) string {
+	if msg.Severity == "" {
+		return slack.SeverityWarning
+	}
+	return msg.Severity
+}
+
+// replaceLinks replaces the slack links of the text with the links of the platform, and formats the other text with
+// plain.
+func replaceLinks(text string, plain func(string) string, link func(url, text string) string) string {
+	var builder strings.Builder
+	last := 0
+	for _, match := range slackLink.FindAllStringSubmatchIndex(text, -1) {
+		builder.WriteString(plain(text[last:match[0]]))
+		builder.WriteString(link(text[match[2]:match[3]], text[match[4]:match[5]]))
+		last = match[1]
+	}
+	builder.WriteString(plain(text[last:]))
+	return builder.String()
+}
+
+func htmlLinks(text string) string {
+	return replaceLinks(text, html.EscapeString, func(url, text string) string {
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
+	})
+}
+
+// truncate truncates the string to at most n bytes on a rune boundary.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := n - len("...")
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
@@ -0,0 +1,111 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+)
+
+func newTestMessage() *slack.Message {
+	msg := &slack.Message{Emoji: "rotating_light", Title: "Contract state invariant violated", Severity: "critical"}
+	msg.AddField("invariant", "paused <= 0 & 1")
+	msg.AddField("tx_hash", "<https://sepolia.etherscan.io/tx/0x01|0x01>")
+	return msg
+}
+
+func TestDiscordSinkRateLimited(t *testing.T) {
+	var requests []map[string][]discordEmbed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]discordEmbed
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		requests = append(requests, body)
+		if len(requests) == 1 {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message":"You are being rate limited.","retry_after":0.01,"global":false}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	s, err := NewDiscordSink(context.Background(), &config.DiscordConfig{WebhookURL: server.URL, MinSeverity: "high"})
+	require.NoError(t, err)
+	s.deliver(newTestMessage())
+
+	require.Len(t, requests, 2)
+	embeds := requests[1]["embeds"]
+	require.Len(t, embeds, 1)
+	assert.Equal(t, "\U0001f6a8 Contract state invariant violated", embeds[0].Title)
+	assert.Equal(t, 0xe01e5a, embeds[0].Color)
+	assert.Equal(t, "[0x01](https://sepolia.etherscan.io/tx/0x01)", embeds[0].Fields[1].Value)
+}
+
+func TestSinkMinSeverity(t *testing.T) {
+	s, err := NewDiscordSink(context.Background(), &config.DiscordConfig{WebhookURL: "http://localhost", MinSeverity: "critical"})
+	require.NoError(t, err)
+
+	s.Notify(&slack.Message{Title: "Large bridge transfer", Severity: "high"})
+	s.Notify(&slack.Message{Title: "Gateway event duplicated"})
+	assert.Len(t, s.queue, 0)
+	s.Notify(newTestMessage())
+	assert.Len(t, s.queue, 1)
+
+	_, err = NewDiscordSink(context.Background(), &config.DiscordConfig{WebhookURL: "http://localhost", MinSeverity: "urgent"})
+	assert.Error(t, err)
+}
+
+func TestTelegramSink(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/bot123:token/sendMessage", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer server.Close()
+
+	s, err := NewTelegramSink(context.Background(), &config.TelegramConfig{BotToken: "123:token", ChatID: "-100123", APIURL: server.URL})
+	require.NoError(t, err)
+	retryAfter, err := s.sender.send(context.Background(), newTestMessage())
+	require.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	assert.Equal(t, "-100123", body["chat_id"])
+	assert.Equal(t, "HTML", body["parse_mode"])
+	assert.Equal(t, "\U0001f6a8 <b>Contract state invariant violated</b>\n"+
+		"• severity: <b>critical</b>\n"+
+		"• invariant: paused &lt;= 0 &amp; 1\n"+
+		"• tx_hash: <a href=\"https://sepolia.etherscan.io/tx/0x01\">0x01</a>\n", body["text"])
+}
+
+func TestMatrixSink(t *testing.T) {
+	var body map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.True(t, strings.HasPrefix(r.URL.EscapedPath(), "/_matrix/client/v3/rooms/%21alerts:example.org/send/m.room.message/"))
+		assert.Equal(t, "Bearer syt_token", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+	}))
+	defer server.Close()
+
+	s, err := NewMatrixSink(context.Background(), &config.MatrixConfig{HomeserverURL: server.URL, AccessToken: "syt_token", RoomID: "!alerts:example.org"})
+	require.NoError(t, err)
+	_, err = s.sender.send(context.Background(), newTestMessage())
+	require.NoError(t, err)
+
+	assert.Equal(t, "m.notice", body["msgtype"])
+	assert.Contains(t, body["body"], "• tx_hash: 0x01 (https://sepolia.etherscan.io/tx/0x01)\n")
+	assert.Contains(t, body["formatted_body"], `<li>tx_hash: <a href="https://sepolia.etherscan.io/tx/0x01">0x01</a></li>`)
+}
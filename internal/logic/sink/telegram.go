@@ -0,0 +1,92 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+)
+
+const (
+	defaultTelegramAPIURL = "https://api.telegram.org"
+	// telegramInterval a bot sends at most 20 messages per minute to a group.
+	telegramInterval = 3 * time.Second
+	// telegramMaxText the maximum length of a message text.
+	telegramMaxText = 4096
+)
+
+type telegramSender struct {
+	cfg *config.TelegramConfig
+	cli *resty.Client
+}
+
+// NewTelegramSink creates the sink sending the alerts as html messages to the telegram chat through the bot api.
+func NewTelegramSink(ctx context.Context, cfg *config.TelegramConfig) (*Sink, error) {
+	if cfg.BotToken == "" || cfg.ChatID == "" {
+		return nil, fmt.Errorf("telegram sink has no bot token or chat id")
+	}
+	return newSink(ctx, "telegram", cfg.MinSeverity, telegramInterval, &telegramSender{cfg: cfg, cli: newClient()})
+}
+
+func (t *telegramSender) send(ctx context.Context, msg *slack.Message) (time.Duration, error) {
+	apiURL := t.cfg.APIURL
+	if apiURL == "" {
+		apiURL = defaultTelegramAPIURL
+	}
+
+	var result struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+		Parameters  struct {
+			RetryAfter int `json:"retry_after"`
+		} `json:"parameters"`
+	}
+	resp, err := t.cli.R().
+		SetContext(ctx).
+		SetBody(map[string]interface{}{
+			"chat_id":                  t.cfg.ChatID,
+			"text":                     telegramText(msg),
+			"parse_mode":               "HTML",
+			"disable_web_page_preview": true,
+		}).
+		SetResult(&result).
+		SetError(&result).
+		Post(fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(apiURL, "/"), t.cfg.BotToken))
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode() == http.StatusTooManyRequests {
+		retryAfter := time.Duration(result.Parameters.RetryAfter) * time.Second
+		if retryAfter <= 0 {
+			retryAfter = telegramInterval
+		}
+		return retryAfter, fmt.Errorf("telegram bot api rate limited: %s", result.Description)
+	}
+	if resp.IsError() || !result.OK {
+		return 0, fmt.Errorf("telegram bot api status: %s, description: %s", resp.Status(), result.Description)
+	}
+	return 0, nil
+}
+
+// telegramText formats the message as html, the fields beyond the text limit are omitted.
+func telegramText(msg *slack.Message) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("%s <b>%s</b>\n", emoji(msg.Emoji), html.EscapeString(msg.Title)))
+	builder.WriteString(fmt.Sprintf("• severity: <b>%s</b>\n", html.EscapeString(severity(msg))))
+	for _, field := range msg.Fields {
+		line := fmt.Sprintf("• %s: %s\n", html.EscapeString(field.Name), htmlLinks(field.Value))
+		if builder.Len()+len(line) > telegramMaxText-len("…") {
+			builder.WriteString("…")
+			break
+		}
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
@@ -1,14 +1,18 @@
 package slack
 
 import (
-	"bytes"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/shopspring/decimal"
 
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/explorer"
+	"github.com/scroll-tech/chain-monitor/internal/logic/token"
 	"github.com/scroll-tech/chain-monitor/internal/orm"
 	"github.com/scroll-tech/chain-monitor/internal/types"
 )
@@ -48,6 +52,89 @@ var (
 		Name: "slack_alert_messenger_event_duplicated_total",
 		Help: "The total number of alert messenger event duplicated.",
 	})
+
+	messengerFailedRelayTotal = promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+		Name: "slack_alert_messenger_failed_relay_total",
+		Help: "The total number of alert messenger message relay failed repeatedly.",
+	})
+
+	messengerDropMessageTotal = promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+		Name: "slack_alert_messenger_drop_message_total",
+		Help: "The total number of alert messenger high value message dropped.",
+	})
+
+	governanceEventTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_governance_event_total",
+		Help: "The total number of alert bridge contract governance event.",
+	}, []string{"layer", "event_type"})
+
+	proxyDriftTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_proxy_drift_total",
+		Help: "The total number of alert bridge proxy drift from the pinned baseline.",
+	}, []string{"layer", "announced"})
+
+	reserveImbalanceTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_reserve_imbalance_total",
+		Help: "The total number of alert l1 escrow and l2 supply imbalance exceeding the tolerance.",
+	}, []string{"token"})
+
+	unauthorizedMintBurnTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_unauthorized_mint_burn_total",
+		Help: "The total number of alert bridged l2 token minted or burned without gateway event.",
+	}, []string{"token_type", "mint"})
+
+	messagePayloadNotMatchTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_message_payload_not_match_total",
+		Help: "The total number of alert messenger message payload not match the gateway event.",
+	}, []string{"layer", "event_type"})
+
+	relayedMessageHashNotMatchTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_relayed_message_hash_not_match_total",
+		Help: "The total number of alert relay transaction calldata not match the relayed message hash.",
+	}, []string{"layer"})
+
+	messageNonceNotContinuousTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_message_nonce_not_continuous_total",
+		Help: "The total number of alert messenger message nonce missing, duplicated or not match the message queue.",
+	}, []string{"layer", "kind"})
+
+	messengerBalanceDriftTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_messenger_balance_drift_total",
+		Help: "The total number of alert tracked messenger eth balance drift from the balance on chain.",
+	}, []string{"layer"})
+
+	messengerOutflowUnattributedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_messenger_outflow_unattributed_total",
+		Help: "The total number of alert value transferred out of the messenger not attributed to a message.",
+	}, []string{"layer", "method"})
+
+	watchedEventTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_watched_event_total",
+		Help: "The total number of alert watched contract event matching the alert conditions.",
+	}, []string{"layer", "contract", "event"})
+
+	invariantViolationTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_invariant_violation_total",
+		Help: "The total number of alert contract state invariant violation.",
+	}, []string{"layer", "name"})
+
+	ruleAlertTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_rule_total",
+		Help: "The total number of alert detection rule.",
+	}, []string{"rule", "severity"})
+	largeTransferTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_large_transfer_total",
+		Help: "The total number of alert large deposit or withdrawal.",
+	}, []string{"layer", "token"})
+	outflowVelocityTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_outflow_velocity_total",
+		Help: "The total number of alert l1 escrow net outflow exceeding the limit within the window.",
+	}, []string{"token"})
+
+	messengerRelayResolvedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "slack_alert_messenger_relay_resolved_total",
+		Help: "The total number of alerted messenger message relayed successfully afterwards.",
+	}, []string{"layer"})
 )
 
 // GatewayTransferInfo the alert message of gateway and transfer event
@@ -69,141 +156,799 @@ type WithdrawRootInfo struct {
 	BlockNumber          uint64
 	LastWithdrawRoot     common.Hash
 	ExpectedWithdrawRoot common.Hash
+	SentMessageCount     int
+}
+
+// MessengerFailureInfo the alert message of messenger failed relay and drop info
+type MessengerFailureInfo struct {
+	Layer        types.LayerType
+	EventType    types.EventType
+	BlockNumber  uint64
+	TxHash       common.Hash
+	MessageHash  common.Hash
+	MessageNonce *big.Int
+	Value        *big.Int
+	FailedTimes  int
+}
+
+// ProxyDriftField the drifted field of a bridge proxy, the governance event announcing it is empty if there is none
+type ProxyDriftField struct {
+	Field            string
+	Baseline         string
+	Current          string
+	GovernanceTxHash string
+}
+
+// ProxyDriftInfo the alert message of bridge proxy drift info
+type ProxyDriftInfo struct {
+	Layer               types.LayerType
+	Name                string
+	ContractAddress     common.Address
+	BaselineBlockNumber uint64
+	BlockNumber         uint64
+	Fields              []ProxyDriftField
+}
+
+// ReserveImbalanceInfo the alert message of l1 escrow and l2 supply imbalance info
+type ReserveImbalanceInfo struct {
+	Name            string
+	L1Gateway       common.Address
+	L1Token         common.Address
+	L2Token         common.Address
+	L1BlockNumber   uint64
+	L2BlockNumber   uint64
+	L1EscrowBalance *big.Int
+	L2TotalSupply   *big.Int
+	InFlight        *big.Int
+	Imbalance       *big.Int
+	Tolerance       *big.Int
+}
+
+// UnauthorizedMintBurnInfo the alert message of bridged l2 token minted or burned without gateway event info
+type UnauthorizedMintBurnInfo struct {
+	TokenAddress common.Address
+	TokenType    types.TokenType
+	TokenID      string
+	Mint         bool
+	// Amount the minted or burned amount, GatewayAmount the amount the gateway events of the transaction claim.
+	Amount        *big.Int
+	GatewayAmount *big.Int
+	BlockNumber   uint64
+	TxHash        common.Hash
+}
+
+// MessagePayloadField a field of the messenger message payload which differs from the gateway event
+type MessagePayloadField struct {
+	Name    string
+	Event   string
+	Payload string
+}
+
+// MessagePayloadInfo the alert message of messenger message payload not match the gateway event info
+type MessagePayloadInfo struct {
+	Layer       types.LayerType
+	EventType   types.EventType
+	TokenType   types.TokenType
+	BlockNumber uint64
+	TxHash      common.Hash
+	MessageHash common.Hash
+	Fields      []MessagePayloadField
+}
+
+// RelayedMessageHashInfo the alert message of relay transaction calldata not match the relayed message hash info
+type RelayedMessageHashInfo struct {
+	Layer              types.LayerType
+	EventType          types.EventType
+	BlockNumber        uint64
+	TxHash             common.Hash
+	MessageHash        common.Hash
+	RelayedMessageHash common.Hash
+	MessageNonce       *big.Int
+	Value              *big.Int
+}
+
+// MessageNonceInfo the alert message of messenger message nonce missing, duplicated or not match the message queue info
+type MessageNonceInfo struct {
+	Layer            types.LayerType
+	Kind             string
+	MessageNonce     uint64
+	LastMessageNonce uint64
+	QueueIndex       *uint64
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+	TxHashes         []common.Hash
+}
+
+// MessengerBalanceDriftInfo the alert message of tracked messenger eth balance drift from the balance on chain info
+type MessengerBalanceDriftInfo struct {
+	Layer              types.LayerType
+	TrackedBlockNumber uint64
+	BlockNumber        uint64
+	TrackedBalance     *big.Int
+	ActualBalance      *big.Int
+}
+
+// MessengerOutflowTransfer a value transfer out of the messenger
+type MessengerOutflowTransfer struct {
+	To    common.Address
+	Value *big.Int
+}
+
+// MessengerOutflowInfo the alert message of value transferred out of the messenger not attributed to a message info
+type MessengerOutflowInfo struct {
+	Layer             types.LayerType
+	BlockNumber       uint64
+	TxHash            common.Hash
+	Method            string
+	MessageHash       common.Hash
+	UnattributedValue *big.Int
+	Transfers         []MessengerOutflowTransfer
+}
+
+// InvariantInfo the alert message of a contract state invariant violation info
+type InvariantInfo struct {
+	Name        string
+	Layer       types.LayerType
+	Contract    common.Address
+	Method      string
+	Expression  string
+	BlockNumber uint64
+	Result      string
+	Previous    string
+}
+
+// RuleInfo the alert message of a detection rule info, the window fields are only set in the rules with a window
+type RuleInfo struct {
+	Name        string
+	Severity    string
+	Description string
+	Layer       types.LayerType
+	Kind        string
+	TokenType   string
+	L1Token     common.Address
+	Amount      string
+	From        common.Address
+	To          common.Address
+	BlockNumber uint64
+	TxHash      common.Hash
+	Window      string
+	Count       int
+	Sum         string
+}
+
+// LargeTransferInfo the alert message of a single deposit or withdrawal above the token threshold, in token units
+type LargeTransferInfo struct {
+	Token       string
+	L1Token     common.Address
+	Layer       types.LayerType
+	EventType   types.EventType
+	Amount      decimal.Decimal
+	Threshold   decimal.Decimal
+	BlockNumber uint64
+	TxHash      string
+	MessageHash string
+}
+
+// OutflowVelocityInfo the alert message of the l1 escrow net outflow exceeding the limit within the window, in token units
+type OutflowVelocityInfo struct {
+	Token            string
+	L1Token          common.Address
+	StartBlockNumber uint64
+	EndBlockNumber   uint64
+	Deposited        decimal.Decimal
+	Withdrawn        decimal.Decimal
+	NetOutflow       decimal.Decimal
+	Limit            decimal.Decimal
 }
 
 // MrkDwnWithdrawRootMessage make the markdown message of withdraw root alert message
-func MrkDwnWithdrawRootMessage(info WithdrawRootInfo) string {
+func MrkDwnWithdrawRootMessage(info WithdrawRootInfo) *Message {
 	withdrawRootNotMatchTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*L2 withdraw root check failed*\n")
-	buffer.WriteString(fmt.Sprintf("• block number: %d\n", info.BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• got withdraw root: %s\n", info.LastWithdrawRoot.Hex()))
-	buffer.WriteString(fmt.Sprintf("• excepted withdraw root: %s\n", info.ExpectedWithdrawRoot.Hex()))
-	return buffer.String()
+	msg := newMessage(kindWithdrawRoot, "bangbang", "L2 withdraw root check failed")
+	msg.Severity = SeverityCritical
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("sent messages in block", fmt.Sprintf("%d", info.SentMessageCount))
+	msg.AddField("got withdraw root", info.LastWithdrawRoot.Hex())
+	msg.AddField("excepted withdraw root", info.ExpectedWithdrawRoot.Hex())
+	msg.setLayer(types.Layer2)
+	return msg
 }
 
 // MrkDwnGatewayTransferMessage make the markdown message of gateway and transfer alert message
-func MrkDwnGatewayTransferMessage(info GatewayTransferInfo) string {
+func MrkDwnGatewayTransferMessage(info GatewayTransferInfo) *Message {
 	gatewayTransferEventNotMatchTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*Gateway event and transfer event check failed*\n")
-	buffer.WriteString(fmt.Sprintf("• token type: %s\n", info.TokenType.String()))
-	buffer.WriteString(fmt.Sprintf("• layer type: %s\n", info.Layer.String()))
-	buffer.WriteString(fmt.Sprintf("• event type: %s\n", info.EventType.String()))
-	buffer.WriteString(fmt.Sprintf("• block number: %d\n", info.BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• tx_hash: %s\n", info.TxHash.Hex()))
-	buffer.WriteString(fmt.Sprintf("• msg_hash: %s\n", info.MessageHash.Hex()))
-	buffer.WriteString(fmt.Sprintf("• transfer balance: %s\n", info.TransferBalance.String()))
-	buffer.WriteString(fmt.Sprintf("• gateway balance: %s\n", info.GatewayBalance.String()))
-	buffer.WriteString(fmt.Sprintf("• err info:%s\n", info.Error))
-	return buffer.String()
+	msg := newMessage(kindGatewayTransfer, "bangbang", "Gateway event and transfer event check failed")
+	msg.Severity = SeverityCritical
+	msg.AddField("token type", info.TokenType.String())
+	msg.AddField("layer type", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.MessageHash = info.MessageHash.Hex()
+	msg.AddField("msg_hash", explorer.Message(info.MessageHash.Hex()))
+	msg.AddField("token", tokenAddress(info.Layer, info.TokenAddress))
+	if info.TokenType == types.TokenTypeERC20 || info.TokenType == types.TokenTypeETH {
+		msg.AddField("transfer balance", token.FormatAmount(info.Layer, info.TokenAddress, info.TransferBalance))
+		msg.AddField("gateway balance", token.FormatAmount(info.Layer, info.TokenAddress, info.GatewayBalance))
+	} else {
+		msg.AddField("transfer balance", info.TransferBalance.String())
+		msg.AddField("gateway balance", info.GatewayBalance.String())
+	}
+	msg.AddField("err info", info.Error)
+	msg.setLayer(info.Layer)
+	msg.addTokens(info.Layer, info.TokenAddress)
+	return msg
 }
 
 // MrkDwnGatewayCrossChainMessage make the markdown message of cross chain alert message
-func MrkDwnGatewayCrossChainMessage(message orm.GatewayMessageMatch, checkResult types.MismatchType) string {
+func MrkDwnGatewayCrossChainMessage(message orm.GatewayMessageMatch, checkResult types.MismatchType) *Message {
 	crossChainGatewayEventNotMatchTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*Cross chain gateway event check failed*\n")
-	buffer.WriteString(fmt.Sprintf("• database id: %d\n", message.ID))
-	buffer.WriteString(fmt.Sprintf("• token type: %s\n", types.TokenType(message.TokenType).String()))
-	buffer.WriteString(fmt.Sprintf("• l1 event type: %s\n", types.EventType(message.L1EventType).String()))
-	buffer.WriteString(fmt.Sprintf("• l2 event type: %s\n", types.EventType(message.L2EventType).String()))
-	buffer.WriteString(fmt.Sprintf("• mismatch type: %s\n", checkResult.String()))
-	buffer.WriteString(fmt.Sprintf("• l1 block number: %d\n", message.L1BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• l2 block number: %d\n", message.L2BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• l1 mount: %s\n", message.L1Amounts))
-	buffer.WriteString(fmt.Sprintf("• l2 mount: %s\n", message.L2Amounts))
-	buffer.WriteString(fmt.Sprintf("• l1 token: %s\n", message.L1TokenIds))
-	buffer.WriteString(fmt.Sprintf("• l2 token: %s\n", message.L2TokenIds))
-	buffer.WriteString(fmt.Sprintf("• l1 tx_hash: %s\n", message.L1TxHash))
-	buffer.WriteString(fmt.Sprintf("• l2 tx_hash: %s\n", message.L2TxHash))
-	buffer.WriteString(fmt.Sprintf("• msg_hash: %s\n", message.MessageHash))
-	return buffer.String()
+	msg := newMessage(kindGatewayCrossChain, "bangbang", "Cross chain gateway event check failed")
+	msg.Severity = SeverityCritical
+	msg.AddField("database id", fmt.Sprintf("%d", message.ID))
+	msg.AddField("token type", types.TokenType(message.TokenType).String())
+	msg.AddField("l1 event type", types.EventType(message.L1EventType).String())
+	msg.AddField("l2 event type", types.EventType(message.L2EventType).String())
+	msg.AddField("mismatch type", checkResult.String())
+	msg.AddField("l1 block number", fmt.Sprintf("%d", message.L1BlockNumber))
+	msg.AddField("l2 block number", fmt.Sprintf("%d", message.L2BlockNumber))
+	if types.TokenType(message.TokenType) == types.TokenTypeERC20 {
+		msg.AddField("l1 mount", token.FormatString(types.Layer1, common.HexToAddress(message.L1L1Token), message.L1Amounts))
+		msg.AddField("l2 mount", token.FormatString(types.Layer2, common.HexToAddress(message.L2L2Token), message.L2Amounts))
+	} else {
+		msg.AddField("l1 mount", message.L1Amounts)
+		msg.AddField("l2 mount", message.L2Amounts)
+	}
+	msg.AddField("l1 token", message.L1TokenIds)
+	msg.AddField("l2 token", message.L2TokenIds)
+	msg.AddField("l1 event from", fmt.Sprintf("%s, to: %s", hexAddress(types.Layer1, message.L1From), hexAddress(types.Layer1, message.L1To)))
+	msg.AddField("l2 event from", fmt.Sprintf("%s, to: %s", hexAddress(types.Layer2, message.L2From), hexAddress(types.Layer2, message.L2To)))
+	msg.AddField("l1 event l1 token address", fmt.Sprintf("%s, l2 token address: %s", hexAddress(types.Layer1, message.L1L1Token), hexAddress(types.Layer2, message.L1L2Token)))
+	msg.AddField("l2 event l1 token address", fmt.Sprintf("%s, l2 token address: %s", hexAddress(types.Layer1, message.L2L1Token), hexAddress(types.Layer2, message.L2L2Token)))
+	msg.AddField("l1 tx_hash", explorer.Tx(types.Layer1, message.L1TxHash))
+	msg.AddField("l2 tx_hash", explorer.Tx(types.Layer2, message.L2TxHash))
+	msg.MessageHash = message.MessageHash
+	msg.AddField("msg_hash", explorer.Message(message.MessageHash))
+	msg.addTokens(types.Layer1, hexAddresses(message.L1L1Token, message.L2L1Token)...)
+	msg.addTokens(types.Layer2, hexAddresses(message.L1L2Token, message.L2L2Token)...)
+	msg.addAddresses(hexAddresses(message.L1From, message.L1To, message.L2From, message.L2To)...)
+	return msg
 }
 
 // MrkDwnETHCrossChainMessage make the markdown message of cross chain alert message
-func MrkDwnETHCrossChainMessage(message orm.MessengerMessageMatch, checkResult types.MismatchType) string {
+func MrkDwnETHCrossChainMessage(message orm.MessengerMessageMatch, checkResult types.MismatchType) *Message {
 	crossChainETHEventNotMatchTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*Cross chain messenger event check failed*\n")
-	buffer.WriteString(fmt.Sprintf("• database id: %d\n", message.ID))
-	buffer.WriteString(fmt.Sprintf("• l1 event type: %s\n", types.EventType(message.L1EventType).String()))
-	buffer.WriteString(fmt.Sprintf("• l2 event type: %s\n", types.EventType(message.L2EventType).String()))
-	buffer.WriteString(fmt.Sprintf("• mismatch type: %s\n", checkResult.String()))
-	buffer.WriteString(fmt.Sprintf("• l1 block number: %d\n", message.L1BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• l2 block number: %d\n", message.L2BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• l1 tx_hash: %s\n", message.L1TxHash))
-	buffer.WriteString(fmt.Sprintf("• l2 tx_hash: %s\n", message.L2TxHash))
-	buffer.WriteString(fmt.Sprintf("• msg_hash: %s\n", message.MessageHash))
-	return buffer.String()
+	msg := newMessage(kindMessengerCrossChain, "bangbang", "Cross chain messenger event check failed")
+	msg.Severity = SeverityCritical
+	msg.AddField("database id", fmt.Sprintf("%d", message.ID))
+	msg.AddField("l1 event type", types.EventType(message.L1EventType).String())
+	msg.AddField("l2 event type", types.EventType(message.L2EventType).String())
+	msg.AddField("mismatch type", checkResult.String())
+	msg.AddField("l1 block number", fmt.Sprintf("%d", message.L1BlockNumber))
+	msg.AddField("l2 block number", fmt.Sprintf("%d", message.L2BlockNumber))
+	msg.AddField("l1 tx_hash", explorer.Tx(types.Layer1, message.L1TxHash))
+	msg.AddField("l2 tx_hash", explorer.Tx(types.Layer2, message.L2TxHash))
+	msg.MessageHash = message.MessageHash
+	msg.AddField("msg_hash", explorer.Message(message.MessageHash))
+	msg.addTokens(types.Layer1, common.Address{})
+	return msg
 }
 
 // MrkDwnETHGatewayMessage make the markdown message of cross chain eth alert message
-func MrkDwnETHGatewayMessage(message *orm.MessengerMessageMatch, expectedEndBalance, actualEndBalance *big.Int) string {
+func MrkDwnETHGatewayMessage(message *orm.MessengerMessageMatch, expectedEndBalance, actualEndBalance *big.Int) *Message {
 	crossChainETHEventBalanceNotMatchTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*Cross chain ETH balance check failed*\n")
-	buffer.WriteString(fmt.Sprintf("• database id: %d\n", message.ID))
-	buffer.WriteString(fmt.Sprintf("• l1 event type: %s\n", types.EventType(message.L1EventType).String()))
-	buffer.WriteString(fmt.Sprintf("• l2 event type: %s\n", types.EventType(message.L2EventType).String()))
-	buffer.WriteString(fmt.Sprintf("• l1 block number: %d\n", message.L1BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• l2 block number: %d\n", message.L2BlockNumber))
-	buffer.WriteString(fmt.Sprintf("• l1 tx_hash: %s\n", message.L1TxHash))
-	buffer.WriteString(fmt.Sprintf("• l2 tx_hash: %s\n", message.L2TxHash))
-	buffer.WriteString(fmt.Sprintf("• msg_hash: %s\n", message.MessageHash))
-	buffer.WriteString(fmt.Sprintf("• expected end balance: %s\n", expectedEndBalance.String()))
-	buffer.WriteString(fmt.Sprintf("• actual end balance: %s\n", actualEndBalance.String()))
-	return buffer.String()
+	msg := newMessage(kindETHBalance, "bangbang", "Cross chain ETH balance check failed")
+	msg.Severity = SeverityCritical
+	msg.AddField("database id", fmt.Sprintf("%d", message.ID))
+	msg.AddField("l1 event type", types.EventType(message.L1EventType).String())
+	msg.AddField("l2 event type", types.EventType(message.L2EventType).String())
+	msg.AddField("l1 block number", fmt.Sprintf("%d", message.L1BlockNumber))
+	msg.AddField("l2 block number", fmt.Sprintf("%d", message.L2BlockNumber))
+	msg.AddField("l1 tx_hash", explorer.Tx(types.Layer1, message.L1TxHash))
+	msg.AddField("l2 tx_hash", explorer.Tx(types.Layer2, message.L2TxHash))
+	msg.MessageHash = message.MessageHash
+	msg.AddField("msg_hash", explorer.Message(message.MessageHash))
+	msg.AddField("expected end balance", token.FormatAmount(types.Layer1, common.Address{}, expectedEndBalance))
+	msg.AddField("actual end balance", token.FormatAmount(types.Layer1, common.Address{}, actualEndBalance))
+	msg.addTokens(types.Layer1, common.Address{})
+	return msg
 }
 
 // MrkDwnGatewayMessageMatchDuplicated make the markdown message of duplicated gateway message
-func MrkDwnGatewayMessageMatchDuplicated(layer types.LayerType, message orm.GatewayMessageMatch) string {
+func MrkDwnGatewayMessageMatchDuplicated(layer types.LayerType, message orm.GatewayMessageMatch) *Message {
 	gatewayEventDuplicatedTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*Gateway event duplicated*\n")
-	buffer.WriteString(fmt.Sprintf("• layer: %s\n", layer.String()))
+	msg := newMessage(kindGatewayDuplicated, "information_source", "Gateway event duplicated")
+	msg.Severity = SeverityInfo
+	msg.AddField("layer", layer.String())
 	if layer == types.Layer1 {
-		buffer.WriteString(fmt.Sprintf("• l1 event type: %s\n", types.EventType(message.L1EventType).String()))
-		buffer.WriteString(fmt.Sprintf("• l1 block number: %d\n", message.L1BlockNumber))
-		buffer.WriteString(fmt.Sprintf("• l1 tx_hash: %s\n", message.L1TxHash))
+		msg.AddField("l1 event type", types.EventType(message.L1EventType).String())
+		msg.AddField("l1 block number", fmt.Sprintf("%d", message.L1BlockNumber))
+		msg.AddField("l1 tx_hash", explorer.Tx(types.Layer1, message.L1TxHash))
 	} else {
-		buffer.WriteString(fmt.Sprintf("• l2 event type: %s\n", types.EventType(message.L2EventType).String()))
-		buffer.WriteString(fmt.Sprintf("• l2 block number: %d\n", message.L2BlockNumber))
-		buffer.WriteString(fmt.Sprintf("• l2 tx_hash: %s\n", message.L2TxHash))
+		msg.AddField("l2 event type", types.EventType(message.L2EventType).String())
+		msg.AddField("l2 block number", fmt.Sprintf("%d", message.L2BlockNumber))
+		msg.AddField("l2 tx_hash", explorer.Tx(types.Layer2, message.L2TxHash))
 	}
-	buffer.WriteString(fmt.Sprintf("• msg_hash: %s\n", message.MessageHash))
-	return buffer.String()
+	msg.MessageHash = message.MessageHash
+	msg.AddField("msg_hash", explorer.Message(message.MessageHash))
+	msg.setLayer(layer)
+	return msg
 }
 
 // MrkDwnMessengerMessageMatchDuplicated make the markdown message of duplicated messenger message
-func MrkDwnMessengerMessageMatchDuplicated(layer types.LayerType, message orm.MessengerMessageMatch) string {
+func MrkDwnMessengerMessageMatchDuplicated(layer types.LayerType, message orm.MessengerMessageMatch) *Message {
 	messengerEventDuplicatedTotal.Inc()
 
-	var buffer bytes.Buffer
-	buffer.WriteString("\n:bangbang: ")
-	buffer.WriteString("*Messenger event duplicated*\n")
-	buffer.WriteString(fmt.Sprintf("• layer: %s\n", layer.String()))
+	msg := newMessage(kindMessengerDuplicated, "information_source", "Messenger event duplicated")
+	msg.Severity = SeverityInfo
+	msg.AddField("layer", layer.String())
 	if layer == types.Layer1 {
-		buffer.WriteString(fmt.Sprintf("• l1 event type: %s\n", types.EventType(message.L1EventType).String()))
-		buffer.WriteString(fmt.Sprintf("• l1 block number: %d\n", message.L1BlockNumber))
-		buffer.WriteString(fmt.Sprintf("• l1 tx_hash: %s\n", message.L1TxHash))
+		msg.AddField("l1 event type", types.EventType(message.L1EventType).String())
+		msg.AddField("l1 block number", fmt.Sprintf("%d", message.L1BlockNumber))
+		msg.AddField("l1 tx_hash", explorer.Tx(types.Layer1, message.L1TxHash))
 	} else {
-		buffer.WriteString(fmt.Sprintf("• l2 event type: %s\n", types.EventType(message.L2EventType).String()))
-		buffer.WriteString(fmt.Sprintf("• l2 block number: %d\n", message.L2BlockNumber))
-		buffer.WriteString(fmt.Sprintf("• l2 tx_hash: %s\n", message.L2TxHash))
+		msg.AddField("l2 event type", types.EventType(message.L2EventType).String())
+		msg.AddField("l2 block number", fmt.Sprintf("%d", message.L2BlockNumber))
+		msg.AddField("l2 tx_hash", explorer.Tx(types.Layer2, message.L2TxHash))
+	}
+	msg.MessageHash = message.MessageHash
+	msg.AddField("msg_hash", explorer.Message(message.MessageHash))
+	msg.setLayer(layer)
+	return msg
+}
+
+// MrkDwnMessengerFailedRelayMessage make the markdown message of repeated messenger relay failure
+func MrkDwnMessengerFailedRelayMessage(info MessengerFailureInfo) *Message {
+	messengerFailedRelayTotal.Inc()
+
+	msg := newMessage(kindMessengerFailedRelay, "warning", "Messenger message relay failed repeatedly")
+	msg.Severity = SeverityWarning
+	msg.relayFailure = true
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("failed times", fmt.Sprintf("%d", info.FailedTimes))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.MessageHash = info.MessageHash.Hex()
+	msg.AddField("msg_hash", explorer.Message(info.MessageHash.Hex()))
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// MrkDwnMessengerDropMessage make the markdown message of high value messenger message dropped
+func MrkDwnMessengerDropMessage(info MessengerFailureInfo) *Message {
+	messengerDropMessageTotal.Inc()
+
+	msg := newMessage(kindMessengerDrop, "warning", "High value messenger message dropped")
+	msg.Severity = SeverityWarning
+	msg.relayFailure = true
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("message nonce", info.MessageNonce.String())
+	msg.AddField("eth value", token.FormatAmount(info.Layer, common.Address{}, info.Value))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.MessageHash = info.MessageHash.Hex()
+	msg.AddField("msg_hash", explorer.Message(info.MessageHash.Hex()))
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// MrkDwnGovernanceEventMessage make the markdown message of bridge contract governance event, it's always warning severity
+func MrkDwnGovernanceEventMessage(event events.GovernanceEvent) *Message {
+	governanceEventTotal.WithLabelValues(event.Layer.String(), event.Type.String()).Inc()
+
+	msg := newMessage(kindGovernanceEvent, "rotating_light", "Bridge contract governance event")
+	msg.Severity = SeverityWarning
+	msg.AddField("layer", event.Layer.String())
+	msg.AddField("event type", event.Type.String())
+	msg.AddField("contract", explorer.Address(event.Layer, event.ContractAddress))
+	msg.AddField("block number", fmt.Sprintf("%d", event.Number))
+	msg.AddField("tx_hash", explorer.Tx(event.Layer, event.TxHash.Hex()))
+
+	names := make([]string, 0, len(event.Args))
+	for name := range event.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		msg.AddField(name, event.Args[name])
+	}
+	msg.setLayer(event.Layer)
+	msg.addAddresses(event.ContractAddress)
+	return msg
+}
+
+// MrkDwnProxyDriftMessage make the markdown message of bridge proxy drift, a drift not announced by a governance event is critical
+func MrkDwnProxyDriftMessage(info ProxyDriftInfo) *Message {
+	announced := true
+	for _, field := range info.Fields {
+		if field.GovernanceTxHash == "" {
+			announced = false
+		}
+	}
+	proxyDriftTotal.WithLabelValues(info.Layer.String(), fmt.Sprintf("%t", announced)).Inc()
+
+	var msg *Message
+	if announced {
+		msg = newMessage(kindProxyDrift, "warning", "Bridge proxy drift from baseline")
+		msg.Severity = SeverityWarning
+	} else {
+		msg = newMessage(kindProxyDrift, "rotating_light", "Bridge proxy drift without governance event")
+		msg.Severity = SeverityCritical
+	}
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("contract", fmt.Sprintf("%s (%s)", info.Name, explorer.AddressLink(info.Layer, info.ContractAddress)))
+	msg.AddField("baseline block number", fmt.Sprintf("%d", info.BaselineBlockNumber))
+	msg.AddField("checked block number", fmt.Sprintf("%d", info.BlockNumber))
+	for _, field := range info.Fields {
+		governanceTxHash := field.GovernanceTxHash
+		if governanceTxHash == "" {
+			governanceTxHash = "none"
+		} else {
+			governanceTxHash = explorer.Tx(info.Layer, governanceTxHash)
+		}
+		msg.AddField(field.Field, fmt.Sprintf("%s -> %s (governance tx_hash: %s)", field.Baseline, field.Current, governanceTxHash))
+	}
+	msg.setLayer(info.Layer)
+	msg.addAddresses(info.ContractAddress)
+	return msg
+}
+
+// MrkDwnReserveImbalanceMessage make the markdown message of l1 escrow and l2 supply imbalance, an l2 supply
+// exceeding the escrow is critical since the bridged tokens are not fully backed
+func MrkDwnReserveImbalanceMessage(info ReserveImbalanceInfo) *Message {
+	reserveImbalanceTotal.WithLabelValues(info.Name).Inc()
+
+	var msg *Message
+	if info.Imbalance.Sign() < 0 {
+		msg = newMessage(kindReserveImbalance, "rotating_light", "L2 supply exceeds L1 escrow")
+		msg.Severity = SeverityCritical
+	} else {
+		msg = newMessage(kindReserveImbalance, "warning", "L1 escrow exceeds L2 supply")
+		msg.Severity = SeverityWarning
+	}
+	msg.AddField("token", info.Name)
+	msg.AddField("l1 gateway", explorer.Address(types.Layer1, info.L1Gateway))
+	msg.AddField("l1 token", tokenAddress(types.Layer1, info.L1Token))
+	msg.AddField("l2 token", tokenAddress(types.Layer2, info.L2Token))
+	msg.AddField("l1 block number", fmt.Sprintf("%d", info.L1BlockNumber))
+	msg.AddField("l2 block number", fmt.Sprintf("%d", info.L2BlockNumber))
+	msg.AddField("l1 escrow balance", token.FormatAmount(types.Layer1, info.L1Token, info.L1EscrowBalance))
+	msg.AddField("l2 total supply", token.FormatAmount(types.Layer1, info.L1Token, info.L2TotalSupply))
+	msg.AddField("in flight", token.FormatAmount(types.Layer1, info.L1Token, info.InFlight))
+	msg.AddField("imbalance", token.FormatAmount(types.Layer1, info.L1Token, info.Imbalance))
+	msg.AddField("tolerance", token.FormatAmount(types.Layer1, info.L1Token, info.Tolerance))
+	msg.addTokens(types.Layer1, info.L1Token)
+	msg.addTokens(types.Layer2, info.L2Token)
+	msg.addAddresses(info.L1Gateway)
+	return msg
+}
+
+// MrkDwnUnauthorizedMintBurnMessage make the markdown message of bridged l2 token minted or burned in a different amount
+// than the gateway events claim, an excess mint is a potential infinite mint exploit
+func MrkDwnUnauthorizedMintBurnMessage(info UnauthorizedMintBurnInfo) *Message {
+	unauthorizedMintBurnTotal.WithLabelValues(info.TokenType.String(), fmt.Sprintf("%t", info.Mint)).Inc()
+
+	gatewayAmount := info.GatewayAmount
+	if gatewayAmount == nil {
+		gatewayAmount = big.NewInt(0)
+	}
+	excess := info.Amount.Cmp(gatewayAmount) > 0
+
+	var msg *Message
+	switch {
+	case info.Mint && excess:
+		msg = newMessage(kindUnauthorizedMintBurn, "rotating_light", "Bridged token minted beyond gateway finalize deposit, potential infinite mint")
+		msg.Severity = SeverityCritical
+	case excess:
+		msg = newMessage(kindUnauthorizedMintBurn, "warning", "Bridged token burned beyond gateway withdraw")
+		msg.Severity = SeverityWarning
+	case info.Mint:
+		msg = newMessage(kindUnauthorizedMintBurn, "warning", "Bridged token minted less than gateway finalize deposit")
+		msg.Severity = SeverityWarning
+	default:
+		msg = newMessage(kindUnauthorizedMintBurn, "warning", "Bridged token burned less than gateway withdraw")
+		msg.Severity = SeverityWarning
+	}
+	msg.AddField("token type", info.TokenType.String())
+	msg.AddField("token address", tokenAddress(types.Layer2, info.TokenAddress))
+	if info.TokenID != "" {
+		msg.AddField("token id", info.TokenID)
+	}
+	if info.TokenType == types.TokenTypeERC20 {
+		msg.AddField("amount", token.FormatAmount(types.Layer2, info.TokenAddress, info.Amount))
+		msg.AddField("gateway amount", token.FormatAmount(types.Layer2, info.TokenAddress, gatewayAmount))
+	} else {
+		msg.AddField("amount", info.Amount.String())
+		msg.AddField("gateway amount", gatewayAmount.String())
+	}
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(types.Layer2, info.TxHash.Hex()))
+	msg.setLayer(types.Layer2)
+	msg.addTokens(types.Layer2, info.TokenAddress)
+	return msg
+}
+
+// MrkDwnMessagePayloadMessage make the markdown message of the messenger message payload not match the gateway event,
+// the gateway emitted an event which differs from what it actually bridges
+func MrkDwnMessagePayloadMessage(info MessagePayloadInfo) *Message {
+	messagePayloadNotMatchTotal.WithLabelValues(info.Layer.String(), info.EventType.String()).Inc()
+
+	msg := newMessage(kindMessagePayload, "rotating_light", "Messenger message payload does not match the gateway event")
+	msg.Severity = SeverityCritical
+	msg.AddField("token type", info.TokenType.String())
+	msg.AddField("layer type", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.MessageHash = info.MessageHash.Hex()
+	msg.AddField("msg_hash", explorer.Message(info.MessageHash.Hex()))
+	for _, field := range info.Fields {
+		msg.AddField(field.Name, fmt.Sprintf("event %s, payload %s", field.Event, field.Payload))
+	}
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// MrkDwnRelayedMessageHashMessage make the markdown message of relay transaction calldata not match the relayed message hash
+func MrkDwnRelayedMessageHashMessage(info RelayedMessageHashInfo) *Message {
+	relayedMessageHashNotMatchTotal.WithLabelValues(info.Layer.String()).Inc()
+
+	msg := newMessage(kindRelayedMessageHash, "rotating_light", "Relay transaction calldata does not reproduce the relayed message hash")
+	msg.Severity = SeverityCritical
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("message nonce", info.MessageNonce.String())
+	msg.AddField("eth value", token.FormatAmount(info.Layer, common.Address{}, info.Value))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.MessageHash = info.MessageHash.Hex()
+	msg.AddField("event msg_hash", explorer.Message(info.MessageHash.Hex()))
+	msg.AddField("calldata msg_hash", info.RelayedMessageHash.Hex())
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// MrkDwnMessageNonceMessage make the markdown message of messenger message nonce missing, duplicated or not match the message queue
+func MrkDwnMessageNonceMessage(info MessageNonceInfo) *Message {
+	messageNonceNotContinuousTotal.WithLabelValues(info.Layer.String(), info.Kind).Inc()
+
+	msg := newMessage(kindMessageNonce, "rotating_light", fmt.Sprintf("Messenger message nonce %s", info.Kind))
+	msg.Severity = SeverityCritical
+	msg.AddField("layer", info.Layer.String())
+	if info.LastMessageNonce > info.MessageNonce {
+		msg.AddField("message nonces", fmt.Sprintf("%d - %d", info.MessageNonce, info.LastMessageNonce))
+	} else {
+		msg.AddField("message nonce", fmt.Sprintf("%d", info.MessageNonce))
+	}
+	if info.QueueIndex != nil {
+		msg.AddField("message queue index", fmt.Sprintf("%d", *info.QueueIndex))
+	}
+	msg.AddField("block range", fmt.Sprintf("%d - %d", info.StartBlockNumber, info.EndBlockNumber))
+	for _, txHash := range info.TxHashes {
+		msg.AddField("tx_hash", explorer.Tx(info.Layer, txHash.Hex()))
+	}
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// MrkDwnMessengerBalanceDriftMessage make the markdown message of tracked messenger eth balance drift from the balance on chain
+func MrkDwnMessengerBalanceDriftMessage(info MessengerBalanceDriftInfo) *Message {
+	messengerBalanceDriftTotal.WithLabelValues(info.Layer.String()).Inc()
+
+	msg := newMessage(kindMessengerBalanceDrift, "rotating_light", "Tracked messenger ETH balance drifts from the balance on chain")
+	msg.Severity = SeverityCritical
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("tracked block number", fmt.Sprintf("%d", info.TrackedBlockNumber))
+	msg.AddField("reconciled block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tracked balance", token.FormatAmount(info.Layer, common.Address{}, info.TrackedBalance))
+	msg.AddField("actual balance", token.FormatAmount(info.Layer, common.Address{}, info.ActualBalance))
+	msg.AddField("drift", token.FormatAmount(info.Layer, common.Address{}, new(big.Int).Sub(info.ActualBalance, info.TrackedBalance)))
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// MrkDwnMessengerOutflowMessage make the markdown message of value transferred out of the messenger not attributed to a message
+func MrkDwnMessengerOutflowMessage(info MessengerOutflowInfo) *Message {
+	messengerOutflowUnattributedTotal.WithLabelValues(info.Layer.String(), info.Method).Inc()
+
+	msg := newMessage(kindMessengerOutflow, "rotating_light", "ETH left the messenger without a message to attribute it to")
+	msg.Severity = SeverityCritical
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.AddField("messenger method", info.Method)
+	if info.MessageHash != (common.Hash{}) {
+		msg.MessageHash = info.MessageHash.Hex()
+		msg.AddField("msg_hash", explorer.Message(info.MessageHash.Hex()))
+	}
+	msg.AddField("unattributed value", token.FormatAmount(info.Layer, common.Address{}, info.UnattributedValue))
+	for _, transfer := range info.Transfers {
+		msg.AddField("transfer", fmt.Sprintf("%s to %s", token.FormatAmount(info.Layer, common.Address{}, transfer.Value), explorer.Address(info.Layer, transfer.To)))
+	}
+	msg.setLayer(info.Layer)
+	for _, transfer := range info.Transfers {
+		msg.addAddresses(transfer.To)
+	}
+	return msg
+}
+
+// MrkDwnWatchedEventMessage make the markdown message of a watched contract event matching its alert conditions,
+// the severity is warning if not configured
+func MrkDwnWatchedEventMessage(event events.WatchedEvent) *Message {
+	watchedEventTotal.WithLabelValues(event.Layer.String(), event.ContractName, event.EventName).Inc()
+
+	severity := SeverityWarning
+	if event.Alert != nil && event.Alert.Severity != "" {
+		severity = event.Alert.Severity
+	}
+
+	msg := newMessage(kindWatchedEvent, "rotating_light", "Watched contract event")
+	msg.Severity = severity
+	msg.AddField("layer", event.Layer.String())
+	msg.AddField("contract", fmt.Sprintf("%s (%s)", event.ContractName, explorer.AddressLink(event.Layer, event.ContractAddress)))
+	msg.AddField("event", event.EventName)
+	msg.AddField("block number", fmt.Sprintf("%d", event.Number))
+	msg.AddField("tx_hash", explorer.Tx(event.Layer, event.TxHash.Hex()))
+
+	names := make([]string, 0, len(event.Args))
+	for name := range event.Args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		msg.AddField(name, event.Args[name])
+	}
+	msg.setLayer(event.Layer)
+	msg.addAddresses(event.ContractAddress)
+	return msg
+}
+
+// MrkDwnInvariantMessage make the markdown message of a contract state invariant violation
+func MrkDwnInvariantMessage(info InvariantInfo) *Message {
+	invariantViolationTotal.WithLabelValues(info.Layer.String(), info.Name).Inc()
+
+	msg := newMessage(kindInvariant, "rotating_light", "Contract state invariant violated")
+	msg.Severity = SeverityCritical
+	msg.AddField("invariant", info.Name)
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("contract", explorer.Address(info.Layer, info.Contract))
+	msg.AddField("method", info.Method)
+	msg.AddField("expression", info.Expression)
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("result", info.Result)
+	if info.Previous != "" {
+		msg.AddField("previous", info.Previous)
+	}
+	msg.setLayer(info.Layer)
+	msg.addAddresses(info.Contract)
+	return msg
+}
+
+// MrkDwnRuleMessage make the markdown message of a detection rule alert, the severity is warning if the rule doesn't set it
+func MrkDwnRuleMessage(info RuleInfo) *Message {
+	severity := info.Severity
+	if severity == "" {
+		severity = SeverityWarning
+	}
+	ruleAlertTotal.WithLabelValues(info.Name, severity).Inc()
+
+	msg := newMessage(kindRule, "rotating_light", fmt.Sprintf("Detection rule %s", info.Name))
+	msg.Severity = severity
+	if info.Description != "" {
+		msg.AddField("description", info.Description)
+	}
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("kind", info.Kind)
+	msg.AddField("token", fmt.Sprintf("%s %s", info.TokenType, tokenAddress(types.Layer1, info.L1Token)))
+	if info.TokenType == "ERC20" || info.TokenType == "ETH" {
+		msg.AddField("amount", token.FormatString(types.Layer1, info.L1Token, info.Amount))
+	} else {
+		msg.AddField("amount", info.Amount)
+	}
+	msg.AddField("from", explorer.Address(info.Layer, info.From))
+	msg.AddField("to", explorer.Address(info.Layer, info.To))
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	if info.Window != "" {
+		msg.AddField("window", info.Window)
+		msg.AddField("count", fmt.Sprintf("%d", info.Count))
+		msg.AddField("sum", info.Sum)
+	}
+	msg.setLayer(info.Layer)
+	msg.addTokens(types.Layer1, info.L1Token)
+	msg.addAddresses(info.From, info.To)
+	return msg
+}
+
+// MrkDwnLargeTransferMessage make the markdown message of large deposit or withdrawal alert message
+func MrkDwnLargeTransferMessage(info LargeTransferInfo) *Message {
+	largeTransferTotal.WithLabelValues(info.Layer.String(), info.Token).Inc()
+
+	msg := newMessage(kindLargeTransfer, "warning", "Large bridge transfer")
+	msg.Severity = SeverityWarning
+	msg.AddField("token", info.Token)
+	msg.AddField("l1 token", tokenAddress(types.Layer1, info.L1Token))
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	metadata, _ := token.Lookup(types.Layer1, info.L1Token)
+	msg.AddField("amount", token.WithSymbol(info.Amount, metadata))
+	msg.AddField("threshold", token.WithSymbol(info.Threshold, metadata))
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash))
+	msg.MessageHash = info.MessageHash
+	msg.AddField("message_hash", explorer.Message(info.MessageHash))
+	msg.setLayer(info.Layer)
+	msg.addTokens(types.Layer1, info.L1Token)
+	return msg
+}
+
+// MrkDwnOutflowVelocityMessage make the markdown message of l1 escrow outflow velocity alert message
+func MrkDwnOutflowVelocityMessage(info OutflowVelocityInfo) *Message {
+	outflowVelocityTotal.WithLabelValues(info.Token).Inc()
+
+	msg := newMessage(kindOutflowVelocity, "rotating_light", "L1 escrow net outflow exceeds the limit")
+	msg.Severity = SeverityCritical
+	msg.AddField("token", info.Token)
+	msg.AddField("l1 token", tokenAddress(types.Layer1, info.L1Token))
+	msg.AddField("l1 blocks", fmt.Sprintf("%d - %d", info.StartBlockNumber, info.EndBlockNumber))
+	metadata, _ := token.Lookup(types.Layer1, info.L1Token)
+	msg.AddField("deposited", token.WithSymbol(info.Deposited, metadata))
+	msg.AddField("withdrawn", token.WithSymbol(info.Withdrawn, metadata))
+	msg.AddField("net outflow", token.WithSymbol(info.NetOutflow, metadata))
+	msg.AddField("limit", token.WithSymbol(info.Limit, metadata))
+	msg.setLayer(types.Layer1)
+	msg.addTokens(types.Layer1, info.L1Token)
+	return msg
+}
+
+// MrkDwnMessengerRelayResolvedMessage make the markdown message of an alerted messenger message relayed successfully,
+// it's posted as a follow-up of the earlier alerts of the message
+func MrkDwnMessengerRelayResolvedMessage(info MessengerFailureInfo) *Message {
+	messengerRelayResolvedTotal.WithLabelValues(info.Layer.String()).Inc()
+
+	msg := newMessage(kindMessengerRelayResolved, "white_check_mark", "Alerted messenger message relayed")
+	msg.Resolved = true
+	msg.AddField("layer", info.Layer.String())
+	msg.AddField("event type", info.EventType.String())
+	msg.AddField("block number", fmt.Sprintf("%d", info.BlockNumber))
+	msg.AddField("tx_hash", explorer.Tx(info.Layer, info.TxHash.Hex()))
+	msg.MessageHash = info.MessageHash.Hex()
+	msg.AddField("msg_hash", explorer.Message(info.MessageHash.Hex()))
+	msg.setLayer(info.Layer)
+	return msg
+}
+
+// tokenAddress returns the address link of the token with its symbol if the metadata of the token is cached.
+func tokenAddress(layer types.LayerType, address common.Address) string {
+	if metadata, exists := token.Lookup(layer, address); exists && metadata.Symbol != "" {
+		return fmt.Sprintf("%s %s", metadata.Symbol, explorer.Address(layer, address))
+	}
+	return explorer.Address(layer, address)
+}
+
+// hexAddresses returns the addresses stored as hex strings, the unset addresses are skipped.
+func hexAddresses(hexes ...string) []common.Address {
+	var addresses []common.Address
+	for _, hex := range hexes {
+		if hex != "" {
+			addresses = append(addresses, common.HexToAddress(hex))
+		}
+	}
+	return addresses
+}
+
+// hexAddress returns the link of an address stored as a hex string, the unset addresses are kept empty.
+func hexAddress(layer types.LayerType, address string) string {
+	if address == "" {
+		return address
 	}
-	buffer.WriteString(fmt.Sprintf("• msg_hash: %s\n", message.MessageHash))
-	return buffer.String()
+	return explorer.Address(layer, common.HexToAddress(address))
 }
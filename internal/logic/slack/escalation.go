@@ -0,0 +1,221 @@
+package slack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+)
+
+// escalationCheckInterval the interval the unacknowledged critical alerts are checked for escalation at.
+const escalationCheckInterval = 30 * time.Second
+
+// PendingAlert a critical alert pending acknowledgement, it's escalated through the escalation tiers unless it's
+// acknowledged by the api, by a reaction to its slack post or by the relay of its failed or dropped message.
+type PendingAlert struct {
+	ID          string    `json:"id"`
+	Kind        string    `json:"kind"`
+	Title       string    `json:"title"`
+	MessageHash string    `json:"message_hash,omitempty"`
+	NotifiedAt  time.Time `json:"notified_at"`
+	// Escalations the number of the escalation tiers the alert was escalated to.
+	Escalations int `json:"escalations"`
+}
+
+type pendingAlert struct {
+	PendingAlert
+	msg *Message
+	// channel and ts the slack post of the alert, the reactions to it acknowledge the alert.
+	channel string
+	ts      string
+}
+
+// track tracks the critical alert for escalation and adds its alert id.
+func (as *AlertSlack) track(msg *Message, now time.Time) {
+	as.pendingMu.Lock()
+	defer as.pendingMu.Unlock()
+	as.lastAlertID++
+	msg.alertID = strconv.FormatUint(as.lastAlertID, 10)
+	msg.AddField("alert_id", msg.alertID)
+	if len(as.pendingOrder) >= maxThreads {
+		as.forget(as.pendingOrder[0])
+	}
+	as.pending[msg.alertID] = &pendingAlert{
+		PendingAlert: PendingAlert{ID: msg.alertID, Kind: msg.Kind, Title: msg.Title, MessageHash: msg.MessageHash, NotifiedAt: now},
+		msg:          msg,
+	}
+	as.pendingOrder = append(as.pendingOrder, msg.alertID)
+}
+
+// posted records the slack post of the pending alert.
+func (as *AlertSlack) posted(alertID, channel, ts string) {
+	if alertID == "" {
+		return
+	}
+	as.pendingMu.Lock()
+	defer as.pendingMu.Unlock()
+	if alert, exists := as.pending[alertID]; exists {
+		alert.channel = channel
+		alert.ts = ts
+	}
+}
+
+// forget stops tracking the alert, the caller holds the pending lock.
+func (as *AlertSlack) forget(alertID string) {
+	delete(as.pending, alertID)
+	for i, id := range as.pendingOrder {
+		if id == alertID {
+			as.pendingOrder = append(as.pendingOrder[:i], as.pendingOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// resolve acknowledges the pending failed relay and drop alerts of the relayed message hash, the relay doesn't clear
+// the other alerts of the message hash, such as a payload mismatch.
+func (as *AlertSlack) resolve(messageHash string) {
+	as.pendingMu.Lock()
+	defer as.pendingMu.Unlock()
+	for _, id := range append([]string(nil), as.pendingOrder...) {
+		alert := as.pending[id]
+		if alert.MessageHash == messageHash && (alert.Kind == kindMessengerFailedRelay || alert.Kind == kindMessengerDrop) {
+			as.forget(id)
+			alertAcknowledgedTotal.WithLabelValues("resolved").Inc()
+		}
+	}
+}
+
+// Acknowledge acknowledges the pending critical alert, it's no longer escalated. It returns false if the alert isn't
+// pending.
+func Acknowledge(alertID string) bool {
+	alertSlack.pendingMu.Lock()
+	defer alertSlack.pendingMu.Unlock()
+	if _, exists := alertSlack.pending[alertID]; !exists {
+		return false
+	}
+	alertSlack.forget(alertID)
+	alertAcknowledgedTotal.WithLabelValues("api").Inc()
+	return true
+}
+
+// PendingAlerts returns the critical alerts pending acknowledgement, the oldest first.
+func PendingAlerts() []PendingAlert {
+	alertSlack.pendingMu.Lock()
+	defer alertSlack.pendingMu.Unlock()
+	alerts := make([]PendingAlert, 0, len(alertSlack.pendingOrder))
+	for _, id := range alertSlack.pendingOrder {
+		alerts = append(alerts, alertSlack.pending[id].PendingAlert)
+	}
+	return alerts
+}
+
+// escalate re-notifies the pending alerts not acknowledged within the after minutes of their next escalation tier
+// to the tier, the alerts escalated to the last tier are no longer tracked.
+func (as *AlertSlack) escalate(now time.Time) {
+	as.pendingMu.Lock()
+	var due []pendingAlert
+	for _, id := range as.pendingOrder {
+		alert := as.pending[id]
+		tier := as.routing.Escalation[alert.Escalations]
+		if now.Sub(alert.NotifiedAt) >= time.Duration(tier.AfterMinutes)*time.Minute {
+			due = append(due, *alert)
+		}
+	}
+	as.pendingMu.Unlock()
+
+	for _, alert := range due {
+		// the reactions are checked without the lock, the alert may be acknowledged meanwhile.
+		if alert.ts != "" && as.cfg.BotToken != "" {
+			reacted, err := as.reacted(alert.channel, alert.ts)
+			if err != nil {
+				log.Warn("check slack alert reactions failed", "error", err, "alert id", alert.ID)
+			}
+			if reacted {
+				as.pendingMu.Lock()
+				if _, exists := as.pending[alert.ID]; exists {
+					as.forget(alert.ID)
+					alertAcknowledgedTotal.WithLabelValues("reaction").Inc()
+				}
+				as.pendingMu.Unlock()
+				continue
+			}
+		}
+
+		as.pendingMu.Lock()
+		pending, exists := as.pending[alert.ID]
+		if !exists {
+			as.pendingMu.Unlock()
+			continue
+		}
+		pending.Escalations++
+		if pending.Escalations == len(as.routing.Escalation) {
+			as.forget(alert.ID)
+		}
+		as.pendingMu.Unlock()
+
+		tier := as.routing.Escalation[alert.Escalations]
+		alertEscalatedTotal.WithLabelValues(strconv.Itoa(alert.Escalations + 1)).Inc()
+		as.deliver(escalatedMessage(alert, tier.Mention, now), destination{sinks: tier.Sinks, channel: tier.Channel, webhookURL: tier.WebhookURL})
+		log.Warn("critical alert escalated", "alert id", alert.ID, "kind", alert.Kind, "tier", alert.Escalations+1)
+	}
+}
+
+// escalatedMessage the re-notification of the unacknowledged alert to its next escalation tier, with the mention of
+// the tier.
+func escalatedMessage(alert pendingAlert, mention string, now time.Time) *Message {
+	msg := newMessage(alert.Kind, "rotating_light", fmt.Sprintf("Escalated: %s", alert.Title))
+	msg.Severity = SeverityCritical
+	if mention != "" {
+		msg.AddField("notify", mention)
+	}
+	msg.AddField("escalation", fmt.Sprintf("tier %d, not acknowledged for %s", alert.Escalations+1, now.Sub(alert.NotifiedAt).Truncate(time.Minute)))
+	msg.Fields = append(msg.Fields, alert.msg.Fields...)
+	return msg
+}
+
+// reacted returns whether the slack post has any reaction, e.g. :eyes:, which acknowledges the alert.
+func (as *AlertSlack) reacted(channel, ts string) (bool, error) {
+	var result struct {
+		OK      bool   `json:"ok"`
+		Error   string `json:"error"`
+		Message struct {
+			Reactions []struct {
+				Name  string `json:"name"`
+				Count int    `json:"count"`
+			} `json:"reactions"`
+		} `json:"message"`
+	}
+	resp, err := as.notifyCli.R().
+		SetAuthToken(as.cfg.BotToken).
+		SetQueryParams(map[string]string{"channel": channel, "timestamp": ts}).
+		SetResult(&result).
+		Get(strings.TrimSuffix(as.apiURL(), "/") + "/reactions.get")
+	if err != nil {
+		return false, err
+	}
+	if resp.IsError() {
+		return false, fmt.Errorf("reactions.get status: %s", resp.Status())
+	}
+	if !result.OK {
+		return false, fmt.Errorf("reactions.get failed: %s", result.Error)
+	}
+	return len(result.Message.Reactions) > 0, nil
+}
+
+func (as *AlertSlack) runEscalation() {
+	tick := time.NewTicker(escalationCheckInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-tick.C:
+			as.escalate(time.Now())
+		case <-as.ctx.Done():
+			return
+		case <-as.stopEscalationChan:
+			log.Info("alert escalation the run loop exit")
+			return
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/scroll-tech/go-ethereum/common"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/token"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// maxFieldsPerSection the maximum number of fields of a block kit section block.
+const maxFieldsPerSection = 10
+
+// resolvedColor the attachment color of the messages reporting an alerted issue resolved.
+const resolvedColor = "#2eb67d"
+
+// the severity tiers the alerts are routed and escalated by.
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// severityColors the attachment colors of the alert severities, the messages without severity use the warning color.
+var severityColors = map[string]string{
+	SeverityCritical: "#e01e5a",
+	SeverityWarning:  "#ecb22e",
+	"high":           "#ecb22e",
+	"medium":         "#439fe0",
+	SeverityInfo:     "#1d9bd1",
+	"low":            "#1d9bd1",
+}
+
+// severityLevels the order of the alert severities. The alerts are info, warning or critical, the low, medium and high
+// severities of the earlier configs are still accepted, low ranks as info, high as warning and medium between them.
+// The messages without a known severity are warning.
+var severityLevels = map[string]int{
+	SeverityInfo:     0,
+	"low":            0,
+	"medium":         1,
+	SeverityWarning:  2,
+	"high":           2,
+	SeverityCritical: 3,
+}
+
+// ValidSeverity returns whether the severity is one of info, warning and critical, or low, medium and high.
+func ValidSeverity(severity string) bool {
+	_, exists := severityLevels[severity]
+	return exists
+}
+
+// Field a named value of an alert message.
+type Field struct {
+	Name  string
+	Value string
+}
+
+// Message an alert message, rendered as block kit blocks by the slack web api and as mrkdwn text for the
+// notification fallback.
+type Message struct {
+	// Kind the kind of the alert, the severity of a kind can be overridden and each severity tier routed by the
+	// routing config.
+	Kind string
+	// Emoji the emoji name without colons, e.g. rotating_light.
+	Emoji    string
+	Title    string
+	Severity string
+	Fields   []Field
+	// MessageHash the bridge message the alert is about, the follow-up alerts of the same message hash are posted as
+	// thread replies of the first one.
+	MessageHash string
+	// Resolved the message reports an alerted issue resolved.
+	Resolved bool
+	// Layer, Tokens and Addresses the labels the silences match the alert by. Layer is l1 or l2 and empty if the alert
+	// spans both layers, the tokens are the lowercase hex addresses and the symbols of the tokens if known.
+	Layer     string
+	Tokens    []string
+	Addresses []string
+
+	// relayFailure the message alerts a message failing to relay or being dropped, which its relay resolves.
+	relayFailure bool
+	// alertID the id of the critical alert pending acknowledgement, set if it's escalated unless acknowledged.
+	alertID string
+	// channel and webhookURL the slack channel or webhook of the route of the message, the configured ones if empty.
+	channel    string
+	webhookURL string
+}
+
+func newMessage(kind, emoji, title string) *Message {
+	return &Message{Kind: kind, Emoji: emoji, Title: title}
+}
+
+// setLayer labels the message with its layer.
+func (m *Message) setLayer(layer types.LayerType) {
+	switch layer {
+	case types.Layer1:
+		m.Layer = "l1"
+	case types.Layer2:
+		m.Layer = "l2"
+	}
+}
+
+// addTokens labels the message with the tokens of the layer, by address and by symbol if the metadata is cached.
+func (m *Message) addTokens(layer types.LayerType, addresses ...common.Address) {
+	for _, address := range addresses {
+		m.Tokens = append(m.Tokens, strings.ToLower(address.Hex()))
+		if metadata, exists := token.Lookup(layer, address); exists && metadata.Symbol != "" {
+			m.Tokens = append(m.Tokens, metadata.Symbol)
+		}
+	}
+}
+
+// addAddresses labels the message with the contract and account addresses it's about, the zero address is skipped.
+func (m *Message) addAddresses(addresses ...common.Address) {
+	for _, address := range addresses {
+		if address != (common.Address{}) {
+			m.Addresses = append(m.Addresses, strings.ToLower(address.Hex()))
+		}
+	}
+}
+
+// AddField appends a field to the message.
+func (m *Message) AddField(name, value string) {
+	m.Fields = append(m.Fields, Field{Name: name, Value: value})
+}
+
+// String returns the mrkdwn text of the message.
+func (m *Message) String() string {
+	var buffer bytes.Buffer
+	buffer.WriteString(fmt.Sprintf("\n:%s: ", m.Emoji))
+	buffer.WriteString(fmt.Sprintf("*%s*\n", m.Title))
+	if m.Severity != "" {
+		buffer.WriteString(fmt.Sprintf("• severity: %s\n", m.Severity))
+	}
+	for _, field := range m.Fields {
+		buffer.WriteString(fmt.Sprintf("• %s: %s\n", field.Name, field.Value))
+	}
+	return buffer.String()
+}
+
+// AtLeast returns whether the message severity is at least the minimum severity, every message is at least an empty
+// minimum severity.
+func (m *Message) AtLeast(minSeverity string) bool {
+	if minSeverity == "" {
+		return true
+	}
+	return m.level() >= severityLevels[minSeverity]
+}
+
+// Tier returns the severity tier of the message, info, warning or critical.
+func (m *Message) Tier() string {
+	switch m.level() {
+	case severityLevels[SeverityCritical]:
+		return SeverityCritical
+	case severityLevels[SeverityInfo]:
+		return SeverityInfo
+	default:
+		return SeverityWarning
+	}
+}
+
+func (m *Message) level() int {
+	if level, exists := severityLevels[m.Severity]; exists {
+		return level
+	}
+	return severityLevels[SeverityWarning]
+}
+
+// Color returns the color of the message severity, in hex like #e01e5a.
+func (m *Message) Color() string {
+	if m.Resolved {
+		return resolvedColor
+	}
+	if color, exists := severityColors[m.Severity]; exists {
+		return color
+	}
+	return severityColors[SeverityWarning]
+}
+
+type textObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type block struct {
+	Type     string       `json:"type"`
+	Text     *textObject  `json:"text,omitempty"`
+	Fields   []textObject `json:"fields,omitempty"`
+	Elements []textObject `json:"elements,omitempty"`
+}
+
+type attachment struct {
+	Color  string  `json:"color"`
+	Blocks []block `json:"blocks"`
+}
+
+// blocks returns the block kit layout of the message, the title section, the severity context and the fields split
+// into sections of at most ten fields.
+func (m *Message) blocks() []block {
+	blocks := []block{{
+		Type: "section",
+		Text: &textObject{Type: "mrkdwn", Text: fmt.Sprintf(":%s: *%s*", m.Emoji, m.Title)},
+	}}
+	if m.Severity != "" {
+		blocks = append(blocks, block{
+			Type:     "context",
+			Elements: []textObject{{Type: "mrkdwn", Text: fmt.Sprintf("severity: *%s*", m.Severity)}},
+		})
+	}
+	for start := 0; start < len(m.Fields); start += maxFieldsPerSection {
+		end := start + maxFieldsPerSection
+		if end > len(m.Fields) {
+			end = len(m.Fields)
+		}
+		section := block{Type: "section"}
+		for _, field := range m.Fields[start:end] {
+			section.Fields = append(section.Fields, textObject{Type: "mrkdwn", Text: fmt.Sprintf("*%s*\n%s", field.Name, field.Value)})
+		}
+		blocks = append(blocks, section)
+	}
+	return blocks
+}
+
+func (m *Message) attachments() []attachment {
+	return []attachment{{Color: m.Color(), Blocks: m.blocks()}}
+}
@@ -0,0 +1,178 @@
+package slack
+
+import (
+	"fmt"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+)
+
+// the kinds of the alerts, the severity of a kind can be overridden by the routing config.
+const (
+	kindWithdrawRoot           = "withdraw_root"
+	kindGatewayTransfer        = "gateway_transfer"
+	kindGatewayCrossChain      = "gateway_cross_chain"
+	kindMessengerCrossChain    = "messenger_cross_chain"
+	kindETHBalance             = "eth_balance"
+	kindGatewayDuplicated      = "gateway_duplicated"
+	kindMessengerDuplicated    = "messenger_duplicated"
+	kindMessengerFailedRelay   = "messenger_failed_relay"
+	kindMessengerDrop          = "messenger_drop"
+	kindGovernanceEvent        = "governance_event"
+	kindProxyDrift             = "proxy_drift"
+	kindReserveImbalance       = "reserve_imbalance"
+	kindUnauthorizedMintBurn   = "unauthorized_mint_burn"
+	kindMessagePayload         = "message_payload"
+	kindRelayedMessageHash     = "relayed_message_hash"
+	kindMessageNonce           = "message_nonce"
+	kindMessengerBalanceDrift  = "messenger_balance_drift"
+	kindMessengerOutflow       = "messenger_outflow"
+	kindWatchedEvent           = "watched_event"
+	kindInvariant              = "invariant"
+	kindRule                   = "rule"
+	kindLargeTransfer          = "large_transfer"
+	kindOutflowVelocity        = "outflow_velocity"
+	kindMessengerRelayResolved = "messenger_relay_resolved"
+)
+
+var alertKinds = map[string]struct{}{
+	kindWithdrawRoot:           {},
+	kindGatewayTransfer:        {},
+	kindGatewayCrossChain:      {},
+	kindMessengerCrossChain:    {},
+	kindETHBalance:             {},
+	kindGatewayDuplicated:      {},
+	kindMessengerDuplicated:    {},
+	kindMessengerFailedRelay:   {},
+	kindMessengerDrop:          {},
+	kindGovernanceEvent:        {},
+	kindProxyDrift:             {},
+	kindReserveImbalance:       {},
+	kindUnauthorizedMintBurn:   {},
+	kindMessagePayload:         {},
+	kindRelayedMessageHash:     {},
+	kindMessageNonce:           {},
+	kindMessengerBalanceDrift:  {},
+	kindMessengerOutflow:       {},
+	kindWatchedEvent:           {},
+	kindInvariant:              {},
+	kindRule:                   {},
+	kindLargeTransfer:          {},
+	kindOutflowVelocity:        {},
+	kindMessengerRelayResolved: {},
+}
+
+// ValidKind returns whether the kind is one of the alert kinds.
+func ValidKind(kind string) bool {
+	_, exists := alertKinds[kind]
+	return exists
+}
+
+// sinkNames the names of the sinks the routes and escalation tiers send to.
+var sinkNames = map[string]struct{}{"slack": {}, "discord": {}, "telegram": {}, "matrix": {}}
+
+// ValidateRouting validates the alert kinds and severities, the routes and the escalation tiers of the routing config.
+func ValidateRouting(cfg *config.AlertRoutingConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	for kind, severity := range cfg.Severities {
+		if _, exists := alertKinds[kind]; !exists {
+			return fmt.Errorf("unknown alert kind %s", kind)
+		}
+		if !ValidSeverity(severity) {
+			return fmt.Errorf("invalid severity %s of alert kind %s", severity, kind)
+		}
+	}
+
+	routed := make(map[string]bool)
+	for _, route := range cfg.Routes {
+		if route.Severity != SeverityInfo && route.Severity != SeverityWarning && route.Severity != SeverityCritical {
+			return fmt.Errorf("invalid route severity %s, expected info, warning or critical", route.Severity)
+		}
+		if routed[route.Severity] {
+			return fmt.Errorf("duplicated route of severity %s", route.Severity)
+		}
+		routed[route.Severity] = true
+		if len(route.Sinks) == 0 {
+			return fmt.Errorf("route of severity %s has no sinks", route.Severity)
+		}
+		if err := validateSinkNames(route.Sinks); err != nil {
+			return fmt.Errorf("route of severity %s: %w", route.Severity, err)
+		}
+	}
+
+	var lastAfter uint64
+	for i, tier := range cfg.Escalation {
+		if tier.AfterMinutes <= lastAfter {
+			return fmt.Errorf("escalation tier %d after minutes must be positive and increasing", i+1)
+		}
+		lastAfter = tier.AfterMinutes
+		if len(tier.Sinks) == 0 {
+			return fmt.Errorf("escalation tier %d has no sinks", i+1)
+		}
+		if err := validateSinkNames(tier.Sinks); err != nil {
+			return fmt.Errorf("escalation tier %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func validateSinkNames(names []string) error {
+	for _, name := range names {
+		if _, exists := sinkNames[name]; !exists {
+			return fmt.Errorf("unknown sink %s, expected slack, discord, telegram or matrix", name)
+		}
+	}
+	return nil
+}
+
+// destination the sinks, slack channel and slack webhook a message is sent to, every sink and the configured
+// channel and webhook if empty.
+type destination struct {
+	sinks      []string
+	channel    string
+	webhookURL string
+}
+
+func (d destination) includes(sink string) bool {
+	if d.sinks == nil {
+		return true
+	}
+	for _, name := range d.sinks {
+		if name == sink {
+			return true
+		}
+	}
+	return false
+}
+
+// route returns the destination of the message by the route of its severity tier, the resolutions follow the alerts
+// they resolve to every sink.
+func (as *AlertSlack) route(msg *Message) destination {
+	if as.routing == nil || msg.Resolved {
+		return destination{}
+	}
+	for _, route := range as.routing.Routes {
+		if route.Severity == msg.Tier() {
+			return destination{sinks: route.Sinks, channel: route.Channel, webhookURL: route.WebhookURL}
+		}
+	}
+	return destination{}
+}
+
+// deliver sends the message to the sinks of the destination, and queues it to slack if slack is one of them and the
+// message is at least the slack min severity.
+func (as *AlertSlack) deliver(msg *Message, dest destination) {
+	msg.channel = dest.channel
+	msg.webhookURL = dest.webhookURL
+	for _, sink := range sinks {
+		if dest.includes(sink.Name()) {
+			sink.Notify(msg)
+		}
+	}
+
+	if !dest.includes("slack") || !msg.AtLeast(as.cfg.MinSeverity) {
+		return
+	}
+	as.senderQueue <- msg
+}
@@ -3,6 +3,9 @@ package slack
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -14,28 +17,108 @@ import (
 	"github.com/scroll-tech/chain-monitor/internal/utils/fanout"
 )
 
-var alertSlack *AlertSlack
+const (
+	defaultAPIURL = "https://slack.com/api"
+	// maxThreads the maximum number of message hash threads remembered, the oldest threads are forgotten first.
+	maxThreads = 10000
+)
+
+var (
+	alertSlack *AlertSlack
+
+	alertSlackRunningTotal = promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
+		Name: "alert_slack_running_total",
+		Help: "The total number of alert slack running.",
+	})
+
+	alertEscalatedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_escalated_total",
+		Help: "The total number of critical alerts escalated, by escalation tier.",
+	}, []string{"tier"})
+
+	alertAcknowledgedTotal = promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+		Name: "alert_acknowledged_total",
+		Help: "The total number of critical alerts acknowledged, by the api, a slack reaction or the resolution.",
+	}, []string{"by"})
+)
+
+// sinks the alert sinks notified besides slack.
+var sinks []Sink
+
+// silencer the silencer of the alerts, no alert is silenced if unset.
+var silencer Silencer
+
+// Sink an alert sink besides slack, such as a discord, telegram or matrix sink. It filters the messages by its own
+// severity filter, and mustn't block the notifier.
+type Sink interface {
+	Name() string
+	Notify(msg *Message)
+}
+
+// RegisterSink registers an alert sink notified of every alert message along with slack, the sinks are registered
+// before the alerts are notified.
+func RegisterSink(sink Sink) {
+	sinks = append(sinks, sink)
+}
+
+// Silencer silences the alerts matching an active silence, e.g. during a contract upgrade or a node maintenance. The
+// silenced alerts are recorded by the silencer instead of being notified.
+type Silencer interface {
+	Silenced(msg *Message) bool
+}
+
+// RegisterSilencer registers the silencer of the alerts, it's registered before the alerts are notified.
+func RegisterSilencer(s Silencer) {
+	silencer = s
+}
+
+// thread the slack thread of the alerts of a message hash, ts is the timestamp of the first alert posted to the
+// channel, the follow-ups are replied in the channel of the thread whatever their route.
+type thread struct {
+	mu      sync.Mutex
+	ts      string
+	channel string
+}
 
 // AlertSlack send slack message
 type AlertSlack struct {
 	cfg       *config.SlackWebhookConfig
+	routing   *config.AlertRoutingConfig
 	notifyCli *resty.Client
 
-	ctx             context.Context
-	senderQueue     chan string
-	sendWorker      *fanout.Fanout
-	stopTimeoutChan chan struct{}
+	ctx                context.Context
+	senderQueue        chan *Message
+	sendWorker         *fanout.Fanout
+	stopTimeoutChan    chan struct{}
+	stopEscalationChan chan struct{}
+
+	// threads and relayFailures are only kept in memory, capped at maxThreads. After a restart the relays of the
+	// failures alerted before aren't notified as their resolution, and the follow-ups start new threads.
+	threadsMu   sync.Mutex
+	threads     map[string]*thread
+	threadOrder []string
+	// relayFailures the message hashes alerted for failing to relay or being dropped, the relay of the message
+	// resolves them. They're forgotten along with their threads.
+	relayFailures map[string]bool
 
-	alertSlackRunningTotal prometheus.Counter
+	pendingMu    sync.Mutex
+	pending      map[string]*pendingAlert
+	pendingOrder []string
+	lastAlertID  uint64
 }
 
-// NewAlertSlack init the alert slack
-func NewAlertSlack(ctx context.Context, cfg *config.SlackWebhookConfig) *AlertSlack {
+// NewAlertSlack init the alert slack, the routing config is optional
+func NewAlertSlack(ctx context.Context, cfg *config.SlackWebhookConfig, routing *config.AlertRoutingConfig) *AlertSlack {
 	as := &AlertSlack{
-		ctx:             ctx,
-		cfg:             cfg,
-		senderQueue:     make(chan string, cfg.WorkerBufferSize),
-		stopTimeoutChan: make(chan struct{}),
+		ctx:                ctx,
+		cfg:                cfg,
+		routing:            routing,
+		senderQueue:        make(chan *Message, cfg.WorkerBufferSize),
+		stopTimeoutChan:    make(chan struct{}),
+		stopEscalationChan: make(chan struct{}),
+		threads:            make(map[string]*thread),
+		relayFailures:      make(map[string]bool),
+		pending:            make(map[string]*pendingAlert),
 	}
 
 	cli := resty.New()
@@ -49,59 +132,220 @@ func NewAlertSlack(ctx context.Context, cfg *config.SlackWebhookConfig) *AlertSl
 	)
 
 	alertSlack = as
-
-	as.alertSlackRunningTotal = promauto.With(prometheus.DefaultRegisterer).NewCounter(prometheus.CounterOpts{
-		Name: "alert_slack_running_total",
-		Help: "The total number of alert slack running.",
-	})
-
 	return as
 }
 
 // Start the slack alert
 func (as *AlertSlack) Start() {
 	go as.run()
+	if as.escalates() {
+		go as.runEscalation()
+	}
 }
 
 // Stop the slack alert
 func (as *AlertSlack) Stop() {
 	as.stopTimeoutChan <- struct{}{}
+	if as.escalates() {
+		as.stopEscalationChan <- struct{}{}
+	}
 }
 
-// Notify a alert message to AlertSlack
-func Notify(msg string) {
-	alertSlack.senderQueue <- msg
+func (as *AlertSlack) escalates() bool {
+	return as.routing != nil && len(as.routing.Escalation) > 0
+}
+
+// Notify a alert message to AlertSlack and the registered sinks, by the route of its severity tier. The severity of
+// the alert kind is overridden by the routing config, and the critical alerts are tracked for escalation. The alerts
+// matching an active silence aren't notified, the resolutions of the notified alerts are never silenced.
+func Notify(msg *Message) {
+	if alertSlack.routing != nil {
+		if severity, exists := alertSlack.routing.Severities[msg.Kind]; exists {
+			msg.Severity = severity
+		}
+	}
+	if !msg.Resolved && silencer != nil && silencer.Silenced(msg) {
+		return
+	}
+
+	if msg.MessageHash != "" {
+		alertSlack.thread(msg.MessageHash)
+		alertSlack.relayFailure(msg)
+	}
+	if msg.Resolved {
+		if msg.MessageHash != "" {
+			alertSlack.resolve(msg.MessageHash)
+		}
+	} else if msg.Tier() == SeverityCritical && alertSlack.escalates() {
+		alertSlack.track(msg, time.Now())
+	}
+	alertSlack.deliver(msg, alertSlack.route(msg))
 }
 
-func (as *AlertSlack) send(msg string) {
+// RelayFailureAlerted returns whether the message hash was alerted for failing to relay or being dropped and isn't
+// resolved yet, the relay of the message is only notified as the resolution of these alerts.
+func RelayFailureAlerted(messageHash string) bool {
+	alertSlack.threadsMu.Lock()
+	defer alertSlack.threadsMu.Unlock()
+	return alertSlack.relayFailures[messageHash]
+}
+
+// relayFailure records the failed relay and drop alerts of the message hash, and forgets them on its resolution.
+func (as *AlertSlack) relayFailure(msg *Message) {
+	as.threadsMu.Lock()
+	defer as.threadsMu.Unlock()
+	switch {
+	case msg.Resolved:
+		delete(as.relayFailures, msg.MessageHash)
+	case msg.relayFailure:
+		as.relayFailures[msg.MessageHash] = true
+	}
+}
+
+// thread returns the thread of the message hash, and creates it if the message hash wasn't alerted.
+func (as *AlertSlack) thread(messageHash string) *thread {
+	as.threadsMu.Lock()
+	defer as.threadsMu.Unlock()
+	if t, exists := as.threads[messageHash]; exists {
+		return t
+	}
+	if len(as.threadOrder) >= maxThreads {
+		delete(as.threads, as.threadOrder[0])
+		delete(as.relayFailures, as.threadOrder[0])
+		as.threadOrder = as.threadOrder[1:]
+	}
+	t := &thread{}
+	as.threads[messageHash] = t
+	as.threadOrder = append(as.threadOrder, messageHash)
+	return t
+}
+
+func (as *AlertSlack) send(msg *Message) {
 	doSendSlack := func(ctx context.Context) {
-		hookContent := map[string]string{
-			"types": "mrkdwn",
-			"text":  msg,
+		if as.cfg.BotToken == "" {
+			as.sendWebhook(msg)
+			return
 		}
 
-		data, err := json.Marshal(hookContent)
-		if err != nil {
-			log.Error("failed to marshal hook content", "err", err)
+		channel := msg.channel
+		if channel == "" {
+			channel = as.cfg.Channel
+		}
+
+		if msg.MessageHash == "" {
+			ts, err := as.postMessage(msg, channel, "")
+			if err != nil {
+				log.Error("appear error when post slack message", "err", err)
+			}
+			as.posted(msg.alertID, channel, ts)
 			return
 		}
 
-		request := as.notifyCli.R().SetHeader("Content-Type", "application/json")
-		request = request.SetFormData(map[string]string{"payload": string(data)})
-		_, err = request.Post(as.cfg.WebhookURL)
+		// the first alert of the message hash starts the thread, the lock is held until it's posted so the
+		// follow-ups are replied to it.
+		t := as.thread(msg.MessageHash)
+		t.mu.Lock()
+		if t.ts == "" {
+			ts, err := as.postMessage(msg, channel, "")
+			if err != nil {
+				log.Error("appear error when post slack message", "err", err, "message hash", msg.MessageHash)
+			}
+			t.ts = ts
+			t.channel = channel
+			t.mu.Unlock()
+			as.posted(msg.alertID, channel, ts)
+			return
+		}
+		threadTS, threadChannel := t.ts, t.channel
+		t.mu.Unlock()
+
+		ts, err := as.postMessage(msg, threadChannel, threadTS)
 		if err != nil {
-			log.Error("appear error when send slack message", "err", err)
+			log.Error("appear error when post slack thread reply", "err", err, "message hash", msg.MessageHash)
 		}
+		as.posted(msg.alertID, threadChannel, ts)
 	}
 
 	if err := as.sendWorker.Do(context.Background(), doSendSlack); err != nil {
-		log.Error("do send notify failed", "error", err, "msg", msg)
+		log.Error("do send notify failed", "error", err, "msg", msg.String())
+	}
+}
+
+func (as *AlertSlack) sendWebhook(msg *Message) {
+	hookContent := struct {
+		Text        string       `json:"text"`
+		Attachments []attachment `json:"attachments"`
+	}{
+		Text:        msg.String(),
+		Attachments: msg.attachments(),
+	}
+
+	data, err := json.Marshal(hookContent)
+	if err != nil {
+		log.Error("failed to marshal hook content", "err", err)
+		return
+	}
+
+	request := as.notifyCli.R().SetHeader("Content-Type", "application/json")
+	request = request.SetFormData(map[string]string{"payload": string(data)})
+	webhookURL := msg.webhookURL
+	if webhookURL == "" {
+		webhookURL = as.cfg.WebhookURL
+	}
+	_, err = request.Post(webhookURL)
+	if err != nil {
+		log.Error("appear error when send slack message", "err", err)
+	}
+}
+
+// postMessage posts the message to the channel by the chat.postMessage web api, as a reply of the thread if threadTS
+// isn't empty, and returns the timestamp of the posted message.
+func (as *AlertSlack) postMessage(msg *Message, channel, threadTS string) (string, error) {
+	body := struct {
+		Channel     string       `json:"channel"`
+		Text        string       `json:"text"`
+		Attachments []attachment `json:"attachments"`
+		ThreadTS    string       `json:"thread_ts,omitempty"`
+	}{
+		Channel:     channel,
+		Text:        msg.String(),
+		Attachments: msg.attachments(),
+		ThreadTS:    threadTS,
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		TS    string `json:"ts"`
+	}
+	resp, err := as.notifyCli.R().
+		SetAuthToken(as.cfg.BotToken).
+		SetHeader("Content-Type", "application/json; charset=utf-8").
+		SetBody(body).
+		SetResult(&result).
+		Post(strings.TrimSuffix(as.apiURL(), "/") + "/chat.postMessage")
+	if err != nil {
+		return "", err
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("chat.postMessage status: %s", resp.Status())
+	}
+	if !result.OK {
+		return "", fmt.Errorf("chat.postMessage failed: %s", result.Error)
+	}
+	return result.TS, nil
+}
+
+func (as *AlertSlack) apiURL() string {
+	if as.cfg.APIURL == "" {
+		return defaultAPIURL
 	}
+	return as.cfg.APIURL
 }
 
 func (as *AlertSlack) run() {
 	for {
-		as.alertSlackRunningTotal.Inc()
+		alertSlackRunningTotal.Inc()
 
 		select {
 		case senderMessage := <-as.senderQueue:
@@ -0,0 +1,359 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+const botToken = "xoxb-test"
+
+type postMessageRequest struct {
+	Channel     string       `json:"channel"`
+	Text        string       `json:"text"`
+	Attachments []attachment `json:"attachments"`
+	ThreadTS    string       `json:"thread_ts"`
+}
+
+// standInSlackAPI serves chat.postMessage and reactions.get in place of the slack web api, the posted messages are
+// numbered as their ts.
+type standInSlackAPI struct {
+	mu        sync.Mutex
+	requests  []postMessageRequest
+	reactions map[string]bool
+	posted    chan postMessageRequest
+}
+
+func (api *standInSlackAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Header.Get("Authorization") != "Bearer "+botToken {
+		_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+		return
+	}
+	if r.URL.Path == "/reactions.get" {
+		api.mu.Lock()
+		reacted := api.reactions[r.URL.Query().Get("timestamp")]
+		api.mu.Unlock()
+		if reacted {
+			_, _ = w.Write([]byte(`{"ok":true,"type":"message","message":{"reactions":[{"name":"eyes","count":1}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"type":"message","message":{}}`))
+		return
+	}
+	if r.URL.Path != "/chat.postMessage" {
+		_, _ = w.Write([]byte(`{"ok":false,"error":"unknown_method"}`))
+		return
+	}
+
+	var req postMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	api.mu.Lock()
+	api.requests = append(api.requests, req)
+	ts := fmt.Sprintf("1700000000.%06d", len(api.requests))
+	api.mu.Unlock()
+
+	_, _ = w.Write([]byte(fmt.Sprintf(`{"ok":true,"channel":"%s","ts":"%s"}`, req.Channel, ts)))
+	api.posted <- req
+}
+
+func (api *standInSlackAPI) wait(t *testing.T) postMessageRequest {
+	select {
+	case req := <-api.posted:
+		return req
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message posted")
+	}
+	return postMessageRequest{}
+}
+
+func TestAlertSlackThreads(t *testing.T) {
+	api := &standInSlackAPI{posted: make(chan postMessageRequest, 10)}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	as := NewAlertSlack(ctx, &config.SlackWebhookConfig{
+		BotToken:         botToken,
+		Channel:          "C0ALERTS",
+		APIURL:           server.URL,
+		WorkerCount:      1,
+		WorkerBufferSize: 10,
+	}, nil)
+	as.Start()
+
+	messageHash := "0x1cca3b4f0ab8e2c0c2a5b3d57b0a2a4aa6e7b1d8e4c1f6d1a0f2bb5c98d4c001"
+	assert.False(t, RelayFailureAlerted(messageHash))
+
+	failure := newMessage(kindMessengerFailedRelay, "bangbang", "Messenger message relay failed repeatedly")
+	failure.MessageHash = messageHash
+	failure.relayFailure = true
+	failure.AddField("failed times", "3")
+	Notify(failure)
+	assert.True(t, RelayFailureAlerted(messageHash))
+
+	first := api.wait(t)
+	assert.Equal(t, "C0ALERTS", first.Channel)
+	assert.Empty(t, first.ThreadTS)
+	assert.Equal(t, failure.String(), first.Text)
+	require.Len(t, first.Attachments, 1)
+	assert.Equal(t, severityColors["warning"], first.Attachments[0].Color)
+
+	repeated := newMessage(kindMessengerFailedRelay, "bangbang", "Messenger message relay failed repeatedly")
+	repeated.MessageHash = messageHash
+	repeated.relayFailure = true
+	repeated.AddField("failed times", "4")
+	Notify(repeated)
+	assert.Equal(t, "1700000000.000001", api.wait(t).ThreadTS)
+
+	resolved := newMessage(kindMessengerRelayResolved, "white_check_mark", "Alerted messenger message relayed")
+	resolved.MessageHash = messageHash
+	resolved.Resolved = true
+	Notify(resolved)
+	reply := api.wait(t)
+	assert.Equal(t, "1700000000.000001", reply.ThreadTS)
+	require.Len(t, reply.Attachments, 1)
+	assert.Equal(t, resolvedColor, reply.Attachments[0].Color)
+	assert.False(t, RelayFailureAlerted(messageHash))
+
+	// the other alerts of a message hash aren't resolved by its relay.
+	mismatchedHash := "0x1cca3b4f0ab8e2c0c2a5b3d57b0a2a4aa6e7b1d8e4c1f6d1a0f2bb5c98d4c002"
+	mismatched := newMessage(kindMessagePayload, "rotating_light", "Messenger message payload mismatched")
+	mismatched.MessageHash = mismatchedHash
+	Notify(mismatched)
+	assert.Empty(t, api.wait(t).ThreadTS)
+	assert.False(t, RelayFailureAlerted(mismatchedHash))
+
+	other := newMessage(kindInvariant, "rotating_light", "Contract state invariant violated")
+	other.Severity = SeverityCritical
+	Notify(other)
+	standalone := api.wait(t)
+	assert.Empty(t, standalone.ThreadTS)
+	require.Len(t, standalone.Attachments, 1)
+	assert.Equal(t, severityColors["critical"], standalone.Attachments[0].Color)
+}
+
+func TestMessageBlocks(t *testing.T) {
+	msg := newMessage(kindWatchedEvent, "rotating_light", "Watched contract event")
+	msg.Severity = SeverityCritical
+	for i := 0; i < 12; i++ {
+		msg.AddField(fmt.Sprintf("arg%d", i), fmt.Sprintf("%d", i))
+	}
+
+	assert.True(t, strings.HasPrefix(msg.String(), "\n:rotating_light: *Watched contract event*\n• severity: critical\n• arg0: 0\n"))
+
+	blocks := msg.blocks()
+	require.Len(t, blocks, 4)
+	assert.Equal(t, ":rotating_light: *Watched contract event*", blocks[0].Text.Text)
+	assert.Equal(t, "context", blocks[1].Type)
+	assert.Equal(t, "severity: *critical*", blocks[1].Elements[0].Text)
+	assert.Len(t, blocks[2].Fields, maxFieldsPerSection)
+	assert.Len(t, blocks[3].Fields, 2)
+	assert.Equal(t, "*arg11*\n11", blocks[3].Fields[1].Text)
+}
+
+// recordingSink records the messages notified to it.
+type recordingSink struct {
+	name     string
+	messages []*Message
+}
+
+func (s *recordingSink) Name() string {
+	return s.name
+}
+
+func (s *recordingSink) Notify(msg *Message) {
+	s.messages = append(s.messages, msg)
+}
+
+func TestAlertRoutingAndEscalation(t *testing.T) {
+	api := &standInSlackAPI{posted: make(chan postMessageRequest, 10), reactions: make(map[string]bool)}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	discord, telegram := &recordingSink{name: "discord"}, &recordingSink{name: "telegram"}
+	sinks = []Sink{discord, telegram}
+	defer func() { sinks = nil }()
+
+	routing := &config.AlertRoutingConfig{
+		Severities: map[string]string{kindGatewayDuplicated: "warning"},
+		Routes: []config.AlertRoute{
+			{Severity: "info", Sinks: []string{"discord"}},
+			{Severity: "critical", Sinks: []string{"slack", "telegram"}, Channel: "C0CRITICAL"},
+		},
+		Escalation: []config.EscalationTier{
+			{AfterMinutes: 15, Sinks: []string{"slack"}, Channel: "C0ONCALL", Mention: "<!channel>"},
+			{AfterMinutes: 30, Sinks: []string{"slack", "discord"}, Channel: "C0ONCALL"},
+		},
+	}
+	require.NoError(t, ValidateRouting(routing))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	as := NewAlertSlack(ctx, &config.SlackWebhookConfig{
+		BotToken:         botToken,
+		Channel:          "C0ALERTS",
+		APIURL:           server.URL,
+		WorkerCount:      1,
+		WorkerBufferSize: 10,
+	}, routing)
+	go as.run()
+
+	// the overridden warning without a route is sent everywhere.
+	duplicated := newMessage(kindGatewayDuplicated, "information_source", "Gateway event duplicated")
+	duplicated.Severity = SeverityInfo
+	Notify(duplicated)
+	assert.Equal(t, "C0ALERTS", api.wait(t).Channel)
+	assert.Equal(t, "warning", duplicated.Severity)
+
+	info := newMessage(kindMessengerDrop, "warning", "High value messenger message dropped")
+	info.Severity = "info"
+	Notify(info)
+
+	violated := newMessage(kindInvariant, "rotating_light", "Contract state invariant violated")
+	violated.Severity = "critical"
+	Notify(violated)
+	posted := api.wait(t)
+	assert.Equal(t, "C0CRITICAL", posted.Channel)
+	assert.Contains(t, posted.Text, "• alert_id: 1\n")
+
+	outflow := newMessage(kindOutflowVelocity, "rotating_light", "L1 escrow net outflow exceeds the limit")
+	outflow.Severity = "critical"
+	Notify(outflow)
+	api.wait(t)
+
+	assert.Equal(t, []*Message{duplicated, info}, discord.messages)
+	assert.Equal(t, []*Message{duplicated, violated, outflow}, telegram.messages)
+
+	require.Len(t, PendingAlerts(), 2)
+	assert.True(t, Acknowledge("2"))
+	assert.False(t, Acknowledge("2"))
+
+	as.escalate(time.Now().Add(16 * time.Minute))
+	escalated := api.wait(t)
+	assert.Equal(t, "C0ONCALL", escalated.Channel)
+	assert.True(t, strings.HasPrefix(escalated.Text, "\n:rotating_light: *Escalated: Contract state invariant violated*\n• severity: critical\n• notify: <!channel>\n• escalation: tier 1, not acknowledged for 16m0s\n"))
+	pending := PendingAlerts()
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Escalations)
+
+	// a reaction to the critical post acknowledges the alert before the second tier.
+	api.mu.Lock()
+	api.reactions["1700000000.000002"] = true
+	api.mu.Unlock()
+	as.escalate(time.Now().Add(31 * time.Minute))
+	assert.Empty(t, PendingAlerts())
+	assert.Len(t, discord.messages, 2)
+
+	assert.Error(t, ValidateRouting(&config.AlertRoutingConfig{Severities: map[string]string{"unknown": "critical"}}))
+	assert.Error(t, ValidateRouting(&config.AlertRoutingConfig{Routes: []config.AlertRoute{{Severity: "high", Sinks: []string{"slack"}}}}))
+	assert.Error(t, ValidateRouting(&config.AlertRoutingConfig{Escalation: []config.EscalationTier{{AfterMinutes: 15, Sinks: []string{"pagerduty"}}}}))
+}
+
+func TestResolveRelayFailures(t *testing.T) {
+	api := &standInSlackAPI{posted: make(chan postMessageRequest, 10), reactions: make(map[string]bool)}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	routing := &config.AlertRoutingConfig{
+		Severities: map[string]string{kindMessengerFailedRelay: SeverityCritical, kindMessagePayload: SeverityCritical},
+		Escalation: []config.EscalationTier{{AfterMinutes: 15, Sinks: []string{"slack"}, Channel: "C0ONCALL"}},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	as := NewAlertSlack(ctx, &config.SlackWebhookConfig{
+		BotToken:         botToken,
+		Channel:          "C0ALERTS",
+		APIURL:           server.URL,
+		WorkerCount:      1,
+		WorkerBufferSize: 10,
+	}, routing)
+	go as.run()
+
+	messageHash := "0x1cca3b4f0ab8e2c0c2a5b3d57b0a2a4aa6e7b1d8e4c1f6d1a0f2bb5c98d4c003"
+	mismatched := newMessage(kindMessagePayload, "rotating_light", "Messenger message payload mismatched")
+	mismatched.MessageHash = messageHash
+	Notify(mismatched)
+	api.wait(t)
+
+	failure := newMessage(kindMessengerFailedRelay, "bangbang", "Messenger message relay failed repeatedly")
+	failure.MessageHash = messageHash
+	Notify(failure)
+	api.wait(t)
+	require.Len(t, PendingAlerts(), 2)
+
+	// the relay acknowledges the failed relay alert, the payload mismatch keeps escalating.
+	resolved := newMessage(kindMessengerRelayResolved, "white_check_mark", "Alerted messenger message relayed")
+	resolved.MessageHash = messageHash
+	resolved.Resolved = true
+	Notify(resolved)
+	api.wait(t)
+
+	pending := PendingAlerts()
+	require.Len(t, pending, 1)
+	assert.Equal(t, kindMessagePayload, pending[0].Kind)
+	assert.Equal(t, messageHash, pending[0].MessageHash)
+}
+
+// kindSilencer silences the alerts of a kind.
+type kindSilencer struct {
+	kind     string
+	silenced []*Message
+}
+
+func (s *kindSilencer) Silenced(msg *Message) bool {
+	if msg.Kind != s.kind {
+		return false
+	}
+	s.silenced = append(s.silenced, msg)
+	return true
+}
+
+func TestSilencedAlert(t *testing.T) {
+	discord := &recordingSink{name: "discord"}
+	sinks = []Sink{discord}
+	s := &kindSilencer{kind: kindProxyDrift}
+	RegisterSilencer(s)
+	defer func() {
+		sinks = nil
+		silencer = nil
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	NewAlertSlack(ctx, &config.SlackWebhookConfig{WorkerCount: 1, WorkerBufferSize: 10}, nil)
+
+	drift := MrkDwnProxyDriftMessage(ProxyDriftInfo{
+		Layer:           types.Layer1,
+		Name:            "L1StandardERC20Gateway",
+		ContractAddress: common.HexToAddress("0xD8A791fE2bE73eb6E6cF1eb0cb3F36adC9B3F8f9"),
+	})
+	assert.Equal(t, "l1", drift.Layer)
+	assert.Equal(t, []string{"0xd8a791fe2be73eb6e6cf1eb0cb3f36adc9b3f8f9"}, drift.Addresses)
+	Notify(drift)
+
+	failure := MrkDwnMessengerFailedRelayMessage(MessengerFailureInfo{Layer: types.Layer2, FailedTimes: 3})
+	assert.Equal(t, "l2", failure.Layer)
+	Notify(failure)
+
+	assert.Equal(t, []*Message{drift}, s.silenced)
+	assert.Equal(t, []*Message{failure}, discord.messages)
+}
@@ -0,0 +1,267 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// erc20MetadataABI only contains the metadata methods of the erc20 tokens.
+const erc20MetadataABI = `[
+	{"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
+
+// retryInterval the interval before the metadata of a token failed to read is read again.
+const retryInterval = 10 * time.Minute
+
+// ETH the metadata of the eth, bridged as the zero token address on both layers.
+var ETH = Metadata{Name: "Ether", Symbol: "ETH", Decimals: 18}
+
+var tokenCache *Cache
+
+// Metadata the metadata of a token.
+type Metadata struct {
+	Name     string
+	Symbol   string
+	Decimals int32
+}
+
+type tokenKey struct {
+	layer   types.LayerType
+	address common.Address
+}
+
+// Cache caches the metadata of the tokens on both layers, from the static token list or read from the tokens.
+type Cache struct {
+	l1Client    *ethclient.Client
+	l2Client    *ethclient.Client
+	metadataABI abi.ABI
+
+	mu     sync.RWMutex
+	tokens map[tokenKey]Metadata
+	// the tokens failed to read, they aren't read again until the retry interval elapses.
+	failed map[tokenKey]time.Time
+
+	tokenMetadataFetchFailureTotal *prometheus.CounterVec
+}
+
+// NewCache loads the static token list and creates the token metadata cache used by the alert messages.
+func NewCache(cfg *config.Config, l1Client, l2Client *ethclient.Client) (*Cache, error) {
+	metadataABI, err := abi.JSON(strings.NewReader(erc20MetadataABI))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		l1Client:    l1Client,
+		l2Client:    l2Client,
+		metadataABI: metadataABI,
+		tokens: map[tokenKey]Metadata{
+			{layer: types.Layer1}: ETH,
+			{layer: types.Layer2}: ETH,
+		},
+		failed: make(map[tokenKey]time.Time),
+		tokenMetadataFetchFailureTotal: promauto.With(prometheus.DefaultRegisterer).NewCounterVec(prometheus.CounterOpts{
+			Name: "token_metadata_fetch_failure_total",
+			Help: "The total number of token metadata fetch failure.",
+		}, []string{"layer"}),
+	}
+
+	if cfg.TokenListFile != "" {
+		if err = c.loadTokenList(cfg.TokenListFile); err != nil {
+			return nil, err
+		}
+	}
+
+	tokenCache = c
+	return c, nil
+}
+
+// tokenListEntry a token of the static token list file.
+type tokenListEntry struct {
+	// Layer the layer of the token, l1 or l2.
+	Layer    string         `json:"layer"`
+	Address  common.Address `json:"address"`
+	Name     string         `json:"name"`
+	Symbol   string         `json:"symbol"`
+	Decimals int32          `json:"decimals"`
+}
+
+func (c *Cache) loadTokenList(file string) error {
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return err
+	}
+	var tokenList struct {
+		Tokens []tokenListEntry `json:"tokens"`
+	}
+	if err = json.Unmarshal(data, &tokenList); err != nil {
+		return fmt.Errorf("parse token list file %s failed: %w", file, err)
+	}
+
+	for _, entry := range tokenList.Tokens {
+		key := tokenKey{address: entry.Address}
+		switch entry.Layer {
+		case "l1":
+			key.layer = types.Layer1
+		case "l2":
+			key.layer = types.Layer2
+		default:
+			return fmt.Errorf("token %s of the token list has invalid layer %q", entry.Address.Hex(), entry.Layer)
+		}
+		c.tokens[key] = Metadata{Name: entry.Name, Symbol: entry.Symbol, Decimals: entry.Decimals}
+	}
+	log.Info("token list loaded", "file", file, "tokens", len(tokenList.Tokens))
+	return nil
+}
+
+// Lookup returns the cached metadata of the token, it never reads the token.
+func Lookup(layer types.LayerType, address common.Address) (Metadata, bool) {
+	if address == (common.Address{}) {
+		return ETH, true
+	}
+	if tokenCache == nil {
+		return Metadata{}, false
+	}
+	tokenCache.mu.RLock()
+	defer tokenCache.mu.RUnlock()
+	metadata, exists := tokenCache.tokens[tokenKey{layer: layer, address: address}]
+	return metadata, exists
+}
+
+// Resolve returns the metadata of the token, and reads it from the token on a cache miss.
+func Resolve(ctx context.Context, layer types.LayerType, address common.Address) (Metadata, error) {
+	if metadata, exists := Lookup(layer, address); exists {
+		return metadata, nil
+	}
+	if tokenCache == nil {
+		return Metadata{}, fmt.Errorf("token metadata cache isn't initialized")
+	}
+	return tokenCache.resolve(ctx, tokenKey{layer: layer, address: address})
+}
+
+func (c *Cache) resolve(ctx context.Context, key tokenKey) (Metadata, error) {
+	c.mu.RLock()
+	failedAt, failed := c.failed[key]
+	c.mu.RUnlock()
+	if failed && time.Since(failedAt) < retryInterval {
+		return Metadata{}, fmt.Errorf("read metadata of token %s failed at %s", key.address.Hex(), failedAt.Format(time.RFC3339))
+	}
+
+	metadata, err := c.fetch(ctx, key)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		log.Warn("read token metadata failed", "layer", key.layer, "token", key.address.Hex(), "error", err)
+		c.failed[key] = time.Now()
+		c.tokenMetadataFetchFailureTotal.WithLabelValues(key.layer.String()).Inc()
+		return Metadata{}, err
+	}
+	delete(c.failed, key)
+	c.tokens[key] = metadata
+	return metadata, nil
+}
+
+func (c *Cache) fetch(ctx context.Context, key tokenKey) (Metadata, error) {
+	client := c.l1Client
+	if key.layer == types.Layer2 {
+		client = c.l2Client
+	}
+
+	call := func(method string) ([]byte, error) {
+		return client.CallContract(ctx, ethereum.CallMsg{To: &key.address, Data: c.metadataABI.Methods[method].ID}, nil)
+	}
+
+	data, err := call("decimals")
+	if err != nil {
+		return Metadata{}, fmt.Errorf("call decimals of token %s failed: %w", key.address.Hex(), err)
+	}
+	outputs, err := c.metadataABI.Unpack("decimals", data)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("unpack decimals of token %s failed: %w", key.address.Hex(), err)
+	}
+	decimals, ok := outputs[0].(uint8)
+	if !ok {
+		return Metadata{}, fmt.Errorf("unexpected decimals %v of token %s", outputs[0], key.address.Hex())
+	}
+
+	metadata := Metadata{Decimals: int32(decimals)}
+	// the name and symbol are optional in the erc20 standard, the token is still formatted with its decimals.
+	if data, err = call("symbol"); err == nil {
+		metadata.Symbol = c.unpackString("symbol", data)
+	}
+	if data, err = call("name"); err == nil {
+		metadata.Name = c.unpackString("name", data)
+	}
+	return metadata, nil
+}
+
+// unpackString unpacks a string output, some early tokens return bytes32 instead.
+func (c *Cache) unpackString(method string, data []byte) string {
+	outputs, err := c.metadataABI.Unpack(method, data)
+	if err == nil {
+		if s, ok := outputs[0].(string); ok {
+			return s
+		}
+	}
+	if len(data) == 32 {
+		return strings.TrimRight(string(data), "\x00")
+	}
+	return ""
+}
+
+// FormatAmount formats the amount in the smallest unit of the token with its symbol, e.g. 1.5 USDC. The amount is
+// returned as is if the metadata of the token isn't cached.
+func FormatAmount(layer types.LayerType, address common.Address, amount *big.Int) string {
+	if amount == nil {
+		return "<nil>"
+	}
+	return FormatDecimal(layer, address, decimal.NewFromBigInt(amount, 0))
+}
+
+// FormatDecimal formats the amount in the smallest unit of the token with its symbol like FormatAmount.
+func FormatDecimal(layer types.LayerType, address common.Address, amount decimal.Decimal) string {
+	metadata, exists := Lookup(layer, address)
+	if !exists {
+		return amount.String()
+	}
+	return WithSymbol(amount.Shift(-metadata.Decimals), metadata)
+}
+
+// FormatString formats the amount string in the smallest unit of the token like FormatAmount, the strings which
+// aren't a single amount such as the erc1155 amount lists are returned as is.
+func FormatString(layer types.LayerType, address common.Address, amount string) string {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return amount
+	}
+	return FormatDecimal(layer, address, value)
+}
+
+// WithSymbol appends the symbol of the token to the amount in token units.
+func WithSymbol(amount decimal.Decimal, metadata Metadata) string {
+	if metadata.Symbol == "" {
+		return amount.String()
+	}
+	return fmt.Sprintf("%s %s", amount.String(), metadata.Symbol)
+}
@@ -0,0 +1,265 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/config"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// erc20DecimalsABI only contains the decimals method of the erc20 tokens.
+const erc20DecimalsABI = `[
+	{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
+
+const ethDecimals int32 = 18
+
+// LogicTransferAlert alerts on the single deposits and withdrawals above the threshold of their token, and on the
+// net outflow of a token from the l1 escrow exceeding its limit within a rolling window of l1 blocks.
+//
+// The thresholds are configured in token units and scaled by the decimals of the token, the ETH is matched by the
+// zero token address.
+type LogicTransferAlert struct {
+	gatewayMessageMatchOrm   *orm.GatewayMessageMatch
+	messengerMessageMatchOrm *orm.MessengerMessageMatch
+	l1Client                 *ethclient.Client
+	decimalsABI              abi.ABI
+
+	tokens map[common.Address]config.TransferAlertToken
+
+	// the l1 and l2 watchers check the transfers concurrently.
+	mu       sync.Mutex
+	decimals map[common.Address]int32
+	// the tokens already alerted, to alert once until the outflow is back within the limit.
+	outflowAlerted map[common.Address]bool
+}
+
+// NewLogicTransferAlert validates the configured thresholds and creates a new LogicTransferAlert instance.
+func NewLogicTransferAlert(cfg *config.Config, db *gorm.DB, l1Client *ethclient.Client) (*LogicTransferAlert, error) {
+	decimalsABI, err := abi.JSON(strings.NewReader(erc20DecimalsABI))
+	if err != nil {
+		return nil, err
+	}
+
+	l := &LogicTransferAlert{
+		gatewayMessageMatchOrm:   orm.NewGatewayMessageMatch(db),
+		messengerMessageMatchOrm: orm.NewMessengerMessageMatch(db),
+		l1Client:                 l1Client,
+		decimalsABI:              decimalsABI,
+		tokens:                   make(map[common.Address]config.TransferAlertToken),
+		decimals:                 map[common.Address]int32{{}: ethDecimals},
+		outflowAlerted:           make(map[common.Address]bool),
+	}
+	if cfg.TransferAlertConfig == nil {
+		return l, nil
+	}
+
+	for _, token := range cfg.TransferAlertConfig.Tokens {
+		if _, exists := l.tokens[token.L1Token]; exists {
+			return nil, fmt.Errorf("transfer alert token %s is configured twice", token.L1Token.Hex())
+		}
+		if token.LargeTransfer.IsNegative() || token.OutflowLimit.IsNegative() {
+			return nil, fmt.Errorf("transfer alert token %s has negative threshold", token.Name)
+		}
+		if token.OutflowLimit.IsPositive() && token.OutflowWindowBlocks == 0 {
+			return nil, fmt.Errorf("transfer alert token %s has outflow limit without window", token.Name)
+		}
+		if token.Decimals != nil {
+			l.decimals[token.L1Token] = *token.Decimals
+		}
+		l.tokens[token.L1Token] = token
+	}
+	return l, nil
+}
+
+// CheckLargeTransfers alerts on the deposits and withdrawals of the message matches of a processed range whose
+// amount exceeds the large transfer threshold of their token.
+func (l *LogicTransferAlert) CheckLargeTransfers(ctx context.Context, layer types.LayerType, gatewayMessageMatches []orm.GatewayMessageMatch, messengerMessageMatches []orm.MessengerMessageMatch) {
+	if len(l.tokens) == 0 {
+		return
+	}
+
+	for _, message := range gatewayMessageMatches {
+		if message.TokenType != int(types.TokenTypeERC20) {
+			continue
+		}
+		switch {
+		case layer == types.Layer1 && message.L1EventType == int(types.L1DepositERC20):
+			l.checkLargeTransfer(ctx, slack.LargeTransferInfo{
+				L1Token:     common.HexToAddress(message.L1L1Token),
+				Layer:       layer,
+				EventType:   types.L1DepositERC20,
+				Amount:      parseAmount(message.L1Amounts),
+				BlockNumber: message.L1BlockNumber,
+				TxHash:      message.L1TxHash,
+				MessageHash: message.MessageHash,
+			})
+		case layer == types.Layer2 && message.L2EventType == int(types.L2WithdrawERC20):
+			l.checkLargeTransfer(ctx, slack.LargeTransferInfo{
+				L1Token:     common.HexToAddress(message.L2L1Token),
+				Layer:       layer,
+				EventType:   types.L2WithdrawERC20,
+				Amount:      parseAmount(message.L2Amounts),
+				BlockNumber: message.L2BlockNumber,
+				TxHash:      message.L2TxHash,
+				MessageHash: message.MessageHash,
+			})
+		}
+	}
+
+	for _, message := range messengerMessageMatches {
+		switch {
+		case layer == types.Layer1 && message.L1EventType == int(types.L1SentMessage):
+			l.checkLargeTransfer(ctx, slack.LargeTransferInfo{
+				Layer:       layer,
+				EventType:   types.L1SentMessage,
+				Amount:      parseAmount(message.ETHAmount),
+				BlockNumber: message.L1BlockNumber,
+				TxHash:      message.L1TxHash,
+				MessageHash: message.MessageHash,
+			})
+		case layer == types.Layer2 && message.L2EventType == int(types.L2SentMessage):
+			l.checkLargeTransfer(ctx, slack.LargeTransferInfo{
+				Layer:       layer,
+				EventType:   types.L2SentMessage,
+				Amount:      parseAmount(message.ETHAmount),
+				BlockNumber: message.L2BlockNumber,
+				TxHash:      message.L2TxHash,
+				MessageHash: message.MessageHash,
+			})
+		}
+	}
+}
+
+func (l *LogicTransferAlert) checkLargeTransfer(ctx context.Context, info slack.LargeTransferInfo) {
+	token, exists := l.tokens[info.L1Token]
+	if !exists || !token.LargeTransfer.IsPositive() {
+		return
+	}
+
+	decimals, err := l.tokenDecimals(ctx, info.L1Token)
+	if err != nil {
+		log.Error("get token decimals failed", "token", token.Name, "l1 token", info.L1Token.Hex(), "error", err)
+		return
+	}
+
+	amount := info.Amount.Shift(-decimals)
+	if amount.LessThanOrEqual(token.LargeTransfer) {
+		return
+	}
+
+	info.Token = token.Name
+	info.Amount = amount
+	info.Threshold = token.LargeTransfer
+	log.Warn("large bridge transfer", "token", token.Name, "layer", info.Layer, "event type", info.EventType, "amount", amount, "threshold", token.LargeTransfer, "tx hash", info.TxHash)
+	slack.Notify(slack.MrkDwnLargeTransferMessage(info))
+}
+
+// CheckOutflow compares the net outflow of every token with an outflow limit from the l1 escrow, the finalized
+// withdrawals minus the deposits over the window ending at the processed l1BlockNumber, with the limit.
+func (l *LogicTransferAlert) CheckOutflow(ctx context.Context, l1BlockNumber uint64) {
+	for _, token := range l.tokens {
+		if !token.OutflowLimit.IsPositive() {
+			continue
+		}
+
+		var startBlockNumber uint64
+		if l1BlockNumber >= token.OutflowWindowBlocks {
+			startBlockNumber = l1BlockNumber - token.OutflowWindowBlocks + 1
+		}
+
+		var deposited, withdrawn decimal.Decimal
+		var err error
+		if token.L1Token == (common.Address{}) {
+			deposited, withdrawn, err = l.messengerMessageMatchOrm.GetL1ETHFlows(ctx, startBlockNumber, l1BlockNumber)
+		} else {
+			deposited, withdrawn, err = l.gatewayMessageMatchOrm.GetL1ERC20Flows(ctx, token.L1Token.Hex(), startBlockNumber, l1BlockNumber)
+		}
+		if err != nil {
+			log.Error("get l1 escrow flows failed", "token", token.Name, "l1 token", token.L1Token.Hex(), "error", err)
+			continue
+		}
+
+		decimals, err := l.tokenDecimals(ctx, token.L1Token)
+		if err != nil {
+			log.Error("get token decimals failed", "token", token.Name, "l1 token", token.L1Token.Hex(), "error", err)
+			continue
+		}
+
+		netOutflow := withdrawn.Sub(deposited).Shift(-decimals)
+		l.mu.Lock()
+		if netOutflow.LessThanOrEqual(token.OutflowLimit) {
+			delete(l.outflowAlerted, token.L1Token)
+			l.mu.Unlock()
+			continue
+		}
+		alerted := l.outflowAlerted[token.L1Token]
+		l.outflowAlerted[token.L1Token] = true
+		l.mu.Unlock()
+
+		log.Warn("l1 escrow net outflow exceeds limit", "token", token.Name, "start", startBlockNumber, "end", l1BlockNumber, "net outflow", netOutflow, "limit", token.OutflowLimit)
+		if alerted {
+			continue
+		}
+
+		slack.Notify(slack.MrkDwnOutflowVelocityMessage(slack.OutflowVelocityInfo{
+			Token:            token.Name,
+			L1Token:          token.L1Token,
+			StartBlockNumber: startBlockNumber,
+			EndBlockNumber:   l1BlockNumber,
+			Deposited:        deposited.Shift(-decimals),
+			Withdrawn:        withdrawn.Shift(-decimals),
+			NetOutflow:       netOutflow,
+			Limit:            token.OutflowLimit,
+		}))
+	}
+}
+
+// tokenDecimals returns the configured decimals of the token, or reads them from the l1 token once.
+func (l *LogicTransferAlert) tokenDecimals(ctx context.Context, l1Token common.Address) (int32, error) {
+	l.mu.Lock()
+	decimals, exists := l.decimals[l1Token]
+	l.mu.Unlock()
+	if exists {
+		return decimals, nil
+	}
+
+	data, err := l.l1Client.CallContract(ctx, ethereum.CallMsg{To: &l1Token, Data: l.decimalsABI.Methods["decimals"].ID}, nil)
+	if err != nil {
+		return 0, err
+	}
+	outputs, err := l.decimalsABI.Unpack("decimals", data)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := outputs[0].(uint8)
+	if !ok {
+		return 0, fmt.Errorf("unexpected decimals output %v", outputs[0])
+	}
+
+	l.mu.Lock()
+	l.decimals[l1Token] = int32(value)
+	l.mu.Unlock()
+	return int32(value), nil
+}
+
+func parseAmount(amount string) decimal.Decimal {
+	value, err := decimal.NewFromString(amount)
+	if err != nil {
+		return decimal.Zero
+	}
+	return value
+}
@@ -0,0 +1,72 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"github.com/scroll-tech/chain-monitor/internal/logic/events"
+	"github.com/scroll-tech/chain-monitor/internal/logic/slack"
+	"github.com/scroll-tech/chain-monitor/internal/orm"
+)
+
+// LogicWatcher records the events of the contracts watched by config and alerts on them.
+type LogicWatcher struct {
+	watchedEventOrm *orm.WatchedEvent
+}
+
+// NewLogicWatcher creates a new LogicWatcher instance.
+func NewLogicWatcher(db *gorm.DB) *LogicWatcher {
+	return &LogicWatcher{
+		watchedEventOrm: orm.NewWatchedEvent(db),
+	}
+}
+
+// InsertWatchedEvents stores the watched events, and returns the events recorded for the first time which match the
+// alert conditions of their config, they're alerted once the transaction is committed.
+func (w *LogicWatcher) InsertWatchedEvents(ctx context.Context, watchedEvents []events.WatchedEvent, dbTX *gorm.DB) ([]events.WatchedEvent, error) {
+	var alerts []events.WatchedEvent
+	for _, event := range watchedEvents {
+		args, err := json.Marshal(event.Args)
+		if err != nil {
+			return nil, fmt.Errorf("marshal watched event args failed, err: %w, tx hash:%s", err, event.TxHash.Hex())
+		}
+
+		watchedEvent := orm.WatchedEvent{
+			Layer:           int(event.Layer),
+			ContractName:    event.ContractName,
+			ContractAddress: event.ContractAddress.Hex(),
+			EventName:       event.EventName,
+			BlockNumber:     event.Number,
+			TxHash:          event.TxHash.Hex(),
+			LogIndex:        event.Index,
+			Args:            string(args),
+		}
+		effectRow, err := w.watchedEventOrm.InsertWatchedEvent(ctx, watchedEvent, dbTX)
+		if err != nil {
+			return nil, fmt.Errorf("watched event orm insert failed, err: %w, layer:%s", err, event.Layer.String())
+		}
+
+		if effectRow == 0 {
+			log.Debug("watched event already recorded", "layer", event.Layer, "tx hash", event.TxHash, "log index", event.Index)
+			continue
+		}
+
+		if event.Alert == nil || !events.MatchConditions(event.Args, event.Alert.Conditions) {
+			continue
+		}
+		alerts = append(alerts, event)
+	}
+	return alerts, nil
+}
+
+// NotifyWatchedEvents raises an alert for each newly recorded watched event matching its alert conditions.
+func (w *LogicWatcher) NotifyWatchedEvents(watchedEvents []events.WatchedEvent) {
+	for _, event := range watchedEvents {
+		log.Warn("watched contract event", "layer", event.Layer, "contract", event.ContractName, "event", event.EventName, "tx hash", event.TxHash, "args", event.Args)
+		slack.Notify(slack.MrkDwnWatchedEventMessage(event))
+	}
+}
@@ -0,0 +1,100 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+)
+
+// AlertSilence a silence of the alerts matching its matchers between starts at and ends at, e.g. during a contract
+// upgrade or a node maintenance. The empty matchers match every alert, and a silence has at least one matcher.
+type AlertSilence struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID int64 `json:"id" gorm:"column:id"`
+	// Kind the alert kind, e.g. proxy_drift.
+	Kind string `json:"kind" gorm:"column:kind"`
+	// Layer l1 or l2.
+	Layer string `json:"layer" gorm:"column:layer"`
+	// Token the token address or symbol.
+	Token string `json:"token" gorm:"column:token"`
+	// Address the contract or account address.
+	Address   string    `json:"address" gorm:"column:address"`
+	StartsAt  time.Time `json:"starts_at" gorm:"column:starts_at"`
+	EndsAt    time.Time `json:"ends_at" gorm:"column:ends_at"`
+	CreatedBy string    `json:"created_by" gorm:"column:created_by"`
+	Reason    string    `json:"reason" gorm:"column:reason"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewAlertSilence creates a new AlertSilence database instance.
+func NewAlertSilence(db *gorm.DB) *AlertSilence {
+	return &AlertSilence{db: db}
+}
+
+// TableName returns the table name for the AlertSilence model.
+func (*AlertSilence) TableName() string {
+	return "alert_silence"
+}
+
+// InsertAlertSilence inserts the silence and returns its id.
+func (a *AlertSilence) InsertAlertSilence(ctx context.Context, silence AlertSilence) (int64, error) {
+	db := a.db.WithContext(ctx)
+	db = db.Model(&AlertSilence{})
+	if err := db.Create(&silence).Error; err != nil {
+		log.Warn("AlertSilence.InsertAlertSilence failed", "error", err)
+		return 0, fmt.Errorf("AlertSilence.InsertAlertSilence failed err:%w, silence: %v", err, silence)
+	}
+	return silence.ID, nil
+}
+
+// GetActiveAlertSilences get the silences active at the time, which start at or before it and end after it.
+func (a *AlertSilence) GetActiveAlertSilences(ctx context.Context, now time.Time) ([]AlertSilence, error) {
+	var silences []AlertSilence
+	db := a.db.WithContext(ctx)
+	db = db.Where("starts_at <= ? AND ends_at > ?", now, now)
+	db = db.Order("id asc")
+	if err := db.Find(&silences).Error; err != nil {
+		log.Warn("AlertSilence.GetActiveAlertSilences failed", "error", err)
+		return nil, fmt.Errorf("AlertSilence.GetActiveAlertSilences failed err:%w", err)
+	}
+	return silences, nil
+}
+
+// GetUnexpiredAlertSilences get the silences not ended at the time, the active and the pending ones, by starts at.
+func (a *AlertSilence) GetUnexpiredAlertSilences(ctx context.Context, now time.Time) ([]AlertSilence, error) {
+	var silences []AlertSilence
+	db := a.db.WithContext(ctx)
+	db = db.Where("ends_at > ?", now)
+	db = db.Order("starts_at asc, id asc")
+	if err := db.Find(&silences).Error; err != nil {
+		log.Warn("AlertSilence.GetUnexpiredAlertSilences failed", "error", err)
+		return nil, fmt.Errorf("AlertSilence.GetUnexpiredAlertSilences failed err:%w", err)
+	}
+	return silences, nil
+}
+
+// ExpireAlertSilence ends the unexpired silence at the time, a pending silence never starts. It returns zero affected
+// rows if the silence doesn't exist or is expired.
+func (a *AlertSilence) ExpireAlertSilence(ctx context.Context, id int64, now time.Time) (int64, error) {
+	db := a.db.WithContext(ctx)
+	db = db.Model(&AlertSilence{})
+	db = db.Where("id = ? AND ends_at > ?", id, now)
+
+	updateFields := map[string]interface{}{
+		"ends_at":    now,
+		"updated_at": now,
+	}
+	result := db.Updates(updateFields)
+	if result.Error != nil {
+		log.Warn("AlertSilence.ExpireAlertSilence failed", "error", result.Error)
+		return 0, fmt.Errorf("AlertSilence.ExpireAlertSilence failed err:%w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
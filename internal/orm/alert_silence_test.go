@@ -0,0 +1,105 @@
+package orm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestAlertSilence(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	silenceOrm := NewAlertSilence(db)
+	silencedOrm := NewSilencedAlert(db)
+
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	var activeID int64
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"insertAlertSilence", func(t *testing.T) {
+				id, err := silenceOrm.InsertAlertSilence(ctx, AlertSilence{
+					Kind:      "proxy_drift",
+					Layer:     "l1",
+					StartsAt:  now.Add(-time.Hour),
+					EndsAt:    now.Add(time.Hour),
+					CreatedBy: "alice",
+					Reason:    "l1 gateway upgrade",
+				})
+				assert.NoError(t, err)
+				assert.NotZero(t, id)
+				activeID = id
+
+				_, err = silenceOrm.InsertAlertSilence(ctx, AlertSilence{
+					Address:   "0xa13baf47339d63b743e7da8741db5456dac1e556",
+					StartsAt:  now.Add(time.Hour),
+					EndsAt:    now.Add(2 * time.Hour),
+					CreatedBy: "bob",
+					Reason:    "node maintenance",
+				})
+				assert.NoError(t, err)
+
+				_, err = silenceOrm.InsertAlertSilence(ctx, AlertSilence{
+					Token:     "USDC",
+					StartsAt:  now.Add(-2 * time.Hour),
+					EndsAt:    now.Add(-time.Hour),
+					CreatedBy: "bob",
+					Reason:    "ended",
+				})
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"getAlertSilences", func(t *testing.T) {
+				active, err := silenceOrm.GetActiveAlertSilences(ctx, now)
+				assert.NoError(t, err)
+				assert.Len(t, active, 1)
+				assert.Equal(t, activeID, active[0].ID)
+				assert.Equal(t, "alice", active[0].CreatedBy)
+
+				unexpired, err := silenceOrm.GetUnexpiredAlertSilences(ctx, now)
+				assert.NoError(t, err)
+				assert.Len(t, unexpired, 2)
+				assert.Equal(t, "node maintenance", unexpired[1].Reason)
+			},
+		},
+		{
+			"silencedAlerts", func(t *testing.T) {
+				for _, title := range []string{"first", "second"} {
+					err := silencedOrm.InsertSilencedAlert(ctx, SilencedAlert{SilenceID: activeID, Kind: "proxy_drift", Severity: "critical", Title: title, Content: title})
+					assert.NoError(t, err)
+				}
+				alerts, err := silencedOrm.GetSilencedAlerts(ctx, activeID, 1)
+				assert.NoError(t, err)
+				assert.Len(t, alerts, 1)
+				assert.Equal(t, "second", alerts[0].Title)
+			},
+		},
+		{
+			"expireAlertSilence", func(t *testing.T) {
+				affectRows, err := silenceOrm.ExpireAlertSilence(ctx, activeID, now)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), affectRows)
+
+				affectRows, err = silenceOrm.ExpireAlertSilence(ctx, activeID, now)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(0), affectRows)
+
+				active, err := silenceOrm.GetActiveAlertSilences(ctx, now)
+				assert.NoError(t, err)
+				assert.Len(t, active, 0)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
@@ -0,0 +1,82 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// ContractBaseline is the pinned proxy state of a bridge contract, the drift checker alerts on any change of it.
+type ContractBaseline struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID                     int64  `json:"id" gorm:"column:id"`
+	Layer                  int    `json:"layer" gorm:"layer"`
+	Name                   string `json:"name" gorm:"name"`
+	ContractAddress        string `json:"contract_address" gorm:"contract_address"`
+	Implementation         string `json:"implementation" gorm:"implementation"`
+	Admin                  string `json:"admin" gorm:"admin"`
+	CodeHash               string `json:"code_hash" gorm:"code_hash"`
+	ImplementationCodeHash string `json:"implementation_code_hash" gorm:"implementation_code_hash"`
+	// the block number the baseline is snapshotted at.
+	BlockNumber uint64 `json:"block_number" gorm:"block_number"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewContractBaseline creates a new ContractBaseline database instance.
+func NewContractBaseline(db *gorm.DB) *ContractBaseline {
+	return &ContractBaseline{db: db}
+}
+
+// TableName returns the table name for the ContractBaseline model.
+func (*ContractBaseline) TableName() string {
+	return "contract_baseline"
+}
+
+// GetContractBaselines get the pinned baselines of the layer
+func (c *ContractBaseline) GetContractBaselines(ctx context.Context, layer types.LayerType) ([]ContractBaseline, error) {
+	var baselines []ContractBaseline
+	db := c.db.WithContext(ctx)
+	db = db.Where("layer = ?", layer)
+	db = db.Order("id asc")
+	if err := db.Find(&baselines).Error; err != nil {
+		log.Warn("ContractBaseline.GetContractBaselines failed", "error", err)
+		return nil, fmt.Errorf("ContractBaseline.GetContractBaselines failed err:%w", err)
+	}
+	return baselines, nil
+}
+
+// InsertOrUpdateContractBaselines pins the baselines, the baseline of a contract already pinned is replaced.
+func (c *ContractBaseline) InsertOrUpdateContractBaselines(ctx context.Context, baselines []ContractBaseline, dbTX ...*gorm.DB) (int64, error) {
+	if len(baselines) == 0 {
+		return 0, nil
+	}
+
+	db := c.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+
+	db = db.WithContext(ctx)
+	db = db.Model(&ContractBaseline{})
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "layer"}, {Name: "contract_address"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "implementation", "admin", "code_hash", "implementation_code_hash", "block_number", "updated_at"}),
+	})
+
+	result := db.Create(&baselines)
+	if result.Error != nil {
+		log.Warn("ContractBaseline.InsertOrUpdateContractBaselines failed", "error", result.Error)
+		return 0, fmt.Errorf("ContractBaseline.InsertOrUpdateContractBaselines failed err:%w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,69 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestContractBaseline_InsertOrUpdateContractBaselines(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	baselineOrm := NewContractBaseline(db)
+
+	messenger := ContractBaseline{
+		Layer:                  int(types.Layer1),
+		Name:                   "scroll_messenger",
+		ContractAddress:        "0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367",
+		Implementation:         "0x1234567890123456789012345678901234567890",
+		Admin:                  "0xEB803eb3F501998126bf37bB823646Ed3D59d072",
+		CodeHash:               "0xfc7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a",
+		ImplementationCodeHash: "0x9dc20c4ecc42d1ee1e0a05e1c07e9e2bb1f4b2d4fa3b24c3b1c1c6a0ab41e9b1",
+		BlockNumber:            100,
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"insertBaselines", func(t *testing.T) {
+				affectRows, err := baselineOrm.InsertOrUpdateContractBaselines(ctx, []ContractBaseline{messenger})
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				baselines, err := baselineOrm.GetContractBaselines(ctx, types.Layer1)
+				assert.NoError(t, err)
+				assert.Len(t, baselines, 1)
+				assert.Equal(t, baselines[0].Implementation, messenger.Implementation)
+
+				baselines, err = baselineOrm.GetContractBaselines(ctx, types.Layer2)
+				assert.NoError(t, err)
+				assert.Len(t, baselines, 0)
+			},
+		},
+		{
+			"updateBaselines", func(t *testing.T) {
+				upgraded := messenger
+				upgraded.Implementation = "0x0987654321098765432109876543210987654321"
+				upgraded.BlockNumber = 200
+				_, err := baselineOrm.InsertOrUpdateContractBaselines(ctx, []ContractBaseline{upgraded})
+				assert.NoError(t, err)
+
+				baselines, err := baselineOrm.GetContractBaselines(ctx, types.Layer1)
+				assert.NoError(t, err)
+				assert.Len(t, baselines, 1)
+				assert.Equal(t, baselines[0].Implementation, upgraded.Implementation)
+				assert.Equal(t, baselines[0].BlockNumber, uint64(200))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -27,6 +28,10 @@ type GatewayMessageMatch struct {
 	L1TxHash      string `json:"l1_tx_hash" gorm:"l1_tx_hash"`
 	L1TokenIds    string `json:"l1_token_ids" gorm:"l1_token_ids"`
 	L1Amounts     string `json:"l1_amounts" gorm:"l1_amounts"`
+	L1From        string `json:"l1_from" gorm:"l1_from"`
+	L1To          string `json:"l1_to" gorm:"l1_to"`
+	L1L1Token     string `json:"l1_l1_token" gorm:"l1_l1_token"`
+	L1L2Token     string `json:"l1_l2_token" gorm:"l1_l2_token"`
 
 	// l2 event info
 	L2EventType   int    `json:"l2_event_type" gorm:"l2_event_type"`
@@ -34,6 +39,10 @@ type GatewayMessageMatch struct {
 	L2TxHash      string `json:"l2_tx_hash" gorm:"l2_tx_hash"`
 	L2TokenIds    string `json:"l2_token_ids" gorm:"l2_token_ids"`
 	L2Amounts     string `json:"l2_amounts" gorm:"l2_amounts"`
+	L2From        string `json:"l2_from" gorm:"l2_from"`
+	L2To          string `json:"l2_to" gorm:"l2_to"`
+	L2L1Token     string `json:"l2_l1_token" gorm:"l2_l1_token"`
+	L2L2Token     string `json:"l2_l2_token" gorm:"l2_l2_token"`
 
 	// status
 	L1BlockStatus      int `json:"l1_block_status" gorm:"l1_block_status"`
@@ -41,6 +50,12 @@ type GatewayMessageMatch struct {
 	L1CrossChainStatus int `json:"l1_cross_chain_status" gorm:"l1_cross_chain_status"`
 	L2CrossChainStatus int `json:"l2_cross_chain_status" gorm:"l2_cross_chain_status"`
 
+	// mismatch reason of the invalid cross chain status, the diff is a json array of the differing fields.
+	L1MismatchType int    `json:"l1_mismatch_type" gorm:"l1_mismatch_type"`
+	L2MismatchType int    `json:"l2_mismatch_type" gorm:"l2_mismatch_type"`
+	L1MismatchDiff string `json:"l1_mismatch_diff" gorm:"l1_mismatch_diff"`
+	L2MismatchDiff string `json:"l2_mismatch_diff" gorm:"l2_mismatch_diff"`
+
 	L1BlockStatusUpdatedAt      time.Time      `json:"l1_block_status_updated_at" gorm:"l1_block_status_updated_at"`
 	L2BlockStatusUpdatedAt      time.Time      `json:"l2_block_status_updated_at" gorm:"l2_block_status_updated_at"`
 	L1CrossChainStatusUpdatedAt time.Time      `json:"l1_cross_chain_status_updated_at" gorm:"l1_cross_chain_status_updated_at"`
@@ -72,6 +87,94 @@ func (m *GatewayMessageMatch) GetBlocksStatus(ctx context.Context, startBlockNum
 	return messages, nil
 }
 
+// GetL1ERC20Flows sums the l1 deposited and finalized withdrawn amounts of the erc20 token between the l1
+// startBlockNumber and endBlockNumber, in the smallest unit of the token.
+func (m *GatewayMessageMatch) GetL1ERC20Flows(ctx context.Context, l1Token string, startBlockNumber, endBlockNumber uint64) (decimal.Decimal, decimal.Decimal, error) {
+	var flows struct {
+		Deposited decimal.Decimal
+		Withdrawn decimal.Decimal
+	}
+	db := m.db.WithContext(ctx)
+	db = db.Model(&GatewayMessageMatch{})
+	db = db.Select("COALESCE(SUM(CASE WHEN l1_event_type = ? THEN CAST(l1_amounts AS NUMERIC) END), 0) AS deposited, "+
+		"COALESCE(SUM(CASE WHEN l1_event_type = ? THEN CAST(l1_amounts AS NUMERIC) END), 0) AS withdrawn",
+		types.L1DepositERC20, types.L1FinalizeWithdrawERC20)
+	db = db.Where("token_type = ?", types.TokenTypeERC20)
+	db = db.Where("l1_l1_token = ?", l1Token)
+	db = db.Where("l1_event_type IN ?", []types.EventType{types.L1DepositERC20, types.L1FinalizeWithdrawERC20})
+	db = db.Where("l1_block_number >= ? AND l1_block_number <= ?", startBlockNumber, endBlockNumber)
+	if err := db.Scan(&flows).Error; err != nil {
+		log.Warn("GatewayMessageMatch.GetL1ERC20Flows failed", "error", err)
+		return decimal.Zero, decimal.Zero, fmt.Errorf("GatewayMessageMatch.GetL1ERC20Flows failed err:%w", err)
+	}
+	return flows.Deposited, flows.Withdrawn, nil
+}
+
+// TokenMessageCount the number of the messages of a token, the token is the l1 token address.
+type TokenMessageCount struct {
+	TokenType int
+	Token     string
+	Count     int64
+}
+
+// MismatchCount the number of the cross chain mismatches of a mismatch type.
+type MismatchCount struct {
+	MismatchType int
+	Count        int64
+}
+
+// GetProcessedMessageCounts get the number of the messages of each token whose block of the layer was processed
+// between startTime and endTime.
+func (m *GatewayMessageMatch) GetProcessedMessageCounts(ctx context.Context, layer types.LayerType, startTime, endTime time.Time) ([]TokenMessageCount, error) {
+	var counts []TokenMessageCount
+	db := m.db.WithContext(ctx)
+	db = db.Model(&GatewayMessageMatch{})
+	switch layer {
+	case types.Layer1:
+		db = db.Select("token_type, l1_l1_token AS token, COUNT(*) AS count")
+		db = db.Where("l1_block_status = ?", types.BlockStatusTypeValid)
+		db = db.Where("l1_block_status_updated_at >= ? AND l1_block_status_updated_at < ?", startTime, endTime)
+		db = db.Group("token_type, l1_l1_token")
+	case types.Layer2:
+		db = db.Select("token_type, l2_l1_token AS token, COUNT(*) AS count")
+		db = db.Where("l2_block_status = ?", types.BlockStatusTypeValid)
+		db = db.Where("l2_block_status_updated_at >= ? AND l2_block_status_updated_at < ?", startTime, endTime)
+		db = db.Group("token_type, l2_l1_token")
+	}
+	db = db.Order("count DESC")
+	if err := db.Scan(&counts).Error; err != nil {
+		log.Warn("GatewayMessageMatch.GetProcessedMessageCounts failed", "error", err)
+		return nil, fmt.Errorf("GatewayMessageMatch.GetProcessedMessageCounts failed err:%w", err)
+	}
+	return counts, nil
+}
+
+// GetMismatchCounts get the number of the cross chain mismatches of the layer of each mismatch type found between
+// startTime and endTime.
+func (m *GatewayMessageMatch) GetMismatchCounts(ctx context.Context, layer types.LayerType, startTime, endTime time.Time) ([]MismatchCount, error) {
+	var counts []MismatchCount
+	db := m.db.WithContext(ctx)
+	db = db.Model(&GatewayMessageMatch{})
+	switch layer {
+	case types.Layer1:
+		db = db.Select("l1_mismatch_type AS mismatch_type, COUNT(*) AS count")
+		db = db.Where("l1_cross_chain_status = ?", types.CrossChainStatusTypeInvalid)
+		db = db.Where("l1_cross_chain_status_updated_at >= ? AND l1_cross_chain_status_updated_at < ?", startTime, endTime)
+		db = db.Group("l1_mismatch_type")
+	case types.Layer2:
+		db = db.Select("l2_mismatch_type AS mismatch_type, COUNT(*) AS count")
+		db = db.Where("l2_cross_chain_status = ?", types.CrossChainStatusTypeInvalid)
+		db = db.Where("l2_cross_chain_status_updated_at >= ? AND l2_cross_chain_status_updated_at < ?", startTime, endTime)
+		db = db.Group("l2_mismatch_type")
+	}
+	db = db.Order("mismatch_type")
+	if err := db.Scan(&counts).Error; err != nil {
+		log.Warn("GatewayMessageMatch.GetMismatchCounts failed", "error", err)
+		return nil, fmt.Errorf("GatewayMessageMatch.GetMismatchCounts failed err:%w", err)
+	}
+	return counts, nil
+}
+
 // GetUncheckedAndDoubleLayerValidGatewayMessageMatches retrieves the earliest unchecked gateway message match records
 // that are valid in both Layer1 and Layer2.
 func (m *GatewayMessageMatch) GetUncheckedAndDoubleLayerValidGatewayMessageMatches(ctx context.Context, layer types.LayerType, limit int) ([]GatewayMessageMatch, error) {
@@ -82,8 +185,10 @@ func (m *GatewayMessageMatch) GetUncheckedAndDoubleLayerValidGatewayMessageMatch
 	switch layer {
 	case types.Layer1:
 		db = db.Where("l1_cross_chain_status = ?", types.CrossChainStatusTypeInvalid)
+		db = db.Where("l1_mismatch_type = ?", types.MismatchTypeUnknown)
 	case types.Layer2:
 		db = db.Where("l2_cross_chain_status = ?", types.CrossChainStatusTypeInvalid)
+		db = db.Where("l2_mismatch_type = ?", types.MismatchTypeUnknown)
 	}
 	db = db.Limit(limit)
 	if err := db.Find(&messages).Error; err != nil {
@@ -105,10 +210,10 @@ func (m *GatewayMessageMatch) InsertOrUpdateEventInfo(ctx context.Context, layer
 	var assignmentColumn clause.Set
 	var where clause.Where
 	if layer == types.Layer1 {
-		assignmentColumn = clause.AssignmentColumns([]string{"token_type", "l1_block_number", "l1_tx_hash", "l1_event_type", "l1_token_ids", "l1_amounts", "l1_block_status", "l1_block_status_updated_at"})
+		assignmentColumn = clause.AssignmentColumns([]string{"token_type", "l1_block_number", "l1_tx_hash", "l1_event_type", "l1_token_ids", "l1_amounts", "l1_from", "l1_to", "l1_l1_token", "l1_l2_token", "l1_block_status", "l1_block_status_updated_at"})
 		where = clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "gateway_message_match.l1_block_number", Value: 0}}}
 	} else {
-		assignmentColumn = clause.AssignmentColumns([]string{"token_type", "l2_block_number", "l2_tx_hash", "l2_event_type", "l2_token_ids", "l2_amounts", "l2_block_status", "l2_block_status_updated_at"})
+		assignmentColumn = clause.AssignmentColumns([]string{"token_type", "l2_block_number", "l2_tx_hash", "l2_event_type", "l2_token_ids", "l2_amounts", "l2_from", "l2_to", "l2_l1_token", "l2_l2_token", "l2_block_status", "l2_block_status_updated_at"})
 		where = clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "gateway_message_match.l2_block_number", Value: 0}}}
 	}
 
@@ -152,6 +257,38 @@ func (m *GatewayMessageMatch) UpdateCrossChainStatus(ctx context.Context, id []i
 	return nil
 }
 
+// UpdateCrossChainMismatch marks the message match invalid for the layer, and records the mismatch type and diff
+// so the row is not checked again.
+func (m *GatewayMessageMatch) UpdateCrossChainMismatch(ctx context.Context, id int64, layer types.LayerType, mismatchType types.MismatchType, diff string) error {
+	db := m.db.WithContext(ctx)
+	db = db.Model(&GatewayMessageMatch{})
+	db = db.Where("id = ?", id)
+
+	var updateFields map[string]interface{}
+	switch layer {
+	case types.Layer1:
+		updateFields = map[string]interface{}{
+			"l1_cross_chain_status":            types.CrossChainStatusTypeInvalid,
+			"l1_mismatch_type":                 mismatchType,
+			"l1_mismatch_diff":                 diff,
+			"l1_cross_chain_status_updated_at": utils.NowUTC(),
+		}
+	case types.Layer2:
+		updateFields = map[string]interface{}{
+			"l2_cross_chain_status":            types.CrossChainStatusTypeInvalid,
+			"l2_mismatch_type":                 mismatchType,
+			"l2_mismatch_diff":                 diff,
+			"l2_cross_chain_status_updated_at": utils.NowUTC(),
+		}
+	}
+
+	if err := db.Updates(updateFields).Error; err != nil {
+		log.Warn("GatewayMessageMatch.UpdateCrossChainMismatch failed", "error", err)
+		return fmt.Errorf("GatewayMessageMatch.UpdateCrossChainMismatch failed err:%w", err)
+	}
+	return nil
+}
+
 // UpdateBlockStatus updates the block status for the given layer and block number range.
 func (m *GatewayMessageMatch) UpdateBlockStatus(ctx context.Context, layer types.LayerType, startBlockNumber, endBlockNumber uint64, dbTX ...*gorm.DB) error {
 	db := m.db
@@ -183,3 +320,60 @@ func (m *GatewayMessageMatch) UpdateBlockStatus(ctx context.Context, layer types
 	}
 	return nil
 }
+
+// ERC20TokenPair an erc20 token mapped by the gateways, the tokens are the checksummed addresses.
+type ERC20TokenPair struct {
+	L1Token string
+	L2Token string
+}
+
+// GetERC20TokenPairs get the distinct l1 and l2 token pairs of the erc20 deposits and finalized withdrawals on l1.
+func (m *GatewayMessageMatch) GetERC20TokenPairs(ctx context.Context) ([]ERC20TokenPair, error) {
+	var pairs []ERC20TokenPair
+	db := m.db.WithContext(ctx)
+	db = db.Model(&GatewayMessageMatch{})
+	db = db.Select("DISTINCT l1_l1_token AS l1_token, l1_l2_token AS l2_token")
+	db = db.Where("token_type = ?", types.TokenTypeERC20)
+	db = db.Where("l1_event_type IN ?", []types.EventType{types.L1DepositERC20, types.L1FinalizeWithdrawERC20})
+	db = db.Where("l1_l1_token <> '' AND l1_l2_token <> ''")
+	db = db.Order("l1_token")
+	if err := db.Scan(&pairs).Error; err != nil {
+		log.Warn("GatewayMessageMatch.GetERC20TokenPairs failed", "error", err)
+		return nil, fmt.Errorf("GatewayMessageMatch.GetERC20TokenPairs failed err:%w", err)
+	}
+	return pairs, nil
+}
+
+// GetERC20InFlightAmount sums the amount of the erc20 token escrowed on l1 but not minted on l2 at the l1
+// l1BlockNumber and the l2 l2BlockNumber, in the smallest unit of the token. A deposit counts from its l1 block until
+// it is finalized on l2, a withdrawal counts from its l2 block until it is finalized on l1, and an event processed on
+// the other layer first counts negatively.
+func (m *GatewayMessageMatch) GetERC20InFlightAmount(ctx context.Context, l1Token string, l1BlockNumber, l2BlockNumber uint64) (decimal.Decimal, error) {
+	var inFlight struct {
+		Amount decimal.Decimal
+	}
+	l1Processed := "l1_block_number > 0 AND l1_block_number <= @l1"
+	l2Processed := "l2_block_number > 0 AND l2_block_number <= @l2"
+	db := m.db.WithContext(ctx)
+	db = db.Model(&GatewayMessageMatch{})
+	db = db.Select("COALESCE(SUM("+
+		"CASE WHEN l1_event_type = @deposit AND "+l1Processed+" THEN CAST(l1_amounts AS NUMERIC) ELSE 0 END - "+
+		"CASE WHEN l2_event_type = @finalizeDeposit AND "+l2Processed+" THEN CAST(l2_amounts AS NUMERIC) ELSE 0 END + "+
+		"CASE WHEN l2_event_type = @withdraw AND "+l2Processed+" THEN CAST(l2_amounts AS NUMERIC) ELSE 0 END - "+
+		"CASE WHEN l1_event_type = @finalizeWithdraw AND "+l1Processed+" THEN CAST(l1_amounts AS NUMERIC) ELSE 0 END"+
+		"), 0) AS amount", map[string]interface{}{
+		"l1":               l1BlockNumber,
+		"l2":               l2BlockNumber,
+		"deposit":          types.L1DepositERC20,
+		"finalizeDeposit":  types.L2FinalizeDepositERC20,
+		"withdraw":         types.L2WithdrawERC20,
+		"finalizeWithdraw": types.L1FinalizeWithdrawERC20,
+	})
+	db = db.Where("token_type = ?", types.TokenTypeERC20)
+	db = db.Where("(l1_l1_token = ? OR l2_l1_token = ?)", l1Token, l1Token)
+	if err := db.Scan(&inFlight).Error; err != nil {
+		log.Warn("GatewayMessageMatch.GetERC20InFlightAmount failed", "error", err)
+		return decimal.Zero, fmt.Errorf("GatewayMessageMatch.GetERC20InFlightAmount failed err:%w", err)
+	}
+	return inFlight.Amount, nil
+}
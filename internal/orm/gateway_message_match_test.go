@@ -3,10 +3,12 @@ package orm
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
 	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
 )
 
@@ -79,6 +81,211 @@ func TestGatewayMessageMatch_InsertOrUpdateEventInfo(t *testing.T) {
 				assert.Equal(t, affectRows, int64(0))
 			},
 		},
+		{
+			name: "UpdateCrossChainMismatch",
+			test: func(t *testing.T) {
+				assert.NoError(t, gatewayMessageMatchOrm.UpdateBlockStatus(ctx, types.Layer1, 120, 120))
+				assert.NoError(t, gatewayMessageMatchOrm.UpdateBlockStatus(ctx, types.Layer2, 1200, 1200))
+
+				messages, err := gatewayMessageMatchOrm.GetUncheckedAndDoubleLayerValidGatewayMessageMatches(ctx, types.Layer1, 10)
+				assert.NoError(t, err)
+				assert.Len(t, messages, 1)
+
+				diff := `[{"field":"amount","expected":"200000000","actual":"100000000"}]`
+				err = gatewayMessageMatchOrm.UpdateCrossChainMismatch(ctx, messages[0].ID, types.Layer1, types.MismatchTypeL1AmountNotMatch, diff)
+				assert.NoError(t, err)
+
+				messages, err = gatewayMessageMatchOrm.GetUncheckedAndDoubleLayerValidGatewayMessageMatches(ctx, types.Layer1, 10)
+				assert.NoError(t, err)
+				assert.Len(t, messages, 0)
+
+				messages, err = gatewayMessageMatchOrm.GetBlocksStatus(ctx, 1200, 1200)
+				assert.NoError(t, err)
+				assert.Len(t, messages, 1)
+				assert.Equal(t, int(types.CrossChainStatusTypeInvalid), messages[0].L1CrossChainStatus)
+				assert.Equal(t, int(types.MismatchTypeL1AmountNotMatch), messages[0].L1MismatchType)
+				assert.Equal(t, diff, messages[0].L1MismatchDiff)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestGatewayMessageMatch_GetERC20InFlightAmount(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	gatewayMessageMatchOrm := NewGatewayMessageMatch(db)
+
+	l1Token := "0x0000000000000000000000000000000000000a01"
+	l2Token := "0x0000000000000000000000000000000000000b01"
+	l1Messages := []GatewayMessageMatch{
+		{MessageHash: "0x1", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 100, L1L1Token: l1Token, L1L2Token: l2Token, L1Amounts: "300"},
+		{MessageHash: "0x2", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 105, L1L1Token: l1Token, L1L2Token: l2Token, L1Amounts: "50"},
+		{MessageHash: "0x4", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1FinalizeWithdrawERC20), L1BlockNumber: 103, L1L1Token: l1Token, L1L2Token: l2Token, L1Amounts: "70"},
+		{MessageHash: "0x5", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 110, L1L1Token: l1Token, L1L2Token: l2Token, L1Amounts: "40"},
+		{MessageHash: "0x6", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 101, L1L1Token: "0x0000000000000000000000000000000000000a02", L1L2Token: "0x0000000000000000000000000000000000000b02", L1Amounts: "9000"},
+	}
+	for _, message := range l1Messages {
+		_, err := gatewayMessageMatchOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, message)
+		assert.NoError(t, err)
+	}
+	l2Messages := []GatewayMessageMatch{
+		{MessageHash: "0x1", TokenType: int(types.TokenTypeERC20), L2EventType: int(types.L2FinalizeDepositERC20), L2BlockNumber: 1000, L2L1Token: l1Token, L2L2Token: l2Token, L2Amounts: "300"},
+		{MessageHash: "0x3", TokenType: int(types.TokenTypeERC20), L2EventType: int(types.L2WithdrawERC20), L2BlockNumber: 1005, L2L1Token: l1Token, L2L2Token: l2Token, L2Amounts: "20"},
+		{MessageHash: "0x4", TokenType: int(types.TokenTypeERC20), L2EventType: int(types.L2WithdrawERC20), L2BlockNumber: 1001, L2L1Token: l1Token, L2L2Token: l2Token, L2Amounts: "70"},
+		{MessageHash: "0x5", TokenType: int(types.TokenTypeERC20), L2EventType: int(types.L2FinalizeDepositERC20), L2BlockNumber: 1002, L2L1Token: l1Token, L2L2Token: l2Token, L2Amounts: "40"},
+	}
+	for _, message := range l2Messages {
+		_, err := gatewayMessageMatchOrm.InsertOrUpdateEventInfo(ctx, types.Layer2, message)
+		assert.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "tokenPairs",
+			test: func(t *testing.T) {
+				pairs, err := gatewayMessageMatchOrm.GetERC20TokenPairs(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, []ERC20TokenPair{
+					{L1Token: l1Token, L2Token: l2Token},
+					{L1Token: "0x0000000000000000000000000000000000000a02", L2Token: "0x0000000000000000000000000000000000000b02"},
+				}, pairs)
+			},
+		},
+		{
+			name: "l2FinalizedBeforeL1Processed",
+			test: func(t *testing.T) {
+				// 0x2 deposited +50, 0x3 withdrawn +20, 0x5 finalized on l2 only -40.
+				inFlight, err := gatewayMessageMatchOrm.GetERC20InFlightAmount(ctx, l1Token, 108, 1010)
+				assert.NoError(t, err)
+				assert.Equal(t, "30", inFlight.String())
+			},
+		},
+		{
+			name: "bothLayersProcessed",
+			test: func(t *testing.T) {
+				inFlight, err := gatewayMessageMatchOrm.GetERC20InFlightAmount(ctx, l1Token, 200, 1010)
+				assert.NoError(t, err)
+				assert.Equal(t, "70", inFlight.String())
+			},
+		},
+		{
+			name: "l1FinalizedBeforeL2Processed",
+			test: func(t *testing.T) {
+				// 0x2 deposited +50, 0x4 finalized on l1 only -70.
+				inFlight, err := gatewayMessageMatchOrm.GetERC20InFlightAmount(ctx, l1Token, 108, 1000)
+				assert.NoError(t, err)
+				assert.Equal(t, "-20", inFlight.String())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestGatewayMessageMatch_GetL1ERC20Flows(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	gatewayMessageMatchOrm := NewGatewayMessageMatch(db)
+
+	l1Token := "0x0000000000000000000000000000000000000a01"
+	messages := []GatewayMessageMatch{
+		{MessageHash: "0x1", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 100, L1L1Token: l1Token, L1Amounts: "300"},
+		{MessageHash: "0x2", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1FinalizeWithdrawERC20), L1BlockNumber: 101, L1L1Token: l1Token, L1Amounts: "1000"},
+		{MessageHash: "0x3", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1FinalizeWithdrawERC20), L1BlockNumber: 102, L1L1Token: "0x0000000000000000000000000000000000000a02", L1Amounts: "5000"},
+		{MessageHash: "0x4", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1FinalizeWithdrawERC20), L1BlockNumber: 110, L1L1Token: l1Token, L1Amounts: "7000"},
+	}
+	for _, message := range messages {
+		_, err := gatewayMessageMatchOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, message)
+		assert.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "window",
+			test: func(t *testing.T) {
+				deposited, withdrawn, err := gatewayMessageMatchOrm.GetL1ERC20Flows(ctx, l1Token, 100, 105)
+				assert.NoError(t, err)
+				assert.Equal(t, "300", deposited.String())
+				assert.Equal(t, "1000", withdrawn.String())
+			},
+		},
+		{
+			name: "empty",
+			test: func(t *testing.T) {
+				deposited, withdrawn, err := gatewayMessageMatchOrm.GetL1ERC20Flows(ctx, l1Token, 200, 300)
+				assert.NoError(t, err)
+				assert.True(t, deposited.IsZero())
+				assert.True(t, withdrawn.IsZero())
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestGatewayMessageMatch_GetReportCounts(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	gatewayMessageMatchOrm := NewGatewayMessageMatch(db)
+
+	now := utils.NowUTC()
+	l1Token := "0x0000000000000000000000000000000000000a01"
+	messages := []GatewayMessageMatch{
+		{MessageHash: "0x1", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 100, L1L1Token: l1Token, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now},
+		{MessageHash: "0x2", TokenType: int(types.TokenTypeERC20), L1EventType: int(types.L1DepositERC20), L1BlockNumber: 101, L1L1Token: l1Token, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now},
+		{MessageHash: "0x3", TokenType: int(types.TokenTypeETH), L1EventType: int(types.L1DepositETH), L1BlockNumber: 102, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now},
+		{MessageHash: "0x4", TokenType: int(types.TokenTypeETH), L1EventType: int(types.L1DepositETH), L1BlockNumber: 103, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now.Add(-48 * time.Hour)},
+	}
+	for _, message := range messages {
+		_, err := gatewayMessageMatchOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, message)
+		assert.NoError(t, err)
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "processedMessageCounts",
+			test: func(t *testing.T) {
+				counts, err := gatewayMessageMatchOrm.GetProcessedMessageCounts(ctx, types.Layer1, now.Add(-time.Hour), now.Add(time.Hour))
+				assert.NoError(t, err)
+				assert.Equal(t, []TokenMessageCount{
+					{TokenType: int(types.TokenTypeERC20), Token: l1Token, Count: 2},
+					{TokenType: int(types.TokenTypeETH), Token: "", Count: 1},
+				}, counts)
+
+				counts, err = gatewayMessageMatchOrm.GetProcessedMessageCounts(ctx, types.Layer2, now.Add(-time.Hour), now.Add(time.Hour))
+				assert.NoError(t, err)
+				assert.Empty(t, counts)
+			},
+		},
+		{
+			name: "mismatchCounts",
+			test: func(t *testing.T) {
+				var message GatewayMessageMatch
+				assert.NoError(t, db.Where("message_hash = ?", "0x1").First(&message).Error)
+				assert.NoError(t, gatewayMessageMatchOrm.UpdateCrossChainMismatch(ctx, message.ID, types.Layer1, types.MismatchTypeL1AmountNotMatch, "[]"))
+
+				counts, err := gatewayMessageMatchOrm.GetMismatchCounts(ctx, types.Layer1, now.Add(-time.Hour), now.Add(time.Hour))
+				assert.NoError(t, err)
+				assert.Equal(t, []MismatchCount{{MismatchType: int(types.MismatchTypeL1AmountNotMatch), Count: 1}}, counts)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,108 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// GovernanceEvent is the audit record of a privileged event raised by the bridge contracts.
+type GovernanceEvent struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID              int64  `json:"id" gorm:"column:id"`
+	Layer           int    `json:"layer" gorm:"layer"`
+	EventType       int    `json:"event_type" gorm:"event_type"`
+	ContractAddress string `json:"contract_address" gorm:"contract_address"`
+	BlockNumber     uint64 `json:"block_number" gorm:"block_number"`
+	TxHash          string `json:"tx_hash" gorm:"tx_hash"`
+	LogIndex        uint   `json:"log_index" gorm:"log_index"`
+	// the decoded event parameters in json.
+	Args string `json:"args" gorm:"args"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewGovernanceEvent creates a new GovernanceEvent database instance.
+func NewGovernanceEvent(db *gorm.DB) *GovernanceEvent {
+	return &GovernanceEvent{db: db}
+}
+
+// TableName returns the table name for the GovernanceEvent model.
+func (*GovernanceEvent) TableName() string {
+	return "governance_event"
+}
+
+// InsertGovernanceEvent inserts the governance event, an event already recorded is ignored and returns zero affected rows.
+func (g *GovernanceEvent) InsertGovernanceEvent(ctx context.Context, event GovernanceEvent, dbTX ...*gorm.DB) (int64, error) {
+	db := g.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+
+	db = db.WithContext(ctx)
+	db = db.Model(&GovernanceEvent{})
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "layer"}, {Name: "tx_hash"}, {Name: "log_index"}},
+		DoNothing: true,
+	})
+
+	result := db.Create(&event)
+	if result.Error != nil {
+		log.Warn("GovernanceEvent.InsertGovernanceEvent failed", "error", result.Error)
+		return 0, fmt.Errorf("GovernanceEvent.InsertGovernanceEvent failed err:%w, event: %v", result.Error, event)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetGovernanceEvents get the governance events of the layer which block number between startBlockNumber and endBlockNumber
+func (g *GovernanceEvent) GetGovernanceEvents(ctx context.Context, layer types.LayerType, startBlockNumber, endBlockNumber uint64) ([]GovernanceEvent, error) {
+	var governanceEvents []GovernanceEvent
+	db := g.db.WithContext(ctx)
+	db = db.Where("layer = ?", layer)
+	db = db.Where("block_number >= ? AND block_number <= ?", startBlockNumber, endBlockNumber)
+	db = db.Order("block_number asc, log_index asc")
+	if err := db.Find(&governanceEvents).Error; err != nil {
+		log.Warn("GovernanceEvent.GetGovernanceEvents failed", "error", err)
+		return nil, fmt.Errorf("GovernanceEvent.GetGovernanceEvents failed err:%w", err)
+	}
+	return governanceEvents, nil
+}
+
+// GetContractGovernanceEvents get the governance events of the given type raised by the contract after the startBlockNumber
+func (g *GovernanceEvent) GetContractGovernanceEvents(ctx context.Context, layer types.LayerType, contractAddress string, eventType types.GovernanceEventType, startBlockNumber uint64) ([]GovernanceEvent, error) {
+	var governanceEvents []GovernanceEvent
+	db := g.db.WithContext(ctx)
+	db = db.Where("layer = ?", layer)
+	db = db.Where("contract_address = ?", contractAddress)
+	db = db.Where("event_type = ?", eventType)
+	db = db.Where("block_number > ?", startBlockNumber)
+	db = db.Order("block_number asc, log_index asc")
+	if err := db.Find(&governanceEvents).Error; err != nil {
+		log.Warn("GovernanceEvent.GetContractGovernanceEvents failed", "error", err)
+		return nil, fmt.Errorf("GovernanceEvent.GetContractGovernanceEvents failed err:%w", err)
+	}
+	return governanceEvents, nil
+}
+
+// GetGovernanceEventsByType get the governance events of the given type raised on the layer, in the order raised
+func (g *GovernanceEvent) GetGovernanceEventsByType(ctx context.Context, layer types.LayerType, eventType types.GovernanceEventType) ([]GovernanceEvent, error) {
+	var governanceEvents []GovernanceEvent
+	db := g.db.WithContext(ctx)
+	db = db.Where("layer = ?", layer)
+	db = db.Where("event_type = ?", eventType)
+	db = db.Order("block_number asc, log_index asc")
+	if err := db.Find(&governanceEvents).Error; err != nil {
+		log.Warn("GovernanceEvent.GetGovernanceEventsByType failed", "error", err)
+		return nil, fmt.Errorf("GovernanceEvent.GetGovernanceEventsByType failed err:%w", err)
+	}
+	return governanceEvents, nil
+}
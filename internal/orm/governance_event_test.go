@@ -0,0 +1,92 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestGovernanceEvent_InsertGovernanceEvent(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	governanceOrm := NewGovernanceEvent(db)
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"insertUpgraded", func(t *testing.T) {
+				upgraded := GovernanceEvent{
+					Layer:           int(types.Layer1),
+					EventType:       int(types.GovernanceEventTypeUpgraded),
+					ContractAddress: "0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367",
+					BlockNumber:     100,
+					TxHash:          "0xfc7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a",
+					LogIndex:        1,
+					Args:            `{"implementation":"0x1234567890123456789012345678901234567890"}`,
+				}
+				affectRows, err := governanceOrm.InsertGovernanceEvent(ctx, upgraded)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				affectRows, err = governanceOrm.InsertGovernanceEvent(ctx, upgraded)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(0))
+
+				upgraded.Layer = int(types.Layer2)
+				affectRows, err = governanceOrm.InsertGovernanceEvent(ctx, upgraded)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+			},
+		},
+		{
+			"getGovernanceEvents", func(t *testing.T) {
+				governanceEvents, err := governanceOrm.GetGovernanceEvents(ctx, types.Layer1, 0, 100)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 1)
+				assert.Equal(t, governanceEvents[0].EventType, int(types.GovernanceEventTypeUpgraded))
+				assert.Equal(t, governanceEvents[0].Args, `{"implementation":"0x1234567890123456789012345678901234567890"}`)
+
+				governanceEvents, err = governanceOrm.GetGovernanceEvents(ctx, types.Layer1, 101, 200)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 0)
+			},
+		},
+		{
+			"getContractGovernanceEvents", func(t *testing.T) {
+				governanceEvents, err := governanceOrm.GetContractGovernanceEvents(ctx, types.Layer1, "0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367", types.GovernanceEventTypeUpgraded, 99)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 1)
+
+				governanceEvents, err = governanceOrm.GetContractGovernanceEvents(ctx, types.Layer1, "0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367", types.GovernanceEventTypeUpgraded, 100)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 0)
+
+				governanceEvents, err = governanceOrm.GetContractGovernanceEvents(ctx, types.Layer1, "0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367", types.GovernanceEventTypeAdminChanged, 0)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 0)
+			},
+		},
+		{
+			"getGovernanceEventsByType", func(t *testing.T) {
+				governanceEvents, err := governanceOrm.GetGovernanceEventsByType(ctx, types.Layer2, types.GovernanceEventTypeUpgraded)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 1)
+				assert.Equal(t, governanceEvents[0].Layer, int(types.Layer2))
+
+				governanceEvents, err = governanceOrm.GetGovernanceEventsByType(ctx, types.Layer1, types.GovernanceEventTypeUpdateTokenMapping)
+				assert.NoError(t, err)
+				assert.Len(t, governanceEvents, 0)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
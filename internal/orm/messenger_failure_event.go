@@ -0,0 +1,27 @@
+package orm
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MessengerFailureEvent is the record of a failed relay, replay or drop event of a messenger message. The failure
+// counts of the message match are recounted from these records, so a rescanned block range isn't counted twice.
+type MessengerFailureEvent struct {
+	ID          int64  `json:"id" gorm:"column:id"`
+	MessageHash string `json:"message_hash" gorm:"column:message_hash"`
+	EventType   int    `json:"event_type" gorm:"column:event_type"`
+	BlockNumber uint64 `json:"block_number" gorm:"column:block_number"`
+	TxHash      string `json:"tx_hash" gorm:"column:tx_hash"`
+	LogIndex    uint   `json:"log_index" gorm:"column:log_index"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// TableName returns the table name for the MessengerFailureEvent model.
+func (*MessengerFailureEvent) TableName() string {
+	return "messenger_failure_event"
+}
@@ -37,6 +37,8 @@ type MessengerMessageMatch struct {
 
 	ETHAmount       string `json:"eth_amount" gorm:"eth_amount"`
 	ETHAmountStatus int    `json:"eth_amount_status" gorm:"eth_amount_status"`
+	// only not empty in relayed messages whose relay transaction calldata reproduces the message hash.
+	RelayedETHAmount string `json:"relayed_eth_amount" gorm:"relayed_eth_amount"`
 
 	// status
 	L1ETHBalanceStatus int `json:"l1_eth_balance_status" gorm:"l1_eth_balance_status"`
@@ -47,11 +49,24 @@ type MessengerMessageMatch struct {
 	L2CrossChainStatus int `json:"l2_cross_chain_status" gorm:"l2_cross_chain_status"`
 	WithdrawRootStatus int `json:"withdraw_root_status" gorm:"withdraw_root_status"`
 
+	// mismatch reason of the invalid cross chain status, the diff is a json array of the differing fields.
+	L1MismatchType int    `json:"l1_mismatch_type" gorm:"l1_mismatch_type"`
+	L2MismatchType int    `json:"l2_mismatch_type" gorm:"l2_mismatch_type"`
+	L1MismatchDiff string `json:"l1_mismatch_diff" gorm:"l1_mismatch_diff"`
+	L2MismatchDiff string `json:"l2_mismatch_diff" gorm:"l2_mismatch_diff"`
+
 	// only not null in the last message of each block.
 	MessageProof []byte `json:"message_proof" gorm:"message_proof"`
 	// only not null in l2 sent messages, and use next message nonce (+1) to distinguish from the zero values.
 	NextMessageNonce uint64 `json:"next_message_nonce" gorm:"next_message_nonce"`
 
+	// failed relay, replay and drop info
+	L1FailedRelayCount int    `json:"l1_failed_relay_count" gorm:"l1_failed_relay_count"`
+	L2FailedRelayCount int    `json:"l2_failed_relay_count" gorm:"l2_failed_relay_count"`
+	L1ReplayCount      int    `json:"l1_replay_count" gorm:"l1_replay_count"`
+	L1DropBlockNumber  uint64 `json:"l1_drop_block_number" gorm:"l1_drop_block_number"`
+	L1DropTxHash       string `json:"l1_drop_tx_hash" gorm:"l1_drop_tx_hash"`
+
 	L1BlockStatusUpdatedAt      time.Time      `json:"l1_block_status_updated_at" gorm:"l1_block_status_updated_at"`
 	L2BlockStatusUpdatedAt      time.Time      `json:"l2_block_status_updated_at" gorm:"l2_block_status_updated_at"`
 	L1CrossChainStatusUpdatedAt time.Time      `json:"l1_cross_chain_status_updated_at" gorm:"l1_cross_chain_status_updated_at"`
@@ -59,6 +74,7 @@ type MessengerMessageMatch struct {
 	L1EthBalanceStatusUpdatedAt time.Time      `json:"l1_eth_balance_status_updated_at" gorm:"l1_eth_balance_status_updated_at"`
 	L2EthBalanceStatusUpdatedAt time.Time      `json:"l2_eth_balance_status_updated_at" gorm:"l2_eth_balance_status_updated_at"`
 	MessageProofUpdatedAt       time.Time      `json:"message_proof_updated_at" gorm:"message_proof_updated_at"`
+	MessageFailureUpdatedAt     time.Time      `json:"message_failure_updated_at" gorm:"message_failure_updated_at"`
 	CreatedAt                   time.Time      `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt                   time.Time      `json:"updated_at" gorm:"column:updated_at"`
 	DeletedAt                   gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
@@ -190,6 +206,126 @@ func (m *MessengerMessageMatch) GetETHCheckStartBlockNumberAndBalance(ctx contex
 	}
 }
 
+// GetLatestETHBalanceValidMessageMatch fetches the message match record of the largest block number whose messenger
+// eth balance of the layer is computed.
+func (m *MessengerMessageMatch) GetLatestETHBalanceValidMessageMatch(ctx context.Context, layer types.LayerType) (*MessengerMessageMatch, error) {
+	var message MessengerMessageMatch
+	db := m.db.WithContext(ctx)
+	switch layer {
+	case types.Layer1:
+		db = db.Where("l1_eth_balance_status = ?", types.ETHBalanceStatusTypeValid)
+		db = db.Order("l1_block_number desc")
+	case types.Layer2:
+		db = db.Where("l2_eth_balance_status = ?", types.ETHBalanceStatusTypeValid)
+		db = db.Order("l2_block_number desc")
+	default:
+		return nil, fmt.Errorf("invalid layer: %v", layer)
+	}
+	err := db.First(&message).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Warn("MessengerMessageMatch.GetLatestETHBalanceValidMessageMatch failed", "error", err)
+		return nil, fmt.Errorf("MessengerMessageMatch.GetLatestETHBalanceValidMessageMatch failed err:%w", err)
+	}
+	return &message, nil
+}
+
+// GetL1ETHFlows sums the eth of the l1 sent messages and the trusted relayed eth of the l1 relayed messages between
+// the l1 startBlockNumber and endBlockNumber, in wei.
+func (m *MessengerMessageMatch) GetL1ETHFlows(ctx context.Context, startBlockNumber, endBlockNumber uint64) (decimal.Decimal, decimal.Decimal, error) {
+	var flows struct {
+		Deposited decimal.Decimal
+		Withdrawn decimal.Decimal
+	}
+	db := m.db.WithContext(ctx)
+	db = db.Model(&MessengerMessageMatch{})
+	db = db.Select("COALESCE(SUM(CASE WHEN l1_event_type = ? AND eth_amount <> '' THEN CAST(eth_amount AS NUMERIC) END), 0) AS deposited, "+
+		"COALESCE(SUM(CASE WHEN l1_event_type = ? AND relayed_eth_amount <> '' THEN CAST(relayed_eth_amount AS NUMERIC) END), 0) AS withdrawn",
+		types.L1SentMessage, types.L1RelayedMessage)
+	db = db.Where("l1_event_type IN ?", []types.EventType{types.L1SentMessage, types.L1RelayedMessage})
+	db = db.Where("l1_block_number >= ? AND l1_block_number <= ?", startBlockNumber, endBlockNumber)
+	if err := db.Scan(&flows).Error; err != nil {
+		log.Warn("MessengerMessageMatch.GetL1ETHFlows failed", "error", err)
+		return decimal.Zero, decimal.Zero, fmt.Errorf("MessengerMessageMatch.GetL1ETHFlows failed err:%w", err)
+	}
+	return flows.Deposited, flows.Withdrawn, nil
+}
+
+// GetProcessedMessageCount get the number of the messages whose block of the layer was processed between startTime and
+// endTime.
+func (m *MessengerMessageMatch) GetProcessedMessageCount(ctx context.Context, layer types.LayerType, startTime, endTime time.Time) (int64, error) {
+	var count int64
+	db := m.db.WithContext(ctx)
+	db = db.Model(&MessengerMessageMatch{})
+	switch layer {
+	case types.Layer1:
+		db = db.Where("l1_block_status = ?", types.BlockStatusTypeValid)
+		db = db.Where("l1_block_status_updated_at >= ? AND l1_block_status_updated_at < ?", startTime, endTime)
+	case types.Layer2:
+		db = db.Where("l2_block_status = ?", types.BlockStatusTypeValid)
+		db = db.Where("l2_block_status_updated_at >= ? AND l2_block_status_updated_at < ?", startTime, endTime)
+	}
+	if err := db.Count(&count).Error; err != nil {
+		log.Warn("MessengerMessageMatch.GetProcessedMessageCount failed", "error", err)
+		return 0, fmt.Errorf("MessengerMessageMatch.GetProcessedMessageCount failed err:%w", err)
+	}
+	return count, nil
+}
+
+// GetMismatchCounts get the number of the cross chain mismatches of the layer of each mismatch type found between
+// startTime and endTime.
+func (m *MessengerMessageMatch) GetMismatchCounts(ctx context.Context, layer types.LayerType, startTime, endTime time.Time) ([]MismatchCount, error) {
+	var counts []MismatchCount
+	db := m.db.WithContext(ctx)
+	db = db.Model(&MessengerMessageMatch{})
+	switch layer {
+	case types.Layer1:
+		db = db.Select("l1_mismatch_type AS mismatch_type, COUNT(*) AS count")
+		db = db.Where("l1_cross_chain_status = ?", types.CrossChainStatusTypeInvalid)
+		db = db.Where("l1_cross_chain_status_updated_at >= ? AND l1_cross_chain_status_updated_at < ?", startTime, endTime)
+		db = db.Group("l1_mismatch_type")
+	case types.Layer2:
+		db = db.Select("l2_mismatch_type AS mismatch_type, COUNT(*) AS count")
+		db = db.Where("l2_cross_chain_status = ?", types.CrossChainStatusTypeInvalid)
+		db = db.Where("l2_cross_chain_status_updated_at >= ? AND l2_cross_chain_status_updated_at < ?", startTime, endTime)
+		db = db.Group("l2_mismatch_type")
+	}
+	db = db.Order("mismatch_type")
+	if err := db.Scan(&counts).Error; err != nil {
+		log.Warn("MessengerMessageMatch.GetMismatchCounts failed", "error", err)
+		return nil, fmt.Errorf("MessengerMessageMatch.GetMismatchCounts failed err:%w", err)
+	}
+	return counts, nil
+}
+
+// GetStuckL1SentMessages get the l1 sent messages processed before sentBefore and neither relayed on l2 nor dropped,
+// returns the oldest limit messages and the number of all the stuck messages.
+func (m *MessengerMessageMatch) GetStuckL1SentMessages(ctx context.Context, sentBefore time.Time, limit int) ([]MessengerMessageMatch, int64, error) {
+	db := m.db.WithContext(ctx)
+	db = db.Model(&MessengerMessageMatch{})
+	db = db.Where("l1_event_type = ?", types.L1SentMessage)
+	db = db.Where("l2_event_type = ?", types.EventTypeUnknown)
+	db = db.Where("l1_drop_block_number = 0")
+	db = db.Where("l1_block_status_updated_at < ?", sentBefore)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		log.Warn("MessengerMessageMatch.GetStuckL1SentMessages failed", "error", err)
+		return nil, 0, fmt.Errorf("MessengerMessageMatch.GetStuckL1SentMessages failed err:%w", err)
+	}
+
+	var messages []MessengerMessageMatch
+	db = db.Order("l1_block_number ASC")
+	db = db.Limit(limit)
+	if err := db.Find(&messages).Error; err != nil {
+		log.Warn("MessengerMessageMatch.GetStuckL1SentMessages failed", "error", err)
+		return nil, 0, fmt.Errorf("MessengerMessageMatch.GetStuckL1SentMessages failed err:%w", err)
+	}
+	return messages, count, nil
+}
+
 // GetLatestValidL2SentMessageMatch fetches the valid l2 sent message with the largest message nonce.
 func (m *MessengerMessageMatch) GetLatestValidL2SentMessageMatch(ctx context.Context) (*MessengerMessageMatch, error) {
 	var message MessengerMessageMatch
@@ -208,6 +344,23 @@ func (m *MessengerMessageMatch) GetLatestValidL2SentMessageMatch(ctx context.Con
 	return &message, nil
 }
 
+// GetLatestL2SentMessageMatch fetches the l2 sent message with the largest message nonce.
+func (m *MessengerMessageMatch) GetLatestL2SentMessageMatch(ctx context.Context) (*MessengerMessageMatch, error) {
+	var message MessengerMessageMatch
+	db := m.db.WithContext(ctx)
+	db = db.Where("next_message_nonce > 0")
+	db = db.Order("next_message_nonce DESC")
+	err := db.First(&message).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Warn("MessengerMessageMatch.GetLatestL2SentMessageMatch failed", "error", err)
+		return nil, fmt.Errorf("MessengerMessageMatch.GetLatestL2SentMessageMatch failed, err:%w", err)
+	}
+	return &message, nil
+}
+
 // GetL2SentMessagesInBlockRange fetches the message match records of l2 sent message within the block range.
 func (m *MessengerMessageMatch) GetL2SentMessagesInBlockRange(ctx context.Context, startBlockNumber, endBlockNumber uint64) ([]*MessengerMessageMatch, error) {
 	var messages []*MessengerMessageMatch
@@ -228,6 +381,21 @@ func (m *MessengerMessageMatch) GetL2SentMessagesInBlockRange(ctx context.Contex
 	return messages, nil
 }
 
+// GetL2SentMessagesByNonceRange fetches the l2 sent message records whose message nonce is between startNonce and
+// endNonce, the end is exclusive.
+func (m *MessengerMessageMatch) GetL2SentMessagesByNonceRange(ctx context.Context, startNonce, endNonce uint64) ([]*MessengerMessageMatch, error) {
+	var messages []*MessengerMessageMatch
+	db := m.db.WithContext(ctx)
+	db = db.Where("next_message_nonce > ?", startNonce)
+	db = db.Where("next_message_nonce <= ?", endNonce)
+	db = db.Order("next_message_nonce ASC")
+	if err := db.Find(&messages).Error; err != nil {
+		log.Warn("MessengerMessageMatch.GetL2SentMessagesByNonceRange failed", "error", err)
+		return nil, fmt.Errorf("MessengerMessageMatch.GetL2SentMessagesByNonceRange failed, err:%w", err)
+	}
+	return messages, nil
+}
+
 // GetMessageMatchByMessageHash get MessageMatch by message_hash
 func (m *MessengerMessageMatch) GetMessageMatchByMessageHash(ctx context.Context, msgHash string) (*MessengerMessageMatch, error) {
 	var message MessengerMessageMatch
@@ -256,7 +424,7 @@ func (m *MessengerMessageMatch) InsertOrUpdateEventInfo(ctx context.Context, lay
 			assignmentColumn = clause.AssignmentColumns([]string{"l1_block_number", "l1_event_type", "l1_tx_hash", "eth_amount", "eth_amount_status", "l1_block_status", "l1_block_status_updated_at"})
 			where = clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "messenger_message_match.l1_block_number", Value: 0}}}
 		} else if message.L1EventType == int(types.L1RelayedMessage) { // relayed
-			assignmentColumn = clause.AssignmentColumns([]string{"l1_block_number", "l1_event_type", "l1_tx_hash", "l1_block_status", "l1_block_status_updated_at"})
+			assignmentColumn = clause.AssignmentColumns([]string{"l1_block_number", "l1_event_type", "l1_tx_hash", "relayed_eth_amount", "l1_block_status", "l1_block_status_updated_at"})
 			where = clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "messenger_message_match.l1_block_number", Value: 0}}}
 		}
 	}
@@ -266,7 +434,7 @@ func (m *MessengerMessageMatch) InsertOrUpdateEventInfo(ctx context.Context, lay
 			assignmentColumn = clause.AssignmentColumns([]string{"l2_block_number", "l2_event_type", "l2_tx_hash", "eth_amount", "eth_amount_status", "next_message_nonce", "l2_block_status", "l2_block_status_updated_at"})
 			where = clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "messenger_message_match.l2_block_number", Value: 0}}}
 		} else if message.L2EventType == int(types.L2RelayedMessage) { // relayed
-			assignmentColumn = clause.AssignmentColumns([]string{"l2_block_number", "l2_event_type", "l2_tx_hash", "l2_block_status", "l2_block_status_updated_at"})
+			assignmentColumn = clause.AssignmentColumns([]string{"l2_block_number", "l2_event_type", "l2_tx_hash", "relayed_eth_amount", "l2_block_status", "l2_block_status_updated_at"})
 			where = clause.Where{Exprs: []clause.Expression{clause.Eq{Column: "messenger_message_match.l2_block_number", Value: 0}}}
 		}
 	}
@@ -284,6 +452,81 @@ func (m *MessengerMessageMatch) InsertOrUpdateEventInfo(ctx context.Context, lay
 	return result.RowsAffected, nil
 }
 
+// InsertOrUpdateMessageFailure records a failed relay, replay or drop event of the message, identified by its tx hash
+// and log index, and updates the failure counts of its message match row by recounting the recorded events. It
+// returns the row after the update, and whether the event wasn't recorded before, a rescanned event changes nothing.
+func (m *MessengerMessageMatch) InsertOrUpdateMessageFailure(ctx context.Context, eventType types.EventType, message MessengerMessageMatch, event MessengerFailureEvent, dbTX ...*gorm.DB) (*MessengerMessageMatch, bool, error) {
+	db := m.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+
+	var countColumn string
+	switch eventType {
+	case types.L1FailedRelayedMessage:
+		countColumn = "l1_failed_relay_count"
+	case types.L2FailedRelayedMessage:
+		countColumn = "l2_failed_relay_count"
+	case types.L1ReplayMessage:
+		countColumn = "l1_replay_count"
+	case types.L1DropMessage:
+	default:
+		return nil, false, fmt.Errorf("MessengerMessageMatch.InsertOrUpdateMessageFailure invalid event type: %s", eventType.String())
+	}
+
+	event.MessageHash = message.MessageHash
+	event.EventType = int(eventType)
+	eventDB := db.Model(&MessengerFailureEvent{})
+	eventDB = eventDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "tx_hash"}, {Name: "log_index"}},
+		DoNothing: true,
+	})
+	result := eventDB.Create(&event)
+	if result.Error != nil {
+		log.Warn("MessengerMessageMatch.InsertOrUpdateMessageFailure failed", "error", result.Error)
+		return nil, false, fmt.Errorf("MessengerMessageMatch.InsertOrUpdateMessageFailure failed err:%w, event: %v", result.Error, event)
+	}
+	recorded := result.RowsAffected > 0
+
+	var assignmentColumns []string
+	if countColumn != "" {
+		var count int64
+		countDB := db.Model(&MessengerFailureEvent{})
+		countDB = countDB.Where("message_hash = ? AND event_type = ?", message.MessageHash, int(eventType))
+		if err := countDB.Count(&count).Error; err != nil {
+			log.Warn("MessengerMessageMatch.InsertOrUpdateMessageFailure failed", "error", err)
+			return nil, false, fmt.Errorf("MessengerMessageMatch.InsertOrUpdateMessageFailure failed err:%w, message: %v", err, message)
+		}
+		switch eventType {
+		case types.L1FailedRelayedMessage:
+			message.L1FailedRelayCount = int(count)
+		case types.L2FailedRelayedMessage:
+			message.L2FailedRelayCount = int(count)
+		case types.L1ReplayMessage:
+			message.L1ReplayCount = int(count)
+		}
+		assignmentColumns = append(assignmentColumns, countColumn)
+	} else {
+		assignmentColumns = append(assignmentColumns, "l1_drop_block_number", "l1_drop_tx_hash")
+	}
+
+	message.MessageFailureUpdatedAt = utils.NowUTC()
+	assignmentColumns = append(assignmentColumns, "message_failure_updated_at")
+
+	matchDB := db.Model(&MessengerMessageMatch{})
+	matchDB = matchDB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "message_hash"}},
+		DoUpdates: clause.AssignmentColumns(assignmentColumns),
+	}, clause.Returning{})
+
+	if err := matchDB.Create(&message).Error; err != nil {
+		log.Warn("MessengerMessageMatch.InsertOrUpdateMessageFailure failed", "error", err)
+		return nil, false, fmt.Errorf("MessengerMessageMatch.InsertOrUpdateMessageFailure failed err:%w, message: %v", err, message)
+	}
+	return &message, recorded, nil
+}
+
 // UpdateMsgProofAndStatus insert or update the withdrawal tree root's message proof and withdraw root status
 func (m *MessengerMessageMatch) UpdateMsgProofAndStatus(ctx context.Context, message *MessengerMessageMatch, dbTX ...*gorm.DB) error {
 	if message == nil {
@@ -359,6 +602,8 @@ func (m *MessengerMessageMatch) UpdateETHBalance(ctx context.Context, layer type
 			"l1_eth_balance_status":            types.ETHBalanceStatusTypeValid,
 			"l1_eth_balance_status_updated_at": utils.NowUTC(),
 			"l1_cross_chain_status":            messageMatch.L1CrossChainStatus,
+			"l1_mismatch_type":                 messageMatch.L1MismatchType,
+			"l1_mismatch_diff":                 messageMatch.L1MismatchDiff,
 			"l1_cross_chain_status_updated_at": utils.NowUTC(),
 		}
 	case types.Layer2:
@@ -367,6 +612,8 @@ func (m *MessengerMessageMatch) UpdateETHBalance(ctx context.Context, layer type
 			"l2_eth_balance_status":            types.ETHBalanceStatusTypeValid,
 			"l2_eth_balance_status_updated_at": utils.NowUTC(),
 			"l2_cross_chain_status":            messageMatch.L2CrossChainStatus,
+			"l2_mismatch_type":                 messageMatch.L2MismatchType,
+			"l2_mismatch_diff":                 messageMatch.L2MismatchDiff,
 			"l2_cross_chain_status_updated_at": utils.NowUTC(),
 		}
 	}
@@ -376,3 +623,42 @@ func (m *MessengerMessageMatch) UpdateETHBalance(ctx context.Context, layer type
 	}
 	return nil
 }
+
+// RebaseMessengerETHBalance replaces the computed messenger eth balance of the layer at the block, the following eth
+// balance checks accumulate from the rebased balance.
+func (m *MessengerMessageMatch) RebaseMessengerETHBalance(ctx context.Context, layer types.LayerType, blockNumber uint64, balance decimal.Decimal, dbTX ...*gorm.DB) (int64, error) {
+	db := m.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+
+	db = db.WithContext(ctx)
+	db = db.Model(&MessengerMessageMatch{})
+
+	var updateFields map[string]interface{}
+	switch layer {
+	case types.Layer1:
+		db = db.Where("l1_block_number = ?", blockNumber)
+		db = db.Where("l1_eth_balance_status = ?", types.ETHBalanceStatusTypeValid)
+		updateFields = map[string]interface{}{
+			"l1_messenger_eth_balance":         balance,
+			"l1_eth_balance_status_updated_at": utils.NowUTC(),
+		}
+	case types.Layer2:
+		db = db.Where("l2_block_number = ?", blockNumber)
+		db = db.Where("l2_eth_balance_status = ?", types.ETHBalanceStatusTypeValid)
+		updateFields = map[string]interface{}{
+			"l2_messenger_eth_balance":         balance,
+			"l2_eth_balance_status_updated_at": utils.NowUTC(),
+		}
+	default:
+		return 0, fmt.Errorf("invalid layer: %v", layer)
+	}
+
+	result := db.Updates(updateFields)
+	if result.Error != nil {
+		log.Warn("MessengerMessageMatch.RebaseMessengerETHBalance failed", "error", result.Error)
+		return 0, fmt.Errorf("MessengerMessageMatch.RebaseMessengerETHBalance failed err:%w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
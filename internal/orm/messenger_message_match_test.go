@@ -3,10 +3,13 @@ package orm
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils"
 	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
 )
 
@@ -153,3 +156,211 @@ func TestMessengerMessageMatch_InsertOrUpdateEventInfo(t *testing.T) {
 		t.Run(test.name, test.test)
 	}
 }
+
+func TestMessengerMessageMatch_InsertOrUpdateMessageFailure(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	messengerOrm := NewMessengerMessageMatch(db)
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"l2FailedRelayedMessageBeforeSent", func(t *testing.T) {
+				failedMsg := MessengerMessageMatch{MessageHash: "0x1"}
+				firstFailure := MessengerFailureEvent{BlockNumber: 100, TxHash: "0x2c7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a", LogIndex: 1}
+				msgMatch, recorded, err := messengerOrm.InsertOrUpdateMessageFailure(ctx, types.L2FailedRelayedMessage, failedMsg, firstFailure)
+				assert.NoError(t, err)
+				assert.True(t, recorded)
+				assert.Equal(t, msgMatch.L2FailedRelayCount, 1)
+
+				// the rescanned failure isn't counted twice.
+				msgMatch, recorded, err = messengerOrm.InsertOrUpdateMessageFailure(ctx, types.L2FailedRelayedMessage, failedMsg, firstFailure)
+				assert.NoError(t, err)
+				assert.False(t, recorded)
+				assert.Equal(t, msgMatch.L2FailedRelayCount, 1)
+
+				secondFailure := MessengerFailureEvent{BlockNumber: 101, TxHash: "0x3c7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a", LogIndex: 0}
+				msgMatch, recorded, err = messengerOrm.InsertOrUpdateMessageFailure(ctx, types.L2FailedRelayedMessage, failedMsg, secondFailure)
+				assert.NoError(t, err)
+				assert.True(t, recorded)
+				assert.Equal(t, msgMatch.L2FailedRelayCount, 2)
+
+				l1SentEventMsg := MessengerMessageMatch{
+					MessageHash:   "0x1",
+					L1EventType:   int(types.L1SentMessage),
+					L1BlockNumber: 120,
+					L1TxHash:      "0xfc7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a",
+					ETHAmount:     "1000",
+				}
+				affectRows, err := messengerOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, l1SentEventMsg)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				msgMatch, err = messengerOrm.GetMessageMatchByMessageHash(ctx, "0x1")
+				assert.NoError(t, err)
+				assert.Equal(t, msgMatch.L1BlockNumber, uint64(120))
+				assert.Equal(t, msgMatch.L2FailedRelayCount, 2)
+			},
+		},
+		{
+			"l1ReplayAndDropMessage", func(t *testing.T) {
+				replay := MessengerFailureEvent{BlockNumber: 150, TxHash: "0x4c7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a", LogIndex: 2}
+				msgMatch, _, err := messengerOrm.InsertOrUpdateMessageFailure(ctx, types.L1ReplayMessage, MessengerMessageMatch{MessageHash: "0x1"}, replay)
+				assert.NoError(t, err)
+				assert.Equal(t, msgMatch.L1ReplayCount, 1)
+
+				dropMsg := MessengerMessageMatch{
+					MessageHash:       "0x1",
+					L1DropBlockNumber: 200,
+					L1DropTxHash:      "0x1c7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a",
+				}
+				drop := MessengerFailureEvent{BlockNumber: 200, TxHash: dropMsg.L1DropTxHash, LogIndex: 0}
+				msgMatch, recorded, err := messengerOrm.InsertOrUpdateMessageFailure(ctx, types.L1DropMessage, dropMsg, drop)
+				assert.NoError(t, err)
+				assert.True(t, recorded)
+				assert.Equal(t, msgMatch.L1ReplayCount, 1)
+				assert.Equal(t, msgMatch.L1DropBlockNumber, uint64(200))
+				assert.Equal(t, msgMatch.L1DropTxHash, "0x1c7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a")
+				assert.Equal(t, msgMatch.L1BlockNumber, uint64(120))
+			},
+		},
+		{
+			"invalidEventType", func(t *testing.T) {
+				_, _, err := messengerOrm.InsertOrUpdateMessageFailure(ctx, types.L1SentMessage, MessengerMessageMatch{MessageHash: "0x1"}, MessengerFailureEvent{})
+				assert.Error(t, err)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestMessengerMessageMatch_RebaseMessengerETHBalance(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	messengerOrm := NewMessengerMessageMatch(db)
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"noValidBalance", func(t *testing.T) {
+				message, err := messengerOrm.GetLatestETHBalanceValidMessageMatch(ctx, types.Layer1)
+				assert.NoError(t, err)
+				assert.Nil(t, message)
+			},
+		},
+		{
+			"rebaseL1Balance", func(t *testing.T) {
+				for i, messageHash := range []string{"0x1", "0x2"} {
+					l1SentEventMsg := MessengerMessageMatch{
+						MessageHash:   messageHash,
+						L1EventType:   int(types.L1SentMessage),
+						L1BlockNumber: uint64(100 + i),
+						L1TxHash:      "0xfc7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a",
+						ETHAmount:     "1000",
+					}
+					_, err := messengerOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, l1SentEventMsg)
+					assert.NoError(t, err)
+
+					inserted, err := messengerOrm.GetMessageMatchByMessageHash(ctx, messageHash)
+					assert.NoError(t, err)
+					err = messengerOrm.UpdateETHBalance(ctx, types.Layer1, MessengerMessageMatch{ID: inserted.ID, L1MessengerETHBalance: decimal.NewFromInt(int64(1000 * (i + 1)))})
+					assert.NoError(t, err)
+				}
+
+				message, err := messengerOrm.GetLatestETHBalanceValidMessageMatch(ctx, types.Layer1)
+				assert.NoError(t, err)
+				assert.Equal(t, message.L1BlockNumber, uint64(101))
+				assert.Equal(t, message.L1MessengerETHBalance.String(), "2000")
+
+				affectRows, err := messengerOrm.RebaseMessengerETHBalance(ctx, types.Layer1, 101, decimal.NewFromInt(2500))
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				balance, err := messengerOrm.GetETHCheckStartBlockNumberAndBalance(ctx, types.Layer1)
+				assert.NoError(t, err)
+				assert.Equal(t, balance.String(), "2500")
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestMessengerMessageMatch_GetL1ETHFlows(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	messengerOrm := NewMessengerMessageMatch(db)
+
+	messages := []MessengerMessageMatch{
+		{MessageHash: "0x1", L1EventType: int(types.L1SentMessage), L1BlockNumber: 100, ETHAmount: "300"},
+		{MessageHash: "0x2", L1EventType: int(types.L1RelayedMessage), L1BlockNumber: 101, RelayedETHAmount: "1000"},
+		{MessageHash: "0x3", L1EventType: int(types.L1RelayedMessage), L1BlockNumber: 102},
+		{MessageHash: "0x4", L1EventType: int(types.L1RelayedMessage), L1BlockNumber: 110, RelayedETHAmount: "7000"},
+	}
+	for _, message := range messages {
+		_, err := messengerOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, message)
+		assert.NoError(t, err)
+	}
+
+	deposited, withdrawn, err := messengerOrm.GetL1ETHFlows(ctx, 100, 105)
+	assert.NoError(t, err)
+	assert.Equal(t, "300", deposited.String())
+	assert.Equal(t, "1000", withdrawn.String())
+}
+
+func TestMessengerMessageMatch_GetStuckL1SentMessages(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	messengerOrm := NewMessengerMessageMatch(db)
+
+	now := utils.NowUTC()
+	messages := []MessengerMessageMatch{
+		{MessageHash: "0x1", L1EventType: int(types.L1SentMessage), L1BlockNumber: 100, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now.Add(-3 * time.Hour)},
+		{MessageHash: "0x2", L1EventType: int(types.L1SentMessage), L1BlockNumber: 101, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now.Add(-2 * time.Hour)},
+		{MessageHash: "0x3", L1EventType: int(types.L1SentMessage), L1BlockNumber: 102, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now.Add(-2 * time.Hour)},
+		{MessageHash: "0x4", L1EventType: int(types.L1SentMessage), L1BlockNumber: 103, L1BlockStatus: int(types.BlockStatusTypeValid), L1BlockStatusUpdatedAt: now},
+	}
+	for _, message := range messages {
+		_, err := messengerOrm.InsertOrUpdateEventInfo(ctx, types.Layer1, message)
+		assert.NoError(t, err)
+	}
+	_, err := messengerOrm.InsertOrUpdateEventInfo(ctx, types.Layer2, MessengerMessageMatch{MessageHash: "0x2", L2EventType: int(types.L2RelayedMessage), L2BlockNumber: 200})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			name: "stuckL1SentMessages",
+			test: func(t *testing.T) {
+				stuck, count, err := messengerOrm.GetStuckL1SentMessages(ctx, now.Add(-time.Hour), 1)
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), count)
+				assert.Len(t, stuck, 1)
+				assert.Equal(t, "0x1", stuck[0].MessageHash)
+			},
+		},
+		{
+			name: "processedMessageCount",
+			test: func(t *testing.T) {
+				count, err := messengerOrm.GetProcessedMessageCount(ctx, types.Layer1, now.Add(-time.Hour), now.Add(time.Hour))
+				assert.NoError(t, err)
+				assert.Equal(t, int64(1), count)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
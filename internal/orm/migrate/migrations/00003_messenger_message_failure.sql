@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose MessengerMessageFailureBegin
+ALTER TABLE messenger_message_match
+    ADD COLUMN l1_failed_relay_count      INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l2_failed_relay_count      INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l1_replay_count            INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l1_drop_block_number       BIGINT          NOT NULL DEFAULT 0,
+    ADD COLUMN l1_drop_tx_hash            VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN message_failure_updated_at TIMESTAMP(0)    DEFAULT NULL;
+
+CREATE TABLE messenger_failure_event
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    message_hash                     VARCHAR         NOT NULL,
+    event_type                       INTEGER         NOT NULL,
+    block_number                     BIGINT          NOT NULL,
+    tx_hash                          VARCHAR         NOT NULL,
+    log_index                        INTEGER         NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX if not exists idx_mfe_tx_hash_log_index ON messenger_failure_event (tx_hash, log_index);
+CREATE INDEX if not exists idx_mfe_message_hash_event_type ON messenger_failure_event (message_hash, event_type);
+-- +goose MessengerMessageFailureEnd
+
+-- +goose Down
+-- +goose MessengerMessageFailureBegin
+drop table if exists messenger_failure_event;
+
+ALTER TABLE messenger_message_match
+    DROP COLUMN if exists l1_failed_relay_count,
+    DROP COLUMN if exists l2_failed_relay_count,
+    DROP COLUMN if exists l1_replay_count,
+    DROP COLUMN if exists l1_drop_block_number,
+    DROP COLUMN if exists l1_drop_tx_hash,
+    DROP COLUMN if exists message_failure_updated_at;
+-- +goose MessengerMessageFailureEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose GovernanceEventBegin
+CREATE TABLE governance_event
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    layer                            INTEGER         NOT NULL,
+    event_type                       INTEGER         NOT NULL,
+    contract_address                 VARCHAR         NOT NULL,
+    block_number                     BIGINT          NOT NULL,
+    tx_hash                          VARCHAR         NOT NULL,
+    log_index                        INTEGER         NOT NULL,
+    args                             TEXT            NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX if not exists idx_ge_layer_tx_hash_log_index ON governance_event (layer, tx_hash, log_index);
+CREATE INDEX if not exists idx_ge_layer_contract_event_block ON governance_event (layer, contract_address, event_type, block_number desc);
+-- +goose GovernanceEventEnd
+
+-- +goose Down
+-- +goose GovernanceEventBegin
+drop table if exists governance_event;
+-- +goose GovernanceEventEnd
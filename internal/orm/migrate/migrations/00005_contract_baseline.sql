@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose ContractBaselineBegin
+CREATE TABLE contract_baseline
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    layer                            INTEGER         NOT NULL,
+    name                             VARCHAR         NOT NULL,
+    contract_address                 VARCHAR         NOT NULL,
+    implementation                   VARCHAR         NOT NULL,
+    admin                            VARCHAR         NOT NULL,
+    code_hash                        VARCHAR         NOT NULL,
+    implementation_code_hash         VARCHAR         NOT NULL,
+    block_number                     BIGINT          NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX if not exists idx_cb_layer_contract_address ON contract_baseline (layer, contract_address);
+-- +goose ContractBaselineEnd
+
+-- +goose Down
+-- +goose ContractBaselineBegin
+drop table if exists contract_baseline;
+-- +goose ContractBaselineEnd
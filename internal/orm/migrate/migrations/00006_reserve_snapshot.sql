@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose ReserveSnapshotBegin
+CREATE TABLE reserve_snapshot
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    name                             VARCHAR         NOT NULL,
+    l1_gateway                       VARCHAR         NOT NULL,
+    l1_token                         VARCHAR         NOT NULL,
+    l2_token                         VARCHAR         NOT NULL,
+
+    l1_block_number                  BIGINT          NOT NULL,
+    l2_block_number                  BIGINT          NOT NULL,
+    l1_escrow_balance                DECIMAL(78, 0)  NOT NULL,
+    l2_total_supply                  DECIMAL(78, 0)  NOT NULL,
+    in_flight                        DECIMAL(78, 0)  NOT NULL,
+    imbalance                        DECIMAL(78, 0)  NOT NULL,
+    tolerance                        DECIMAL(78, 0)  NOT NULL,
+    reserve_status                   INTEGER         NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX if not exists idx_rs_l1_token_l1_block_l2_block ON reserve_snapshot (l1_token, l1_block_number, l2_block_number);
+CREATE INDEX if not exists idx_rs_status_id ON reserve_snapshot (reserve_status, id DESC);
+
+ALTER TABLE gateway_message_match
+    ADD COLUMN l1_l1_token                VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN l1_l2_token                VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN l2_l1_token                VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN l2_l2_token                VARCHAR         NOT NULL DEFAULT '';
+-- +goose ReserveSnapshotEnd
+
+-- +goose Down
+-- +goose ReserveSnapshotBegin
+drop table if exists reserve_snapshot;
+
+ALTER TABLE gateway_message_match
+    DROP COLUMN if exists l1_l1_token,
+    DROP COLUMN if exists l1_l2_token,
+    DROP COLUMN if exists l2_l1_token,
+    DROP COLUMN if exists l2_l2_token;
+-- +goose ReserveSnapshotEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose GatewayMessageMatchAddressBegin
+ALTER TABLE gateway_message_match
+    ADD COLUMN l1_from                    VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN l1_to                      VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN l2_from                    VARCHAR         NOT NULL DEFAULT '',
+    ADD COLUMN l2_to                      VARCHAR         NOT NULL DEFAULT '';
+-- +goose GatewayMessageMatchAddressEnd
+
+-- +goose Down
+-- +goose GatewayMessageMatchAddressBegin
+ALTER TABLE gateway_message_match
+    DROP COLUMN if exists l1_from,
+    DROP COLUMN if exists l1_to,
+    DROP COLUMN if exists l2_from,
+    DROP COLUMN if exists l2_to;
+-- +goose GatewayMessageMatchAddressEnd
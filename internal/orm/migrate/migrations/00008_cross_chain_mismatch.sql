@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose CrossChainMismatchBegin
+ALTER TABLE gateway_message_match
+    ADD COLUMN l1_mismatch_type           INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l2_mismatch_type           INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l1_mismatch_diff           TEXT            NOT NULL DEFAULT '',
+    ADD COLUMN l2_mismatch_diff           TEXT            NOT NULL DEFAULT '';
+
+ALTER TABLE messenger_message_match
+    ADD COLUMN l1_mismatch_type           INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l2_mismatch_type           INTEGER         NOT NULL DEFAULT 0,
+    ADD COLUMN l1_mismatch_diff           TEXT            NOT NULL DEFAULT '',
+    ADD COLUMN l2_mismatch_diff           TEXT            NOT NULL DEFAULT '';
+-- +goose CrossChainMismatchEnd
+
+-- +goose Down
+-- +goose CrossChainMismatchBegin
+ALTER TABLE gateway_message_match
+    DROP COLUMN if exists l1_mismatch_type,
+    DROP COLUMN if exists l2_mismatch_type,
+    DROP COLUMN if exists l1_mismatch_diff,
+    DROP COLUMN if exists l2_mismatch_diff;
+
+ALTER TABLE messenger_message_match
+    DROP COLUMN if exists l1_mismatch_type,
+    DROP COLUMN if exists l2_mismatch_type,
+    DROP COLUMN if exists l1_mismatch_diff,
+    DROP COLUMN if exists l2_mismatch_diff;
+-- +goose CrossChainMismatchEnd
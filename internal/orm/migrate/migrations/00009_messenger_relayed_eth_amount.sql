@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose MessengerRelayedETHAmountBegin
+ALTER TABLE messenger_message_match
+    ADD COLUMN relayed_eth_amount         VARCHAR         NOT NULL DEFAULT '';
+-- +goose MessengerRelayedETHAmountEnd
+
+-- +goose Down
+-- +goose MessengerRelayedETHAmountBegin
+ALTER TABLE messenger_message_match
+    DROP COLUMN if exists relayed_eth_amount;
+-- +goose MessengerRelayedETHAmountEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose WithdrawTrieCheckpointBegin
+CREATE TABLE withdraw_trie_checkpoint
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    l2_block_number                  BIGINT          NOT NULL,
+    next_message_nonce               BIGINT          NOT NULL,
+    withdraw_root                    VARCHAR         NOT NULL,
+    branches                         BYTEA           NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX if not exists idx_wtc_l2_block_number ON withdraw_trie_checkpoint (l2_block_number);
+-- +goose WithdrawTrieCheckpointEnd
+
+-- +goose Down
+-- +goose WithdrawTrieCheckpointBegin
+drop table if exists withdraw_trie_checkpoint;
+-- +goose WithdrawTrieCheckpointEnd
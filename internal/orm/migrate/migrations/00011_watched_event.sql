@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose WatchedEventBegin
+CREATE TABLE watched_event
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    layer                            INTEGER         NOT NULL,
+    contract_name                    VARCHAR         NOT NULL,
+    contract_address                 VARCHAR         NOT NULL,
+    event_name                       VARCHAR         NOT NULL,
+    block_number                     BIGINT          NOT NULL,
+    tx_hash                          VARCHAR         NOT NULL,
+    log_index                        INTEGER         NOT NULL,
+    args                             TEXT            NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX if not exists idx_we_layer_tx_hash_log_index ON watched_event (layer, tx_hash, log_index);
+CREATE INDEX if not exists idx_we_layer_contract_event_block ON watched_event (layer, contract_address, event_name, block_number desc);
+-- +goose WatchedEventEnd
+
+-- +goose Down
+-- +goose WatchedEventBegin
+drop table if exists watched_event;
+-- +goose WatchedEventEnd
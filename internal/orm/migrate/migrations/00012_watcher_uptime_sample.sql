@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose WatcherUptimeSampleBegin
+CREATE TABLE watcher_uptime_sample
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    layer                            VARCHAR         NOT NULL,
+    block_number                     BIGINT          NOT NULL,
+    last_advanced_at                 TIMESTAMP(0)    NOT NULL,
+    up                               BOOLEAN         NOT NULL,
+    sampled_at                       TIMESTAMP(0)    NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE INDEX if not exists idx_wus_layer_sampled_at ON watcher_uptime_sample (layer, sampled_at);
+CREATE INDEX if not exists idx_wus_sampled_at ON watcher_uptime_sample (sampled_at);
+-- +goose WatcherUptimeSampleEnd
+
+-- +goose Down
+-- +goose WatcherUptimeSampleBegin
+drop table if exists watcher_uptime_sample;
+-- +goose WatcherUptimeSampleEnd
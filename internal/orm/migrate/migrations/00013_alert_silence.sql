@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose AlertSilenceBegin
+CREATE TABLE alert_silence
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    kind                             VARCHAR         NOT NULL DEFAULT '',
+    layer                            VARCHAR         NOT NULL DEFAULT '',
+    token                            VARCHAR         NOT NULL DEFAULT '',
+    address                          VARCHAR         NOT NULL DEFAULT '',
+    starts_at                        TIMESTAMP(0)    NOT NULL,
+    ends_at                          TIMESTAMP(0)    NOT NULL,
+    created_by                       VARCHAR         NOT NULL,
+    reason                           TEXT            NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE INDEX if not exists idx_as_ends_at ON alert_silence (ends_at);
+
+CREATE TABLE silenced_alert
+(
+    id                               BIGSERIAL       PRIMARY KEY,
+    silence_id                       BIGINT          NOT NULL,
+    kind                             VARCHAR         NOT NULL,
+    severity                         VARCHAR         NOT NULL,
+    title                            VARCHAR         NOT NULL,
+    message_hash                     VARCHAR         NOT NULL DEFAULT '',
+    content                          TEXT            NOT NULL,
+
+    created_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at                       TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at                       TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE INDEX if not exists idx_sa_silence_id ON silenced_alert (silence_id, id desc);
+-- +goose AlertSilenceEnd
+
+-- +goose Down
+-- +goose AlertSilenceBegin
+drop table if exists silenced_alert;
+drop table if exists alert_silence;
+-- +goose AlertSilenceEnd
@@ -0,0 +1,119 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// ReserveSnapshot is the reconciliation of the l1 escrow balance of a bridged token with its l2 total supply.
+type ReserveSnapshot struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID        int64  `json:"id" gorm:"column:id"`
+	Name      string `json:"name" gorm:"name"`
+	L1Gateway string `json:"l1_gateway" gorm:"l1_gateway"`
+	L1Token   string `json:"l1_token" gorm:"l1_token"`
+	L2Token   string `json:"l2_token" gorm:"l2_token"`
+
+	L1BlockNumber   uint64          `json:"l1_block_number" gorm:"l1_block_number"`
+	L2BlockNumber   uint64          `json:"l2_block_number" gorm:"l2_block_number"`
+	L1EscrowBalance decimal.Decimal `json:"l1_escrow_balance" gorm:"l1_escrow_balance"`
+	L2TotalSupply   decimal.Decimal `json:"l2_total_supply" gorm:"l2_total_supply"`
+	// the deposits and withdrawals processed on one layer only at the block numbers, still escrowed but not minted.
+	InFlight decimal.Decimal `json:"in_flight" gorm:"in_flight"`
+	// the l1 escrow balance minus the l2 total supply and the in-flight amount, negative means the l2 supply is not
+	// fully backed.
+	Imbalance     decimal.Decimal `json:"imbalance" gorm:"imbalance"`
+	Tolerance     decimal.Decimal `json:"tolerance" gorm:"tolerance"`
+	ReserveStatus int             `json:"reserve_status" gorm:"reserve_status"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewReserveSnapshot creates a new ReserveSnapshot database instance.
+func NewReserveSnapshot(db *gorm.DB) *ReserveSnapshot {
+	return &ReserveSnapshot{db: db}
+}
+
+// TableName returns the table name for the ReserveSnapshot model.
+func (*ReserveSnapshot) TableName() string {
+	return "reserve_snapshot"
+}
+
+// GetLatestReserveSnapshot get the latest reserve snapshot of the l1 token, returns nil if the token never reconciled
+func (r *ReserveSnapshot) GetLatestReserveSnapshot(ctx context.Context, l1Token string) (*ReserveSnapshot, error) {
+	var snapshot ReserveSnapshot
+	db := r.db.WithContext(ctx)
+	db = db.Where("l1_token = ?", l1Token)
+	db = db.Order("id desc")
+	if err := db.First(&snapshot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.Warn("ReserveSnapshot.GetLatestReserveSnapshot failed", "error", err)
+		return nil, fmt.Errorf("ReserveSnapshot.GetLatestReserveSnapshot failed err:%w", err)
+	}
+	return &snapshot, nil
+}
+
+// ReserveSummary the reconciliations of a token within a time range.
+type ReserveSummary struct {
+	Name       string
+	L1Token    string
+	Snapshots  int64
+	Imbalanced int64
+}
+
+// GetReserveSummaries get the number of the reconciliations and the imbalanced ones of each token between startTime
+// and endTime.
+func (r *ReserveSnapshot) GetReserveSummaries(ctx context.Context, startTime, endTime time.Time) ([]ReserveSummary, error) {
+	var summaries []ReserveSummary
+	db := r.db.WithContext(ctx)
+	db = db.Model(&ReserveSnapshot{})
+	db = db.Select("name, l1_token, COUNT(*) AS snapshots, COUNT(CASE WHEN reserve_status = ? THEN 1 END) AS imbalanced", types.ReserveStatusTypeInvalid)
+	db = db.Where("created_at >= ? AND created_at < ?", startTime, endTime)
+	db = db.Group("name, l1_token")
+	db = db.Order("name")
+	if err := db.Scan(&summaries).Error; err != nil {
+		log.Warn("ReserveSnapshot.GetReserveSummaries failed", "error", err)
+		return nil, fmt.Errorf("ReserveSnapshot.GetReserveSummaries failed err:%w", err)
+	}
+	return summaries, nil
+}
+
+// InsertReserveSnapshots inserts the reserve snapshots, a token already reconciled at the same heights is ignored.
+func (r *ReserveSnapshot) InsertReserveSnapshots(ctx context.Context, snapshots []ReserveSnapshot, dbTX ...*gorm.DB) (int64, error) {
+	if len(snapshots) == 0 {
+		return 0, nil
+	}
+
+	db := r.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+
+	db = db.WithContext(ctx)
+	db = db.Model(&ReserveSnapshot{})
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "l1_token"}, {Name: "l1_block_number"}, {Name: "l2_block_number"}},
+		DoNothing: true,
+	})
+
+	result := db.Create(&snapshots)
+	if result.Error != nil {
+		log.Warn("ReserveSnapshot.InsertReserveSnapshots failed", "error", result.Error)
+		return 0, fmt.Errorf("ReserveSnapshot.InsertReserveSnapshots failed err:%w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,98 @@
+package orm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestReserveSnapshot_InsertReserveSnapshots(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	reserveOrm := NewReserveSnapshot(db)
+
+	l1Token := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	snapshot := ReserveSnapshot{
+		Name:            "USDT",
+		L1Gateway:       "0xD8A791fE2bE73eb6E6cF1eb0cb3F36adC9B3F8f9",
+		L1Token:         l1Token,
+		L2Token:         "0xf55BEC9cafDbE8730f096Aa55dad6D22d44099Df",
+		L1BlockNumber:   100,
+		L2BlockNumber:   200,
+		L1EscrowBalance: decimal.NewFromInt(1000),
+		L2TotalSupply:   decimal.NewFromInt(900),
+		Imbalance:       decimal.NewFromInt(100),
+		Tolerance:       decimal.NewFromInt(10),
+		ReserveStatus:   int(types.ReserveStatusTypeInvalid),
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"getLatestReserveSnapshotEmpty", func(t *testing.T) {
+				latest, err := reserveOrm.GetLatestReserveSnapshot(ctx, l1Token)
+				assert.NoError(t, err)
+				assert.Nil(t, latest)
+			},
+		},
+		{
+			"insertReserveSnapshots", func(t *testing.T) {
+				affectRows, err := reserveOrm.InsertReserveSnapshots(ctx, []ReserveSnapshot{snapshot})
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				affectRows, err = reserveOrm.InsertReserveSnapshots(ctx, []ReserveSnapshot{snapshot})
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(0))
+
+				next := snapshot
+				next.L1BlockNumber = 101
+				next.Imbalance = decimal.NewFromInt(5)
+				next.ReserveStatus = int(types.ReserveStatusTypeValid)
+				affectRows, err = reserveOrm.InsertReserveSnapshots(ctx, []ReserveSnapshot{next})
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				latest, err := reserveOrm.GetLatestReserveSnapshot(ctx, l1Token)
+				assert.NoError(t, err)
+				assert.Equal(t, latest.L1BlockNumber, uint64(101))
+				assert.True(t, latest.Imbalance.Equal(decimal.NewFromInt(5)))
+				assert.Equal(t, latest.ReserveStatus, int(types.ReserveStatusTypeValid))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
+
+func TestReserveSnapshot_GetReserveSummaries(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	reserveOrm := NewReserveSnapshot(db)
+
+	snapshots := []ReserveSnapshot{
+		{Name: "USDT", L1Token: "0xdAC17F958D2ee523a2206206994597C13D831ec7", L1BlockNumber: 100, L2BlockNumber: 200, ReserveStatus: int(types.ReserveStatusTypeValid)},
+		{Name: "USDT", L1Token: "0xdAC17F958D2ee523a2206206994597C13D831ec7", L1BlockNumber: 101, L2BlockNumber: 201, ReserveStatus: int(types.ReserveStatusTypeInvalid)},
+		{Name: "WBTC", L1Token: "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", L1BlockNumber: 100, L2BlockNumber: 200, ReserveStatus: int(types.ReserveStatusTypeValid)},
+	}
+	_, err := reserveOrm.InsertReserveSnapshots(ctx, snapshots)
+	assert.NoError(t, err)
+
+	now := time.Now()
+	summaries, err := reserveOrm.GetReserveSummaries(ctx, now.Add(-time.Hour), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []ReserveSummary{
+		{Name: "USDT", L1Token: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Snapshots: 2, Imbalanced: 1},
+		{Name: "WBTC", L1Token: "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", Snapshots: 1, Imbalanced: 0},
+	}, summaries)
+}
@@ -0,0 +1,63 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+)
+
+// SilencedAlert the record of an alert not notified because it matched an active silence.
+type SilencedAlert struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID          int64  `json:"id" gorm:"column:id"`
+	SilenceID   int64  `json:"silence_id" gorm:"column:silence_id"`
+	Kind        string `json:"kind" gorm:"column:kind"`
+	Severity    string `json:"severity" gorm:"column:severity"`
+	Title       string `json:"title" gorm:"column:title"`
+	MessageHash string `json:"message_hash" gorm:"column:message_hash"`
+	// the mrkdwn text of the alert.
+	Content string `json:"content" gorm:"column:content"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewSilencedAlert creates a new SilencedAlert database instance.
+func NewSilencedAlert(db *gorm.DB) *SilencedAlert {
+	return &SilencedAlert{db: db}
+}
+
+// TableName returns the table name for the SilencedAlert model.
+func (*SilencedAlert) TableName() string {
+	return "silenced_alert"
+}
+
+// InsertSilencedAlert inserts the silenced alert.
+func (s *SilencedAlert) InsertSilencedAlert(ctx context.Context, alert SilencedAlert) error {
+	db := s.db.WithContext(ctx)
+	db = db.Model(&SilencedAlert{})
+	if err := db.Create(&alert).Error; err != nil {
+		log.Warn("SilencedAlert.InsertSilencedAlert failed", "error", err)
+		return fmt.Errorf("SilencedAlert.InsertSilencedAlert failed err:%w", err)
+	}
+	return nil
+}
+
+// GetSilencedAlerts get the latest alerts silenced by the silence, the newest first.
+func (s *SilencedAlert) GetSilencedAlerts(ctx context.Context, silenceID int64, limit int) ([]SilencedAlert, error) {
+	var alerts []SilencedAlert
+	db := s.db.WithContext(ctx)
+	db = db.Where("silence_id = ?", silenceID)
+	db = db.Order("id desc")
+	db = db.Limit(limit)
+	if err := db.Find(&alerts).Error; err != nil {
+		log.Warn("SilencedAlert.GetSilencedAlerts failed", "error", err)
+		return nil, fmt.Errorf("SilencedAlert.GetSilencedAlerts failed err:%w", err)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,79 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+)
+
+// WatchedEvent is the record of an event captured from a contract watched by config.
+type WatchedEvent struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID              int64  `json:"id" gorm:"column:id"`
+	Layer           int    `json:"layer" gorm:"layer"`
+	ContractName    string `json:"contract_name" gorm:"contract_name"`
+	ContractAddress string `json:"contract_address" gorm:"contract_address"`
+	EventName       string `json:"event_name" gorm:"event_name"`
+	BlockNumber     uint64 `json:"block_number" gorm:"block_number"`
+	TxHash          string `json:"tx_hash" gorm:"tx_hash"`
+	LogIndex        uint   `json:"log_index" gorm:"log_index"`
+	// the decoded event parameters in json.
+	Args string `json:"args" gorm:"args"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewWatchedEvent creates a new WatchedEvent database instance.
+func NewWatchedEvent(db *gorm.DB) *WatchedEvent {
+	return &WatchedEvent{db: db}
+}
+
+// TableName returns the table name for the WatchedEvent model.
+func (*WatchedEvent) TableName() string {
+	return "watched_event"
+}
+
+// InsertWatchedEvent inserts the watched event, an event already recorded is ignored and returns zero affected rows.
+func (w *WatchedEvent) InsertWatchedEvent(ctx context.Context, event WatchedEvent, dbTX ...*gorm.DB) (int64, error) {
+	db := w.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+
+	db = db.WithContext(ctx)
+	db = db.Model(&WatchedEvent{})
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "layer"}, {Name: "tx_hash"}, {Name: "log_index"}},
+		DoNothing: true,
+	})
+
+	result := db.Create(&event)
+	if result.Error != nil {
+		log.Warn("WatchedEvent.InsertWatchedEvent failed", "error", result.Error)
+		return 0, fmt.Errorf("WatchedEvent.InsertWatchedEvent failed err:%w, event: %v", result.Error, event)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetWatchedEvents get the watched events of the layer which block number between startBlockNumber and endBlockNumber
+func (w *WatchedEvent) GetWatchedEvents(ctx context.Context, layer types.LayerType, startBlockNumber, endBlockNumber uint64) ([]WatchedEvent, error) {
+	var watchedEvents []WatchedEvent
+	db := w.db.WithContext(ctx)
+	db = db.Where("layer = ?", layer)
+	db = db.Where("block_number >= ? AND block_number <= ?", startBlockNumber, endBlockNumber)
+	db = db.Order("block_number asc, log_index asc")
+	if err := db.Find(&watchedEvents).Error; err != nil {
+		log.Warn("WatchedEvent.GetWatchedEvents failed", "error", err)
+		return nil, fmt.Errorf("WatchedEvent.GetWatchedEvents failed err:%w", err)
+	}
+	return watchedEvents, nil
+}
@@ -0,0 +1,66 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/types"
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestWatchedEvent_InsertWatchedEvent(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	watchedOrm := NewWatchedEvent(db)
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"insertWatchedEvent", func(t *testing.T) {
+				event := WatchedEvent{
+					Layer:           int(types.Layer1),
+					ContractName:    "rollup",
+					ContractAddress: "0xa13BAF47339d63B743e7Da8741db5456DAc1E556",
+					EventName:       "FinalizeBatch",
+					BlockNumber:     100,
+					TxHash:          "0xfc7d3ea5ec8dc9b664a5a886c3b33d21e665355057601033481a439498efb79a",
+					LogIndex:        1,
+					Args:            `{"batchIndex":"10"}`,
+				}
+				affectRows, err := watchedOrm.InsertWatchedEvent(ctx, event)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+
+				affectRows, err = watchedOrm.InsertWatchedEvent(ctx, event)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(0))
+
+				event.Layer = int(types.Layer2)
+				affectRows, err = watchedOrm.InsertWatchedEvent(ctx, event)
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(1))
+			},
+		},
+		{
+			"getWatchedEvents", func(t *testing.T) {
+				watchedEvents, err := watchedOrm.GetWatchedEvents(ctx, types.Layer1, 0, 100)
+				assert.NoError(t, err)
+				assert.Len(t, watchedEvents, 1)
+				assert.Equal(t, watchedEvents[0].EventName, "FinalizeBatch")
+				assert.Equal(t, watchedEvents[0].Args, `{"batchIndex":"10"}`)
+
+				watchedEvents, err = watchedOrm.GetWatchedEvents(ctx, types.Layer1, 101, 200)
+				assert.NoError(t, err)
+				assert.Len(t, watchedEvents, 0)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
@@ -0,0 +1,123 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+)
+
+// WatcherUptimeSample a sample of the block number processed by the watcher of a layer, the watcher is up at the
+// sample if its block number advanced within the stall timeout.
+type WatcherUptimeSample struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID          int64  `json:"id" gorm:"column:id"`
+	Layer       string `json:"layer" gorm:"column:layer"`
+	BlockNumber uint64 `json:"block_number" gorm:"column:block_number"`
+	// the time the block number last advanced, the stall of the watcher is tracked across restarts from it.
+	LastAdvancedAt time.Time `json:"last_advanced_at" gorm:"column:last_advanced_at"`
+	Up             bool      `json:"up" gorm:"column:up"`
+	SampledAt      time.Time `json:"sampled_at" gorm:"column:sampled_at"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewWatcherUptimeSample creates a new WatcherUptimeSample database instance.
+func NewWatcherUptimeSample(db *gorm.DB) *WatcherUptimeSample {
+	return &WatcherUptimeSample{db: db}
+}
+
+// TableName returns the table name for the WatcherUptimeSample model.
+func (*WatcherUptimeSample) TableName() string {
+	return "watcher_uptime_sample"
+}
+
+// WatcherUptime the samples of a watcher within a time range.
+type WatcherUptime struct {
+	Up          int64
+	Samples     int64
+	BlockNumber uint64
+}
+
+// GetLatestWatcherUptimeSample get the latest sample of the layer, returns nil if the layer was never sampled.
+func (w *WatcherUptimeSample) GetLatestWatcherUptimeSample(ctx context.Context, layer string) (*WatcherUptimeSample, error) {
+	var sample WatcherUptimeSample
+	db := w.db.WithContext(ctx)
+	db = db.Where("layer = ?", layer)
+	db = db.Order("sampled_at desc")
+	if err := db.First(&sample).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		log.Warn("WatcherUptimeSample.GetLatestWatcherUptimeSample failed", "error", err)
+		return nil, fmt.Errorf("WatcherUptimeSample.GetLatestWatcherUptimeSample failed err:%w", err)
+	}
+	return &sample, nil
+}
+
+// GetFirstSampledAt get the time of the first sample kept of the layer, or of any layer if the layer is empty.
+// Returns the zero time if there is no sample.
+func (w *WatcherUptimeSample) GetFirstSampledAt(ctx context.Context, layer string) (time.Time, error) {
+	var sample WatcherUptimeSample
+	db := w.db.WithContext(ctx)
+	if layer != "" {
+		db = db.Where("layer = ?", layer)
+	}
+	db = db.Order("sampled_at asc")
+	if err := db.First(&sample).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, nil
+		}
+		log.Warn("WatcherUptimeSample.GetFirstSampledAt failed", "error", err)
+		return time.Time{}, fmt.Errorf("WatcherUptimeSample.GetFirstSampledAt failed err:%w", err)
+	}
+	return sample.SampledAt, nil
+}
+
+// GetWatcherUptime get the number of the samples of the layer between startTime and endTime and the ones the watcher
+// was up at, along with the latest block number sampled.
+func (w *WatcherUptimeSample) GetWatcherUptime(ctx context.Context, layer string, startTime, endTime time.Time) (*WatcherUptime, error) {
+	var uptime WatcherUptime
+	db := w.db.WithContext(ctx)
+	db = db.Model(&WatcherUptimeSample{})
+	db = db.Select("COUNT(CASE WHEN up THEN 1 END) AS up, COUNT(*) AS samples, COALESCE(MAX(block_number), 0) AS block_number")
+	db = db.Where("layer = ?", layer)
+	db = db.Where("sampled_at >= ? AND sampled_at < ?", startTime, endTime)
+	if err := db.Scan(&uptime).Error; err != nil {
+		log.Warn("WatcherUptimeSample.GetWatcherUptime failed", "error", err)
+		return nil, fmt.Errorf("WatcherUptimeSample.GetWatcherUptime failed err:%w", err)
+	}
+	return &uptime, nil
+}
+
+// InsertWatcherUptimeSamples inserts the samples of the watchers.
+func (w *WatcherUptimeSample) InsertWatcherUptimeSamples(ctx context.Context, samples []WatcherUptimeSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	db := w.db.WithContext(ctx)
+	db = db.Model(&WatcherUptimeSample{})
+	if err := db.Create(&samples).Error; err != nil {
+		log.Warn("WatcherUptimeSample.InsertWatcherUptimeSamples failed", "error", err)
+		return fmt.Errorf("WatcherUptimeSample.InsertWatcherUptimeSamples failed err:%w", err)
+	}
+	return nil
+}
+
+// DeleteWatcherUptimeSamples deletes the samples taken before the time, they're out of the longest digest period.
+func (w *WatcherUptimeSample) DeleteWatcherUptimeSamples(ctx context.Context, before time.Time) error {
+	db := w.db.WithContext(ctx)
+	db = db.Unscoped()
+	db = db.Where("sampled_at < ?", before)
+	if err := db.Delete(&WatcherUptimeSample{}).Error; err != nil {
+		log.Warn("WatcherUptimeSample.DeleteWatcherUptimeSamples failed", "error", err)
+		return fmt.Errorf("WatcherUptimeSample.DeleteWatcherUptimeSamples failed err:%w", err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package orm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestWatcherUptimeSample(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	sampleOrm := NewWatcherUptimeSample(db)
+
+	startTime := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	var samples []WatcherUptimeSample
+	for i := 0; i < 10; i++ {
+		samples = append(samples, WatcherUptimeSample{
+			Layer:          "Layer1",
+			BlockNumber:    uint64(100 + i),
+			LastAdvancedAt: startTime.Add(time.Duration(i) * time.Minute),
+			Up:             i%2 == 0,
+			SampledAt:      startTime.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"getEmpty", func(t *testing.T) {
+				latest, err := sampleOrm.GetLatestWatcherUptimeSample(ctx, "Layer1")
+				assert.NoError(t, err)
+				assert.Nil(t, latest)
+
+				firstSampledAt, err := sampleOrm.GetFirstSampledAt(ctx, "")
+				assert.NoError(t, err)
+				assert.True(t, firstSampledAt.IsZero())
+			},
+		},
+		{
+			"insertAndGetWatcherUptime", func(t *testing.T) {
+				assert.NoError(t, sampleOrm.InsertWatcherUptimeSamples(ctx, samples))
+				assert.NoError(t, sampleOrm.InsertWatcherUptimeSamples(ctx, []WatcherUptimeSample{{
+					Layer: "Layer2", BlockNumber: 200, LastAdvancedAt: startTime, Up: true, SampledAt: startTime.Add(5 * time.Minute),
+				}}))
+
+				latest, err := sampleOrm.GetLatestWatcherUptimeSample(ctx, "Layer1")
+				assert.NoError(t, err)
+				assert.Equal(t, uint64(109), latest.BlockNumber)
+				assert.True(t, latest.LastAdvancedAt.Equal(startTime.Add(9*time.Minute)))
+
+				firstSampledAt, err := sampleOrm.GetFirstSampledAt(ctx, "Layer2")
+				assert.NoError(t, err)
+				assert.True(t, firstSampledAt.Equal(startTime.Add(5*time.Minute)))
+
+				uptime, err := sampleOrm.GetWatcherUptime(ctx, "Layer1", startTime.Add(2*time.Minute), startTime.Add(8*time.Minute))
+				assert.NoError(t, err)
+				assert.Equal(t, &WatcherUptime{Up: 3, Samples: 6, BlockNumber: 107}, uptime)
+			},
+		},
+		{
+			"deleteWatcherUptimeSamples", func(t *testing.T) {
+				assert.NoError(t, sampleOrm.DeleteWatcherUptimeSamples(ctx, startTime.Add(5*time.Minute)))
+
+				firstSampledAt, err := sampleOrm.GetFirstSampledAt(ctx, "")
+				assert.NoError(t, err)
+				assert.True(t, firstSampledAt.Equal(startTime.Add(5*time.Minute)))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
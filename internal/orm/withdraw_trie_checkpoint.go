@@ -0,0 +1,95 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WithdrawTrieCheckpoint is the state of the l2 withdraw trie after the messages of a l2 block are appended, the
+// trie can be recovered from the frontier branches of any checkpoint.
+type WithdrawTrieCheckpoint struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID               int64  `json:"id" gorm:"column:id"`
+	L2BlockNumber    uint64 `json:"l2_block_number" gorm:"l2_block_number"`
+	NextMessageNonce uint64 `json:"next_message_nonce" gorm:"next_message_nonce"`
+	WithdrawRoot     string `json:"withdraw_root" gorm:"withdraw_root"`
+	// the concatenated frontier branches of the trie, the last branch is the withdraw root.
+	Branches []byte `json:"branches" gorm:"branches"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewWithdrawTrieCheckpoint creates a new WithdrawTrieCheckpoint database instance.
+func NewWithdrawTrieCheckpoint(db *gorm.DB) *WithdrawTrieCheckpoint {
+	return &WithdrawTrieCheckpoint{db: db}
+}
+
+// TableName returns the table name for the WithdrawTrieCheckpoint model.
+func (*WithdrawTrieCheckpoint) TableName() string {
+	return "withdraw_trie_checkpoint"
+}
+
+// GetLatestWithdrawTrieCheckpoint get the checkpoint with the largest l2 block number
+func (w *WithdrawTrieCheckpoint) GetLatestWithdrawTrieCheckpoint(ctx context.Context) (*WithdrawTrieCheckpoint, error) {
+	var checkpoint WithdrawTrieCheckpoint
+	db := w.db.WithContext(ctx)
+	db = db.Order("l2_block_number DESC")
+	err := db.First(&checkpoint).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Warn("WithdrawTrieCheckpoint.GetLatestWithdrawTrieCheckpoint failed", "error", err)
+		return nil, fmt.Errorf("WithdrawTrieCheckpoint.GetLatestWithdrawTrieCheckpoint failed err:%w", err)
+	}
+	return &checkpoint, nil
+}
+
+// GetWithdrawTrieCheckpoints get at most limit checkpoints whose l2 block number is larger than afterBlockNumber
+func (w *WithdrawTrieCheckpoint) GetWithdrawTrieCheckpoints(ctx context.Context, afterBlockNumber uint64, limit int) ([]WithdrawTrieCheckpoint, error) {
+	var checkpoints []WithdrawTrieCheckpoint
+	db := w.db.WithContext(ctx)
+	db = db.Where("l2_block_number > ?", afterBlockNumber)
+	db = db.Order("l2_block_number ASC")
+	db = db.Limit(limit)
+	if err := db.Find(&checkpoints).Error; err != nil {
+		log.Warn("WithdrawTrieCheckpoint.GetWithdrawTrieCheckpoints failed", "error", err)
+		return nil, fmt.Errorf("WithdrawTrieCheckpoint.GetWithdrawTrieCheckpoints failed err:%w", err)
+	}
+	return checkpoints, nil
+}
+
+// InsertOrUpdateWithdrawTrieCheckpoints inserts the checkpoints, the checkpoint of a block already stored is replaced.
+func (w *WithdrawTrieCheckpoint) InsertOrUpdateWithdrawTrieCheckpoints(ctx context.Context, checkpoints []WithdrawTrieCheckpoint, dbTX ...*gorm.DB) (int64, error) {
+	if len(checkpoints) == 0 {
+		return 0, nil
+	}
+
+	db := w.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+
+	db = db.WithContext(ctx)
+	db = db.Model(&WithdrawTrieCheckpoint{})
+	db = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "l2_block_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{"next_message_nonce", "withdraw_root", "branches", "updated_at"}),
+	})
+
+	result := db.Create(&checkpoints)
+	if result.Error != nil {
+		log.Warn("WithdrawTrieCheckpoint.InsertOrUpdateWithdrawTrieCheckpoints failed", "error", result.Error)
+		return 0, fmt.Errorf("WithdrawTrieCheckpoint.InsertOrUpdateWithdrawTrieCheckpoints failed err:%w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,77 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/scroll-tech/chain-monitor/internal/utils/testcontainer"
+)
+
+func TestWithdrawTrieCheckpoint_InsertOrUpdateWithdrawTrieCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	db := testcontainer.SetupDB(ctx, t)
+	checkpointOrm := NewWithdrawTrieCheckpoint(db)
+
+	first := WithdrawTrieCheckpoint{
+		L2BlockNumber:    100,
+		NextMessageNonce: 1,
+		WithdrawRoot:     "0x0000000000000000000000000000000000000000000000000000000000000001",
+		Branches:         []byte{1},
+	}
+	second := WithdrawTrieCheckpoint{
+		L2BlockNumber:    200,
+		NextMessageNonce: 3,
+		WithdrawRoot:     "0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0",
+		Branches:         []byte{2},
+	}
+
+	tests := []struct {
+		name string
+		test func(t *testing.T)
+	}{
+		{
+			"emptyCheckpoints", func(t *testing.T) {
+				checkpoint, err := checkpointOrm.GetLatestWithdrawTrieCheckpoint(ctx)
+				assert.NoError(t, err)
+				assert.Nil(t, checkpoint)
+			},
+		},
+		{
+			"insertCheckpoints", func(t *testing.T) {
+				affectRows, err := checkpointOrm.InsertOrUpdateWithdrawTrieCheckpoints(ctx, []WithdrawTrieCheckpoint{first, second})
+				assert.NoError(t, err)
+				assert.Equal(t, affectRows, int64(2))
+
+				checkpoint, err := checkpointOrm.GetLatestWithdrawTrieCheckpoint(ctx)
+				assert.NoError(t, err)
+				assert.Equal(t, checkpoint.L2BlockNumber, uint64(200))
+				assert.Equal(t, checkpoint.WithdrawRoot, second.WithdrawRoot)
+
+				checkpoints, err := checkpointOrm.GetWithdrawTrieCheckpoints(ctx, 100, 10)
+				assert.NoError(t, err)
+				assert.Len(t, checkpoints, 1)
+				assert.Equal(t, checkpoints[0].NextMessageNonce, uint64(3))
+			},
+		},
+		{
+			"updateCheckpoints", func(t *testing.T) {
+				replaced := first
+				replaced.NextMessageNonce = 2
+				_, err := checkpointOrm.InsertOrUpdateWithdrawTrieCheckpoints(ctx, []WithdrawTrieCheckpoint{replaced})
+				assert.NoError(t, err)
+
+				checkpoints, err := checkpointOrm.GetWithdrawTrieCheckpoints(ctx, 0, 1)
+				assert.NoError(t, err)
+				assert.Len(t, checkpoints, 1)
+				assert.Equal(t, checkpoints[0].NextMessageNonce, uint64(2))
+				assert.Equal(t, checkpoints[0].Branches, []byte{1})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, test.test)
+	}
+}
@@ -20,4 +20,12 @@ func Route(router *gin.Engine) {
 
 func v1(router *gin.RouterGroup) {
 	router.GET("/batch_status", controller.FinalizeBatchCtl.BatchStatus)
+	router.GET("/alerts/pending", controller.AlertCtl.PendingAlerts)
+	router.GET("/silences", controller.SilenceCtl.List)
+	router.GET("/silences/:id/alerts", controller.SilenceCtl.SilencedAlerts)
+
+	admin := router.Group("", controller.AuthCtl.Admin)
+	admin.POST("/alerts/:alert_id/ack", controller.AlertCtl.Acknowledge)
+	admin.POST("/silences", controller.SilenceCtl.Create)
+	admin.DELETE("/silences/:id", controller.SilenceCtl.Expire)
 }
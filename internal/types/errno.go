@@ -7,4 +7,10 @@ const (
 	InternalServerError = 500
 	// ErrParameterInvalidNo is invalid params
 	ErrParameterInvalidNo = 40001
+	// ErrAlertNotPendingNo the alert isn't pending acknowledgement
+	ErrAlertNotPendingNo = 40002
+	// ErrSilenceNotFoundNo the silence doesn't exist or is expired
+	ErrSilenceNotFoundNo = 40003
+	// ErrUnauthorizedNo the admin token is missing or invalid
+	ErrUnauthorizedNo = 40101
 )
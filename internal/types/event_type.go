@@ -90,4 +90,14 @@ const (
 	L2FinalizeBatchDepositERC1155
 	// L2BatchWithdrawERC1155 represents the event for batch withdrawing ERC1155 tokens on Layer 2.
 	L2BatchWithdrawERC1155
+
+	// L1FailedRelayedMessage represents a message whose relay failed on Layer 1.
+	L1FailedRelayedMessage
+	// L2FailedRelayedMessage represents a message whose relay failed on Layer 2.
+	L2FailedRelayedMessage
+
+	// L1ReplayMessage represents a Layer 1 message replayed through the scroll messenger.
+	L1ReplayMessage
+	// L1DropMessage represents a Layer 1 message dropped through the scroll messenger.
+	L1DropMessage
 )
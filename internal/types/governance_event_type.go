@@ -0,0 +1,27 @@
+package types
+
+//go:generate stringer -type GovernanceEventType
+
+// GovernanceEventType represents the type of privileged event raised by the bridge contracts.
+type GovernanceEventType int
+
+const (
+	// GovernanceEventTypeUnknown represents an unknown or undefined governance event.
+	GovernanceEventTypeUnknown GovernanceEventType = iota
+	// GovernanceEventTypeUpgraded represents the EIP-1967 proxy implementation upgraded event.
+	GovernanceEventTypeUpgraded
+	// GovernanceEventTypeAdminChanged represents the EIP-1967 proxy admin changed event.
+	GovernanceEventTypeAdminChanged
+	// GovernanceEventTypeOwnershipTransferred represents the contract ownership transferred event.
+	GovernanceEventTypeOwnershipTransferred
+	// GovernanceEventTypePaused represents the contract paused event.
+	GovernanceEventTypePaused
+	// GovernanceEventTypeUnpaused represents the contract unpaused event.
+	GovernanceEventTypeUnpaused
+	// GovernanceEventTypeRoleGranted represents the access control role granted event.
+	GovernanceEventTypeRoleGranted
+	// GovernanceEventTypeRoleRevoked represents the access control role revoked event.
+	GovernanceEventTypeRoleRevoked
+	// GovernanceEventTypeUpdateTokenMapping represents the custom gateway token mapping updated event.
+	GovernanceEventTypeUpdateTokenMapping
+)
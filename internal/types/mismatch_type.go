@@ -18,4 +18,16 @@ const (
 	MismatchTypeL1AmountNotMatch
 	// MismatchTypeL2AmountNotMatch represents a mismatch where the layer2 amount does not match the Layer 1
 	MismatchTypeL2AmountNotMatch
+	// MismatchTypeL1TokenNotMatch represents a mismatch where the layer1 l1/l2 token addresses do not match the Layer 2
+	MismatchTypeL1TokenNotMatch
+	// MismatchTypeL2TokenNotMatch represents a mismatch where the layer2 l1/l2 token addresses do not match the Layer 1
+	MismatchTypeL2TokenNotMatch
+	// MismatchTypeL1RecipientNotMatch represents a mismatch where the layer1 recipient does not match the Layer 2
+	MismatchTypeL1RecipientNotMatch
+	// MismatchTypeL2RecipientNotMatch represents a mismatch where the layer2 recipient does not match the Layer 1
+	MismatchTypeL2RecipientNotMatch
+	// MismatchTypeL1SenderNotMatch represents a mismatch where the layer1 sender does not match the Layer 2
+	MismatchTypeL1SenderNotMatch
+	// MismatchTypeL2SenderNotMatch represents a mismatch where the layer2 sender does not match the Layer 1
+	MismatchTypeL2SenderNotMatch
 )
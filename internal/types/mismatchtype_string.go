@@ -14,11 +14,17 @@ func _() {
 	_ = x[MismatchTypeL2EventNotMatch-3]
 	_ = x[MismatchTypeL1AmountNotMatch-4]
 	_ = x[MismatchTypeL2AmountNotMatch-5]
+	_ = x[MismatchTypeL1TokenNotMatch-6]
+	_ = x[MismatchTypeL2TokenNotMatch-7]
+	_ = x[MismatchTypeL1RecipientNotMatch-8]
+	_ = x[MismatchTypeL2RecipientNotMatch-9]
+	_ = x[MismatchTypeL1SenderNotMatch-10]
+	_ = x[MismatchTypeL2SenderNotMatch-11]
 }
 
-const _MismatchType_name = "MismatchTypeUnknownMismatchTypeValidMismatchTypeL1EventNotMatchMismatchTypeL2EventNotMatchMismatchTypeL1AmountNotMatchMismatchTypeL2AmountNotMatch"
+const _MismatchType_name = "MismatchTypeUnknownMismatchTypeValidMismatchTypeL1EventNotMatchMismatchTypeL2EventNotMatchMismatchTypeL1AmountNotMatchMismatchTypeL2AmountNotMatchMismatchTypeL1TokenNotMatchMismatchTypeL2TokenNotMatchMismatchTypeL1RecipientNotMatchMismatchTypeL2RecipientNotMatchMismatchTypeL1SenderNotMatchMismatchTypeL2SenderNotMatch"
 
-var _MismatchType_index = [...]uint8{0, 19, 36, 63, 90, 118, 146}
+var _MismatchType_index = [...]uint16{0, 19, 36, 63, 90, 118, 146, 173, 200, 231, 262, 290, 318}
 
 func (i MismatchType) String() string {
 	if i < 0 || i >= MismatchType(len(_MismatchType_index)-1) {
@@ -1,8 +1,32 @@
 package types
 
+import "time"
+
 // FinalizeBatchCheckParam the param of batch status check
 type FinalizeBatchCheckParam struct {
 	BatchIndex       uint64 `form:"batch_index" json:"batch_index" binding:"required"`
 	StartBlockNumber uint64 `form:"start_block_number" json:"start_block_number" binding:"required"`
 	EndBlockNumber   uint64 `form:"end_block_number" json:"end_block_number" binding:"required"`
 }
+
+// AlertAckParam the param of critical alert acknowledgement
+type AlertAckParam struct {
+	AlertID string `uri:"alert_id" binding:"required"`
+}
+
+// SilenceCreateParam the param of alert silence creation, at least one of the kind, layer, token and address matchers
+// is required. The silence starts now if starts at is unset, it's created by the holder of the admin token.
+type SilenceCreateParam struct {
+	Kind     string    `json:"kind"`
+	Layer    string    `json:"layer"`
+	Token    string    `json:"token"`
+	Address  string    `json:"address"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason" binding:"required"`
+}
+
+// SilenceIDParam the param of the alert silence id
+type SilenceIDParam struct {
+	ID int64 `uri:"id" binding:"required"`
+}
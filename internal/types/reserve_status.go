@@ -0,0 +1,13 @@
+package types
+
+//go:generate stringer -type ReserveStatus
+
+// ReserveStatus represents the status of a reserve reconciliation between l1 escrow and l2 supply.
+type ReserveStatus int
+
+const (
+	// ReserveStatusTypeInvalid represents an imbalance exceeding the tolerance.
+	ReserveStatusTypeInvalid ReserveStatus = iota
+	// ReserveStatusTypeValid represents an imbalance within the tolerance.
+	ReserveStatusTypeValid
+)
@@ -0,0 +1,125 @@
+package calltrace
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/rpc"
+)
+
+// CallFrame is a call frame of the callTracer.
+type CallFrame struct {
+	Type   string         `json:"type"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *hexutil.Big   `json:"value,omitempty"`
+	Input  hexutil.Bytes  `json:"input"`
+	Output hexutil.Bytes  `json:"output,omitempty"`
+	Error  string         `json:"error,omitempty"`
+	Calls  []CallFrame    `json:"calls,omitempty"`
+}
+
+// TxTrace is the call trace of a transaction in the block.
+type TxTrace struct {
+	TxHash common.Hash `json:"txHash"`
+	Result *CallFrame  `json:"result"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// Reverted returns true if the frame is reverted, the value transfers of a reverted frame and its sub frames are undone.
+func (f *CallFrame) Reverted() bool {
+	return f.Error != ""
+}
+
+// TransferredValue returns the ether the frame moves from the From to the To, the delegate and static calls move none.
+func (f *CallFrame) TransferredValue() *big.Int {
+	switch f.Type {
+	case "CALL", "CREATE", "CREATE2", "SELFDESTRUCT":
+		if f.Value != nil {
+			return f.Value.ToInt()
+		}
+	}
+	return new(big.Int)
+}
+
+// AddressCall is a not reverted call into an address along with the not reverted value transfers out of the address
+// made while executing the call, the transfers of the nested calls into the address belong to the nested calls.
+type AddressCall struct {
+	TxHash    common.Hash
+	Frame     *CallFrame
+	Transfers []*CallFrame
+}
+
+// TraceBlockByNumber traces the transactions of the block with the callTracer, the transaction hashes are filled from
+// the block for the nodes which don't return them.
+func TraceBlockByNumber(ctx context.Context, cli *rpc.Client, blockNumber uint64) ([]TxTrace, error) {
+	var traces []TxTrace
+	var block struct {
+		Transactions []common.Hash `json:"transactions"`
+	}
+	number := hexutil.EncodeUint64(blockNumber)
+	reqs := []rpc.BatchElem{
+		{
+			Method: "debug_traceBlockByNumber",
+			Args:   []interface{}{number, map[string]interface{}{"tracer": "callTracer"}},
+			Result: &traces,
+		},
+		{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{number, false},
+			Result: &block,
+		},
+	}
+	if err := cli.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		if req.Error != nil {
+			return nil, fmt.Errorf("%s of block %d failed, err: %w", req.Method, blockNumber, req.Error)
+		}
+	}
+	if len(traces) != len(block.Transactions) {
+		return nil, fmt.Errorf("trace count %d doesn't match transaction count %d of block %d", len(traces), len(block.Transactions), blockNumber)
+	}
+
+	for i := range traces {
+		if traces[i].Error != "" {
+			return nil, fmt.Errorf("trace transaction %v of block %d failed, err: %s", block.Transactions[i].Hex(), blockNumber, traces[i].Error)
+		}
+		traces[i].TxHash = block.Transactions[i]
+	}
+	return traces, nil
+}
+
+// AddressCalls returns the calls into the address in the traces.
+func AddressCalls(traces []TxTrace, address common.Address) []*AddressCall {
+	var calls []*AddressCall
+	for _, trace := range traces {
+		if trace.Result == nil {
+			continue
+		}
+		calls = collectAddressCalls(calls, trace.TxHash, trace.Result, address, nil)
+	}
+	return calls
+}
+
+func collectAddressCalls(calls []*AddressCall, txHash common.Hash, frame *CallFrame, address common.Address, current *AddressCall) []*AddressCall {
+	if frame.Reverted() {
+		return calls
+	}
+
+	if frame.To == address && frame.Type != "DELEGATECALL" && frame.Type != "STATICCALL" {
+		current = &AddressCall{TxHash: txHash, Frame: frame}
+		calls = append(calls, current)
+	} else if current != nil && frame.From == address && frame.TransferredValue().Sign() > 0 {
+		current.Transfers = append(current.Transfers, frame)
+	}
+
+	for i := range frame.Calls {
+		calls = collectAddressCalls(calls, txHash, &frame.Calls[i], address, current)
+	}
+	return calls
+}
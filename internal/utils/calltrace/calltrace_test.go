@@ -0,0 +1,129 @@
+package calltrace
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	messenger = common.HexToAddress("0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367")
+	relayer   = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	target    = common.HexToAddress("0x0000000000000000000000000000000000000002")
+	feeVault  = common.HexToAddress("0x0000000000000000000000000000000000000003")
+	txHash0   = common.HexToHash("0x01")
+	txHash1   = common.HexToHash("0x02")
+)
+
+// standInDebugAPI serves the traces of a single block in place of a traced node.
+type standInDebugAPI struct {
+	traces json.RawMessage
+}
+
+func (api *standInDebugAPI) TraceBlockByNumber(number hexutil.Uint64, config map[string]interface{}) (json.RawMessage, error) {
+	return api.traces, nil
+}
+
+type standInEthAPI struct {
+	txHashes []common.Hash
+}
+
+func (api *standInEthAPI) GetBlockByNumber(number hexutil.Uint64, fullTx bool) (map[string]interface{}, error) {
+	return map[string]interface{}{"transactions": api.txHashes}, nil
+}
+
+func value(v int64) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(v))
+}
+
+func newStandInClient(t *testing.T, traces []TxTrace, txHashes []common.Hash) *rpc.Client {
+	// the stand-in node doesn't return the transaction hashes like the older nodes.
+	for i := range traces {
+		traces[i].TxHash = common.Hash{}
+	}
+	data, err := json.Marshal(traces)
+	assert.NoError(t, err)
+
+	server := rpc.NewServer()
+	assert.NoError(t, server.RegisterName("debug", &standInDebugAPI{traces: data}))
+	assert.NoError(t, server.RegisterName("eth", &standInEthAPI{txHashes: txHashes}))
+	t.Cleanup(server.Stop)
+	return rpc.DialInProc(server)
+}
+
+func TestTraceBlockByNumber(t *testing.T) {
+	relay := TxTrace{Result: &CallFrame{
+		Type: "CALL", From: relayer, To: messenger, Value: value(0),
+		Calls: []CallFrame{
+			{Type: "CALL", From: messenger, To: target, Value: value(100)},
+		},
+	}}
+	cli := newStandInClient(t, []TxTrace{relay}, []common.Hash{txHash0})
+	defer cli.Close()
+
+	traces, err := TraceBlockByNumber(context.Background(), cli, 10)
+	assert.NoError(t, err)
+	assert.Len(t, traces, 1)
+	assert.Equal(t, txHash0, traces[0].TxHash)
+	assert.Equal(t, target, traces[0].Result.Calls[0].To)
+	assert.Equal(t, big.NewInt(100), traces[0].Result.Calls[0].TransferredValue())
+
+	cli = newStandInClient(t, []TxTrace{relay}, []common.Hash{txHash0, txHash1})
+	defer cli.Close()
+	_, err = TraceBlockByNumber(context.Background(), cli, 10)
+	assert.Error(t, err)
+}
+
+func TestAddressCalls(t *testing.T) {
+	traces := []TxTrace{
+		{
+			TxHash: txHash0,
+			Result: &CallFrame{
+				Type: "CALL", From: relayer, To: messenger, Value: value(0),
+				Calls: []CallFrame{
+					{
+						Type: "DELEGATECALL", From: messenger, To: common.HexToAddress("0x04"), Value: value(0),
+						Calls: []CallFrame{
+							{
+								Type: "CALL", From: messenger, To: target, Value: value(100),
+								Calls: []CallFrame{
+									// the target sends a message back in the relay.
+									{
+										Type: "CALL", From: target, To: messenger, Value: value(30),
+										Calls: []CallFrame{{Type: "CALL", From: messenger, To: feeVault, Value: value(10)}},
+									},
+								},
+							},
+							{Type: "CALL", From: messenger, To: feeVault, Value: value(5), Error: "execution reverted"},
+							{Type: "STATICCALL", From: messenger, To: feeVault},
+						},
+					},
+				},
+			},
+		},
+		{
+			TxHash: txHash1,
+			Result: &CallFrame{Type: "CALL", From: relayer, To: target, Value: value(1)},
+		},
+	}
+
+	calls := AddressCalls(traces, messenger)
+	assert.Len(t, calls, 2)
+
+	assert.Equal(t, txHash0, calls[0].TxHash)
+	assert.Equal(t, relayer, calls[0].Frame.From)
+	assert.Len(t, calls[0].Transfers, 1)
+	assert.Equal(t, target, calls[0].Transfers[0].To)
+	assert.Equal(t, big.NewInt(100), calls[0].Transfers[0].TransferredValue())
+
+	assert.Equal(t, target, calls[1].Frame.From)
+	assert.Len(t, calls[1].Transfers, 1)
+	assert.Equal(t, feeVault, calls[1].Transfers[0].To)
+	assert.Equal(t, big.NewInt(10), calls[1].Transfers[0].TransferredValue())
+}
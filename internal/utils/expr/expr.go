@@ -0,0 +1,177 @@
+// Package expr implements the small expression language of the configurable checks. An expression is made of
+// numbers, strings, booleans, hex literals and variables, combined with the operators below, ordered by precedence:
+//
+//	||
+//	&&
+//	!
+//	== != < <= > >=
+//	+ -
+//	* /
+//	unary -
+//
+// Numbers are decimals, hex literals such as addresses and hashes are strings, and strings compare equal
+// case-insensitively so checksummed and lowercase addresses match. Functions are called as name(args...).
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Func is a function callable from an expression.
+type Func func(args ...interface{}) (interface{}, error)
+
+// Env holds the variables and the functions an expression is evaluated with, the builtin functions are used
+// when a function isn't found in Funcs.
+type Env struct {
+	Vars  map[string]interface{}
+	Funcs map[string]Func
+}
+
+// ErrUndefined is returned when an expression refers to a variable missing from the env.
+var ErrUndefined = errors.New("undefined variable")
+
+// Expression is a parsed expression.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Parse parses the expression source.
+func Parse(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %q at %d", p.peek().text, p.peek().pos)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// MustParse is like Parse but panics if the source can't be parsed.
+func MustParse(source string) *Expression {
+	e, err := Parse(source)
+	if err != nil {
+		panic(fmt.Sprintf("expr: parse %q failed: %v", source, err))
+	}
+	return e
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Variables returns the names of the variables the expression refers to.
+func (e *Expression) Variables() []string {
+	seen := make(map[string]bool)
+	var names []string
+	walk(e.root, func(n node) {
+		if v, ok := n.(*varNode); ok && !seen[v.name] {
+			seen[v.name] = true
+			names = append(names, v.name)
+		}
+	})
+	return names
+}
+
+// Eval evaluates the expression, the result is a decimal.Decimal, a string or a bool.
+func (e *Expression) Eval(env Env) (interface{}, error) {
+	return e.root.eval(env)
+}
+
+// EvalBool evaluates the expression and requires a bool result.
+func (e *Expression) EvalBool(env Env) (bool, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression %q is %s, not bool", e.source, typeName(v))
+	}
+	return b, nil
+}
+
+// Normalize converts a go value to an expression value: integers, big numbers and decimals become decimals,
+// fmt.Stringer values such as addresses and hashes become strings.
+func Normalize(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case decimal.Decimal, string, bool:
+		return x, nil
+	case int:
+		return decimal.NewFromInt(int64(x)), nil
+	case int64:
+		return decimal.NewFromInt(x), nil
+	case uint64:
+		return decimal.NewFromBigInt(new(big.Int).SetUint64(x), 0), nil
+	case *big.Int:
+		if x == nil {
+			return nil, errors.New("nil big int")
+		}
+		return decimal.NewFromBigInt(x, 0), nil
+	case fmt.Stringer:
+		return x.String(), nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
+
+var builtins = map[string]Func{
+	"lower": func(args ...interface{}) (interface{}, error) {
+		s, err := stringArg("lower", args)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToLower(s), nil
+	},
+	"upper": func(args ...interface{}) (interface{}, error) {
+		s, err := stringArg("upper", args)
+		if err != nil {
+			return nil, err
+		}
+		return strings.ToUpper(s), nil
+	},
+	"abs": func(args ...interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("abs takes 1 argument, got %d", len(args))
+		}
+		d, ok := args[0].(decimal.Decimal)
+		if !ok {
+			return nil, fmt.Errorf("abs argument is %s, not number", typeName(args[0]))
+		}
+		return d.Abs(), nil
+	},
+}
+
+func stringArg(name string, args []interface{}) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("%s takes 1 argument, got %d", name, len(args))
+	}
+	s, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("%s argument is %s, not string", name, typeName(args[0]))
+	}
+	return s, nil
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case decimal.Decimal:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	}
+	return fmt.Sprintf("%T", v)
+}
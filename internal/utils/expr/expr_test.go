@@ -0,0 +1,147 @@
+package expr
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEval(t *testing.T) {
+	env := Env{
+		Vars: map[string]interface{}{
+			"paused":      false,
+			"counterpart": common.HexToAddress("0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367"),
+			"result":      big.NewInt(100),
+			"previous":    uint64(99),
+			"amount":      decimal.RequireFromString("1.5"),
+		},
+	}
+
+	tests := []struct {
+		source string
+		want   interface{}
+	}{
+		{"paused == false", true},
+		{"!paused && result > previous", true},
+		{"counterpart == 0x6774bcbd5cecef1336b5300fb5186a12ddd8b367", true},
+		{"counterpart != '0x6774Bcbd5ceCeF1336b5300fb5186a12DDD8b367'", false},
+		{"result >= previous + 2 || paused", false},
+		{"result - previous * 2 == -98", true},
+		{"(result - previous) * 2", decimal.NewFromInt(2)},
+		{"amount * 2 == 3", true},
+		{"result / 8 == 12.5", true},
+		{"abs(previous - result) == 1", true},
+		{"lower(\"ABC\") + upper('d')", "abcD"},
+	}
+
+	for _, test := range tests {
+		e, err := Parse(test.source)
+		assert.NoError(t, err, test.source)
+		got, err := e.Eval(env)
+		assert.NoError(t, err, test.source)
+		if want, ok := test.want.(decimal.Decimal); ok {
+			assert.True(t, want.Equal(got.(decimal.Decimal)), test.source)
+			continue
+		}
+		assert.Equal(t, test.want, got, test.source)
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	for _, source := range []string{"", "1 +", "(1", "1 2", "'abc", "a == #"} {
+		_, err := Parse(source)
+		assert.Error(t, err, source)
+	}
+
+	env := Env{Vars: map[string]interface{}{"a": true}}
+	_, err := MustParse("b == 1").Eval(env)
+	assert.True(t, errors.Is(err, ErrUndefined))
+
+	for _, source := range []string{"a + 1", "a < true", "1 == 'x'", "1 / 0", "a && 1", "f(1)"} {
+		_, evalErr := MustParse(source).Eval(env)
+		assert.Error(t, evalErr, source)
+	}
+
+	_, err = MustParse("1 + 1").EvalBool(env)
+	assert.Error(t, err)
+}
+
+func TestFuncsAndVariables(t *testing.T) {
+	e := MustParse("sum(amount, 10) > limit && token == 'USDC'")
+	assert.Equal(t, []string{"amount", "limit", "token"}, e.Variables())
+
+	env := Env{
+		Vars: map[string]interface{}{"amount": 5, "limit": 12, "token": "usdc"},
+		Funcs: map[string]Func{
+			"sum": func(args ...interface{}) (interface{}, error) {
+				total := decimal.Zero
+				for _, arg := range args {
+					total = total.Add(arg.(decimal.Decimal))
+				}
+				return total, nil
+			},
+		},
+	}
+	ok, err := e.EvalBool(env)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestPrecedence(t *testing.T) {
+	env := Env{Vars: map[string]interface{}{"a": big.NewInt(2)}}
+
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		// && binds tighter than ||.
+		{"true || false && false", true},
+		{"false && false || true", true},
+		// ! binds tighter than && and looser than the comparisons.
+		{"!false && false", false},
+		{"!1 == 2", true},
+		// the comparisons bind looser than the arithmetic.
+		{"1 + 2 == 3 && 2 * 2 == 4", true},
+		{"1 + 2 * 3 == 7", true},
+		{"a * a + a == 6", true},
+		// the arithmetic is left associative.
+		{"10 - 4 - 3 == 3", true},
+		{"12 / 3 / 2 == 2", true},
+		{"-2 * -3 == 6", true},
+		{"--1 == 1", true},
+		// the logical operators short circuit, the right operand isn't evaluated.
+		{"false && undefined", false},
+		{"true || 1", true},
+	}
+
+	for _, test := range tests {
+		got, err := MustParse(test.source).EvalBool(env)
+		assert.NoError(t, err, test.source)
+		assert.Equal(t, test.want, got, test.source)
+	}
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	tests := []struct {
+		source string
+		err    string
+	}{
+		{"1 < 2 < 3", `unexpected "<" at 6`},
+		{"(1 + 2", `expected ")" at end of expression`},
+		{"f(1 2)", `expected ")" at 4, got "2"`},
+		{"1 @ 2", `unexpected "@" at 2`},
+		{"'abc", "unterminated string at 0"},
+		{"1 +", "unexpected end of expression"},
+		{")", `unexpected ")" at 0`},
+		{"1.2.3", `invalid number "1.2.3" at 0`},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.source)
+		assert.EqualError(t, err, test.err, test.source)
+	}
+}
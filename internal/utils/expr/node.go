@@ -0,0 +1,246 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type node interface {
+	eval(env Env) (interface{}, error)
+}
+
+func walk(n node, fn func(node)) {
+	fn(n)
+	switch x := n.(type) {
+	case *logicalNode:
+		walk(x.left, fn)
+		walk(x.right, fn)
+	case *compareNode:
+		walk(x.left, fn)
+		walk(x.right, fn)
+	case *arithNode:
+		walk(x.left, fn)
+		walk(x.right, fn)
+	case *notNode:
+		walk(x.operand, fn)
+	case *negNode:
+		walk(x.operand, fn)
+	case *callNode:
+		for _, arg := range x.args {
+			walk(arg, fn)
+		}
+	}
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(Env) (interface{}, error) {
+	return n.value, nil
+}
+
+type varNode struct {
+	name string
+}
+
+func (n *varNode) eval(env Env) (interface{}, error) {
+	v, ok := env.Vars[n.name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUndefined, n.name)
+	}
+	return Normalize(v)
+}
+
+type callNode struct {
+	name string
+	args []node
+}
+
+func (n *callNode) eval(env Env) (interface{}, error) {
+	fn, ok := env.Funcs[n.name]
+	if !ok {
+		fn, ok = builtins[n.name]
+	}
+	if !ok {
+		return nil, fmt.Errorf("undefined function: %s", n.name)
+	}
+	args := make([]interface{}, 0, len(n.args))
+	for _, arg := range n.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, v)
+	}
+	v, err := fn(args...)
+	if err != nil {
+		return nil, err
+	}
+	return Normalize(v)
+}
+
+type logicalNode struct {
+	op          string
+	left, right node
+}
+
+func (n *logicalNode) eval(env Env) (interface{}, error) {
+	left, err := evalBool(n.left, env, n.op)
+	if err != nil {
+		return nil, err
+	}
+	if (n.op == "||" && left) || (n.op == "&&" && !left) {
+		return left, nil
+	}
+	return evalBool(n.right, env, n.op)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n *notNode) eval(env Env) (interface{}, error) {
+	v, err := evalBool(n.operand, env, "!")
+	if err != nil {
+		return nil, err
+	}
+	return !v, nil
+}
+
+func evalBool(n node, env Env, op string) (bool, error) {
+	v, err := n.eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("operand of %s is %s, not bool", op, typeName(v))
+	}
+	return b, nil
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n *compareNode) eval(env Env) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmp int
+	switch l := left.(type) {
+	case decimal.Decimal:
+		r, ok := right.(decimal.Decimal)
+		if !ok {
+			return nil, fmt.Errorf("compare number with %s", typeName(right))
+		}
+		cmp = l.Cmp(r)
+	case string:
+		r, ok := right.(string)
+		if !ok {
+			return nil, fmt.Errorf("compare string with %s", typeName(right))
+		}
+		if n.op == "==" || n.op == "!=" {
+			return strings.EqualFold(l, r) == (n.op == "=="), nil
+		}
+		cmp = strings.Compare(l, r)
+	case bool:
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("compare bool with %s", typeName(right))
+		}
+		if n.op != "==" && n.op != "!=" {
+			return nil, fmt.Errorf("bool doesn't support %s", n.op)
+		}
+		return (l == r) == (n.op == "=="), nil
+	default:
+		return nil, fmt.Errorf("compare unsupported %s", typeName(left))
+	}
+
+	switch n.op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+type arithNode struct {
+	op          string
+	left, right node
+}
+
+func (n *arithNode) eval(env Env) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if l, ok := left.(string); ok && n.op == "+" {
+		r, rOK := right.(string)
+		if !rOK {
+			return nil, fmt.Errorf("add string with %s", typeName(right))
+		}
+		return l + r, nil
+	}
+
+	l, lOK := left.(decimal.Decimal)
+	r, rOK := right.(decimal.Decimal)
+	if !lOK || !rOK {
+		return nil, fmt.Errorf("operands of %s are %s and %s, not numbers", n.op, typeName(left), typeName(right))
+	}
+	switch n.op {
+	case "+":
+		return l.Add(r), nil
+	case "-":
+		return l.Sub(r), nil
+	case "*":
+		return l.Mul(r), nil
+	case "/":
+		if r.IsZero() {
+			return nil, errors.New("division by zero")
+		}
+		return l.Div(r), nil
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+type negNode struct {
+	operand node
+}
+
+func (n *negNode) eval(env Env) (interface{}, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	d, ok := v.(decimal.Decimal)
+	if !ok {
+		return nil, fmt.Errorf("operand of - is %s, not number", typeName(v))
+	}
+	return d.Neg(), nil
+}
@@ -46,6 +46,21 @@ func (w *WithdrawTrie) Initialize(currentMessageNonce uint64, msgHash common.Has
 	w.NextMessageNonce = currentMessageNonce + 1
 }
 
+// InitializeFromBranches will initialize the merkle trie with the frontier branches returned by Branches
+func (w *WithdrawTrie) InitializeFromBranches(nextMessageNonce uint64, branches []common.Hash) {
+	w.branches = make([]common.Hash, MaxHeight)
+	copy(w.branches, branches)
+	w.height = len(branches) - 1
+	w.NextMessageNonce = nextMessageNonce
+}
+
+// Branches returns the frontier branches of the withdraw trie, the last branch is the root.
+func (w *WithdrawTrie) Branches() []common.Hash {
+	branches := make([]common.Hash, w.height+1)
+	copy(branches, w.branches)
+	return branches
+}
+
 // AppendMessages appends a list of new messages as leaf nodes to the rightest of the tree and returns the proofs for all messages.
 func (w *WithdrawTrie) AppendMessages(hashes []common.Hash) [][]byte {
 	length := len(hashes)
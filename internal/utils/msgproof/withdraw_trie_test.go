@@ -173,6 +173,29 @@ func TestWithdrawTrieMultiple(t *testing.T) {
 	}
 }
 
+func TestWithdrawTrieInitializeFromBranches(t *testing.T) {
+	var hashes []common.Hash
+	for i := 0; i < 64; i++ {
+		hashes = append(hashes, common.BigToHash(big.NewInt(int64(i+1))))
+	}
+
+	for initial := 0; initial < 32; initial++ {
+		withdrawTrie := NewWithdrawTrie()
+		withdrawTrie.AppendMessages(hashes[:initial])
+
+		recoveredTrie := NewWithdrawTrie()
+		recoveredTrie.InitializeFromBranches(withdrawTrie.NextMessageNonce, withdrawTrie.Branches())
+		assert.Equal(t, withdrawTrie.MessageRoot().String(), recoveredTrie.MessageRoot().String())
+
+		for finish := initial; finish < 64; finish++ {
+			withdrawTrie.AppendMessages(hashes[finish : finish+1])
+			recoveredTrie.AppendMessages(hashes[finish : finish+1])
+			assert.Equal(t, computeMerkleRoot(hashes[:finish+1]).String(), recoveredTrie.MessageRoot().String())
+			assert.Equal(t, withdrawTrie.Branches(), recoveredTrie.Branches())
+		}
+	}
+}
+
 func verifyMerkleProof(index uint64, leaf common.Hash, proof []common.Hash) common.Hash {
 	root := leaf
 	for _, h := range proof {
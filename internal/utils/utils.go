@@ -99,6 +99,11 @@ func GetL2WithdrawRootsForBlocks(ctx context.Context, cli *rpc.Client, queueAddr
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return nil, fmt.Errorf("get withdraw root of block %d failed, err: %w", blockNumbers[i], reqs[i].Error)
+		}
+	}
 	withdrawRootsMap := make(map[uint64]common.Hash)
 	for i, withdrawRoot := range withdrawRoots {
 		withdrawRootsMap[blockNumbers[i]] = withdrawRoot
@@ -106,6 +111,43 @@ func GetL2WithdrawRootsForBlocks(ctx context.Context, cli *rpc.Client, queueAddr
 	return withdrawRootsMap, nil
 }
 
+// GetBalancesForBlocks gets batch balances of the address at a specific array of blocks from the geth node.
+func GetBalancesForBlocks(ctx context.Context, cli *rpc.Client, address common.Address, blockNumbers []uint64) (map[uint64]*big.Int, error) {
+	numbers := len(blockNumbers)
+	balances := make([]hexutil.Big, numbers)
+	reqs := make([]rpc.BatchElem, numbers)
+	for i, blockNumber := range blockNumbers {
+		reqs[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{address, hexutil.EncodeUint64(blockNumber)},
+			Result: &balances[i],
+		}
+	}
+	parallels := 8
+	eg := errgroup.Group{}
+	eg.SetLimit(parallels)
+	for i := 0; i < numbers; i += parallels {
+		start := i
+		end := mathutil.Min(start+parallels, len(reqs))
+		eg.Go(func() error {
+			return cli.BatchCallContext(ctx, reqs[start:end])
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return nil, fmt.Errorf("get balance of block %d failed, err: %w", blockNumbers[i], reqs[i].Error)
+		}
+	}
+	balancesMap := make(map[uint64]*big.Int)
+	for i := range balances {
+		balancesMap[blockNumbers[i]] = balances[i].ToInt()
+	}
+	return balancesMap, nil
+}
+
 // UnpackLog unpacks a retrieved log into the provided output structure.
 func UnpackLog(c *abi.ABI, out interface{}, event string, log types.Log) error {
 	if log.Topics[0] != c.Events[event].ID {